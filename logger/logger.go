@@ -1,8 +1,12 @@
 package logger
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
 
 	"gopkg.in/natefinch/lumberjack.v2"
 )
@@ -11,14 +15,42 @@ var (
 	Info  *log.Logger
 	Error *log.Logger
 	Debug *log.Logger
+
+	// FilePath is the log file set by InitLogger, used by diagnostics
+	FilePath string
+
+	// output is the rotating log file, kept so SetLevel can turn loggers back on
+	output io.Writer
+
+	// startup holds what is logged before InitLogger opens the log file, e.g. while reading
+	// the config that says where the log goes. InitLogger writes it to the file.
+	startup bytes.Buffer
 )
 
-// InitLogger sets up logging to file with automatic rotation
-func InitLogger(logFilePath string) {
-	// Create logs directory if it doesn't exist
-	if err := os.MkdirAll("logs", 0755); err != nil {
-		log.Fatal("Failed to create logs directory:", err)
+const logFlags = log.Ldate | log.Ltime | log.Lshortfile
+
+func init() {
+	Info = log.New(&startup, "INFO: ", logFlags)
+	Error = log.New(&startup, "ERROR: ", logFlags)
+	Debug = log.New(&startup, "DEBUG: ", logFlags)
+}
+
+// InitLogger sets up logging to file with automatic rotation. It returns an error, and leaves
+// logging as it was, if the log file's directory can't be created or the file can't be written.
+func InitLogger(logFilePath string) error {
+	// Create the log file's directory if it doesn't exist
+	dir := filepath.Dir(logFilePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("cannot create log directory %s: %v", dir, err)
+	}
+	// lumberjack only opens the file on the first write, so check it can be written now
+	f, err := os.OpenFile(logFilePath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot write log file %s: %v", logFilePath, err)
 	}
+	f.Close()
+
+	FilePath = logFilePath
 
 	// Set up log rotation
 	logFile := &lumberjack.Logger{
@@ -29,8 +61,31 @@ func InitLogger(logFilePath string) {
 		Compress:   true, // compress old log files
 	}
 
+	output = logFile
+	logFile.Write(startup.Bytes())
+	startup.Reset()
+
 	// Initialize loggers with different prefixes (writing only to file)
-	Info = log.New(logFile, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile)
-	Error = log.New(logFile, "ERROR: ", log.Ldate|log.Ltime|log.Lshortfile)
-	Debug = log.New(logFile, "DEBUG: ", log.Ldate|log.Ltime|log.Lshortfile)
+	Info = log.New(logFile, "INFO: ", logFlags)
+	Error = log.New(logFile, "ERROR: ", logFlags)
+	Debug = log.New(logFile, "DEBUG: ", logFlags)
+	return nil
+}
+
+// SetLevel silences the loggers below level: "debug" writes everything, "info" drops
+// Debug and "error" keeps only Error. Unknown levels are treated as "info".
+func SetLevel(level string) {
+	if output == nil {
+		return
+	}
+	Info.SetOutput(output)
+	Debug.SetOutput(output)
+	switch level {
+	case "debug":
+	case "error":
+		Info.SetOutput(io.Discard)
+		Debug.SetOutput(io.Discard)
+	default:
+		Debug.SetOutput(io.Discard)
+	}
 }
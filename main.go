@@ -1,25 +1,104 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
 	"lms-tui/logger"
 	"lms-tui/pkg"
 	"lms-tui/ui"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"time"
-	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
+// Command-line flags override the defaults and config.json, so one build can run against
+// the production share, a test directory or another lab's setup. Relative paths are taken
+// from the working directory, as usual for flags.
+var (
+	configFlag = flag.String("config", "", "config file (default: config.json in the app directory)")
+	logFlag    = flag.String("log", "", "log file (default: lms.log in config.json's log_dir, or logs/ in the app directory)")
+	rootFlag   = flag.String("root", "", "project root (overrides project_root in config.json)")
+	batchFlag  = flag.String("batch", "", "run a command without the TUI, print the result and exit (see below)")
+)
+
+// usage adds the batch commands to the flag defaults
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [flags]\n       %s [flags] -batch <command> [args]\n\nFlags:\n", os.Args[0], os.Args[0])
+	flag.PrintDefaults()
+	fmt.Fprintf(out, "\nBatch commands:\n")
+	for _, command := range pkg.BatchCommands() {
+		fmt.Fprintf(out, "  %-28s %s\n", strings.TrimSpace(command.Name+" "+command.Args), command.Usage)
+	}
+}
+
+// flagPath makes a path given on the command line absolute
+func flagPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
 func main() {
-	// Initialize logging system
-	logger.InitLogger("logs/lms.log")
-	logger.Info.Println("Application starting...")
+	flag.Usage = usage
+	flag.Parse()
 
-	// Load configuration from config.json
-	if err := pkg.LoadConfig("config.json"); err != nil {
+	// Load configuration from config.json first, since it says where the log goes. Config and
+	// logs live next to the executable so the working directory doesn't matter. Anything
+	// logged before InitLogger is held and written to the log file once it is open.
+	logger.Info.Println("Application starting...")
+	configPath := pkg.DefaultConfigPath()
+	if *configFlag != "" {
+		configPath = flagPath(*configFlag)
+	}
+	if err := pkg.LoadConfig(configPath); err != nil {
 		logger.Info.Printf("Failed to load config, using defaults: %v", err)
 	}
 
+	// Initialize logging system. A log named with -log must work; one from log_dir falls
+	// back to the default log if it can't be used.
+	logPath := pkg.LogPath()
+	if *logFlag != "" {
+		logPath = flagPath(*logFlag)
+	}
+	if err := logger.InitLogger(logPath); err != nil {
+		if *logFlag != "" || logPath == pkg.DefaultLogPath() {
+			fmt.Fprintf(os.Stderr, "LMS cannot start: %v\n", err)
+			os.Exit(1)
+		}
+		if fallbackErr := logger.InitLogger(pkg.DefaultLogPath()); fallbackErr != nil {
+			fmt.Fprintf(os.Stderr, "LMS cannot start: %v (and the default log: %v)\n", err, fallbackErr)
+			os.Exit(1)
+		}
+		logger.Error.Printf("WARNING: log_dir in %s can't be used, logging to %s instead: %v", pkg.ConfigPath(), logger.FilePath, err)
+	}
+
+	// Deferred first so it runs last, after the cleanup below
+	defer ui.RecoverFromCrash()
+
+	logger.SetLevel(pkg.Config.LogLevel)
+	// -root is not written into Config, so saving settings doesn't store it in config.json
+	if *rootFlag != "" {
+		pkg.SetProjectRoot(flagPath(*rootFlag))
+	} else if pkg.Config.ProjectRoot != "" {
+		pkg.SetProjectRoot(pkg.ResolveAppPath(pkg.Config.ProjectRoot))
+	}
+	logger.Info.Printf("Config: %s, logs: %s, project root: %s", pkg.ConfigPath(), logger.FilePath, pkg.ProjectRoot)
+
+	// Batch mode runs one command against the same config and files, without a terminal
+	if *batchFlag != "" {
+		if err := pkg.RunBatch(*batchFlag, flag.Args(), os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", *batchFlag, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Prevent screen from sleeping while app is running (Wayland/GNOME)
 	inhibitCmd := exec.Command("gnome-session-inhibit", "--inhibit", "idle", "--reason", "LMS TUI Application Active", "sleep", "infinity")
 	if err := inhibitCmd.Start(); err != nil {
@@ -64,32 +143,60 @@ func main() {
 
 	app := tview.NewApplication()
 
-	// Global input capture for numpad key mappings
+	// Watch the network share so techs know when it drops
+	stopShareStatus := ui.StartShareStatus(app)
+	defer stopShareStatus()
+
+	// Draw the share status line, help overlay and quit prompt on top of whatever screen is showing
+	app.SetAfterDrawFunc(func(screen tcell.Screen) {
+		ui.TrackScreenWidth(screen)
+		ui.DrawShareStatus(screen)
+		ui.DrawHelpOverlay(screen)
+		ui.DrawQuitPrompt(screen)
+	})
+
+	// Global input capture for numpad key mappings (configured in config.json)
+	remapKeys := ui.NewKeyRemapCapture(pkg.Config.KeyRemaps)
 	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyCtrlJ {
-			// Convert Ctrl+J (numpad Enter) to regular Enter
-			return tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+		// While the quit prompt is open it takes every key
+		if ui.QuitPromptVisible() {
+			ui.HandleQuitPromptKey(app, remapKeys(event))
+			return nil
 		}
-		if event.Rune() == '*' {
-			// Convert * to arrow up
-			return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+		// Ctrl+C would otherwise stop the app mid-sample, so both keys quit the same way
+		if event.Key() == tcell.KeyCtrlQ || event.Key() == tcell.KeyCtrlC {
+			ui.RequestQuit(app)
+			return nil
 		}
-		if event.Rune() == '-' {
-			// Convert - to arrow down
-			return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+		// While help is open, only ?/Esc/Enter close it and all other keys are swallowed
+		if ui.HelpVisible() {
+			event = remapKeys(event)
+			if event.Rune() == '?' || event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyEnter {
+				ui.ToggleHelp()
+			}
+			return nil
 		}
-		return event
+		// In a notes or reason field every printable key is typed, including '?' and remapped keys
+		if event.Key() == tcell.KeyRune && ui.TypingText(app) {
+			return event
+		}
+		if event.Rune() == '?' {
+			ui.ToggleHelp()
+			return nil
+		}
+		return remapKeys(event)
 	})
 
 	loginScreen := ui.NewLoginScreen(app, func(userID, pin string) {
-		 if userID == "1234" && pin == "0000" {
+		user, err := pkg.Authenticate(userID, pin)
+		if err == nil {
 			logger.Info.Printf("User logged in: %s", userID)
-			homescreen, homeList := ui.NewHomeScreen(app)
+			homescreen, homeList := ui.NewHomeScreen(app, user)
 			app.SetRoot(homescreen, true)
 			app.SetFocus(homeList)
-		 } else {
-			logger.Info.Printf("Failed login attempt for user: %s", userID)
-		 }
+		} else {
+			logger.Info.Printf("Failed login attempt for user %s: %v", userID, err)
+		}
 	})
 
 
@@ -1,16 +1,23 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Job represents a job/project in the LMS system
 type Job struct {
-	ProjectNumber    string    // Display number (e.g., "25490" or "25490_03")
-	BaseJobNumber    string    // Base job number without suffix (e.g., "25490")
-	LabFilePath      string    // Full path to the Lab file being used
+	ProjectNumber    string // Display number (e.g., "25490" or "25490_03")
+	BaseJobNumber    string // Base job number without suffix (e.g., "25490")
+	FolderName       string // Folder under projects/ the Lab file was found in
+	LabFilePath      string // Full path to the Lab file being used
 	ProjectName      string
 	EngineerInitials string
 	DateAssigned     time.Time
 	DueDate          time.Time
+	OnHold           bool // Paused, e.g. awaiting client info; set from the job folder's .lmsmeta.json
+	HoldReason       string
+	DuplicateFolders []string // Other project folders holding this job number; discovery uses the first by name
 }
 
 // FormatDateAssigned returns the assigned date in MM/DD/YYYY format
@@ -22,3 +29,11 @@ func (j *Job) FormatDateAssigned() string {
 func (j *Job) FormatDueDate() string {
 	return j.DueDate.Format("01/02/2006")
 }
+
+// DuplicateWarning describes the other folders holding this job number, or "" when there are none
+func (j *Job) DuplicateWarning() string {
+	if len(j.DuplicateFolders) == 0 {
+		return ""
+	}
+	return "⚠ Using " + j.FolderName + ", also in " + strings.Join(j.DuplicateFolders, ", ")
+}
@@ -0,0 +1,68 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Where the app keeps its files. Nothing is resolved against the working directory, so the
+// app behaves the same however it is started (desktop launcher, another folder, cron):
+//   - config.json and logs/ live in the app directory, next to the executable; config.json's
+//     log_dir can move the log, relative to the app directory
+//   - job data (projects, ex_project, oven tracking, users.json) lives under ProjectRoot,
+//     which config.json's project_root can move; a relative project_root is taken from
+//     the app directory
+
+// AppDirEnv names an environment variable that overrides the app directory, e.g. for
+// `go run`, whose executable is built in a temp directory
+const AppDirEnv = "LMS_APP_DIR"
+
+// AppDir returns the directory holding config.json and logs/: $LMS_APP_DIR when set,
+// otherwise the directory of the running executable. It only falls back to the working
+// directory if the executable can't be found.
+func AppDir() string {
+	if dir := os.Getenv(AppDirEnv); dir != "" {
+		if abs, err := filepath.Abs(dir); err == nil {
+			return abs
+		}
+		return dir
+	}
+	exe, err := os.Executable()
+	if err == nil {
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			exe = resolved
+		}
+		return filepath.Dir(exe)
+	}
+	if wd, err := os.Getwd(); err == nil {
+		return wd
+	}
+	return "."
+}
+
+// DefaultConfigPath returns the config.json LoadConfig reads at startup
+func DefaultConfigPath() string {
+	return filepath.Join(AppDir(), "config.json")
+}
+
+// DefaultLogPath returns the log file used when log_dir is not set, or can't be written
+func DefaultLogPath() string {
+	return filepath.Join(AppDir(), "logs", "lms.log")
+}
+
+// LogPath returns the log file to write: lms.log in the configured log_dir if set,
+// otherwise DefaultLogPath. Call it after LoadConfig.
+func LogPath() string {
+	if Config.LogDir == "" {
+		return DefaultLogPath()
+	}
+	return filepath.Join(ResolveAppPath(Config.LogDir), "lms.log")
+}
+
+// ResolveAppPath returns path unchanged if it is absolute, otherwise joined to AppDir
+func ResolveAppPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(AppDir(), path)
+}
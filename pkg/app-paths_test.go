@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppPathsIgnoreWorkingDirectory(t *testing.T) {
+	appDir := t.TempDir()
+	t.Setenv(AppDirEnv, appDir)
+	savedConfig, savedPath := Config, loadedConfigPath
+	t.Cleanup(func() { Config, loadedConfigPath = savedConfig, savedPath })
+
+	if err := os.WriteFile(filepath.Join(appDir, "config.json"), []byte(`{"project_root": "data", "log_level": "debug"}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// Start from an unrelated directory that has its own config.json
+	wd := t.TempDir()
+	if err := os.WriteFile(filepath.Join(wd, "config.json"), []byte(`{"log_level": "error"}`), 0644); err != nil {
+		t.Fatalf("failed to write decoy config: %v", err)
+	}
+	t.Chdir(wd)
+
+	if got, want := DefaultConfigPath(), filepath.Join(appDir, "config.json"); got != want {
+		t.Errorf("DefaultConfigPath = %s, want %s", got, want)
+	}
+	if got, want := DefaultLogPath(), filepath.Join(appDir, "logs", "lms.log"); got != want {
+		t.Errorf("DefaultLogPath = %s, want %s", got, want)
+	}
+
+	if err := LoadConfig(DefaultConfigPath()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if Config.LogLevel != "debug" {
+		t.Errorf("loaded log level %q, want the app directory's config, not the working directory's", Config.LogLevel)
+	}
+	if got, want := ConfigPath(), filepath.Join(appDir, "config.json"); got != want {
+		t.Errorf("ConfigPath = %s, want %s", got, want)
+	}
+
+	// A relative project_root is taken from the app directory too
+	if got, want := ResolveAppPath(Config.ProjectRoot), filepath.Join(appDir, "data"); got != want {
+		t.Errorf("ResolveAppPath(%q) = %s, want %s", Config.ProjectRoot, got, want)
+	}
+	if got := ResolveAppPath("/srv/lab"); got != "/srv/lab" {
+		t.Errorf("ResolveAppPath kept absolute path as %s", got)
+	}
+}
+
+func TestLogPathUsesConfiguredLogDir(t *testing.T) {
+	appDir := t.TempDir()
+	t.Setenv(AppDirEnv, appDir)
+	saved := Config.LogDir
+	t.Cleanup(func() { Config.LogDir = saved })
+
+	tests := []struct {
+		logDir, want string
+	}{
+		{"", filepath.Join(appDir, "logs", "lms.log")},
+		{"lab-logs", filepath.Join(appDir, "lab-logs", "lms.log")},
+		{"/var/log/lms", "/var/log/lms/lms.log"},
+	}
+	for _, tt := range tests {
+		Config.LogDir = tt.logDir
+		if got := LogPath(); got != tt.want {
+			t.Errorf("LogPath with log_dir %q = %s, want %s", tt.logDir, got, tt.want)
+		}
+	}
+}
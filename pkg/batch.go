@@ -0,0 +1,278 @@
+package pkg
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"lms-tui/logger"
+	"lms-tui/models"
+)
+
+// BatchCommand is an operation that can run without the TUI, e.g. from cron
+type BatchCommand struct {
+	Name  string
+	Args  string // Argument synopsis for usage, e.g. "<job>"
+	Usage string
+	run   func(args []string, out io.Writer) error
+}
+
+// batchCommands are the operations RunBatch knows, in the order usage lists them
+var batchCommands = []BatchCommand{
+	{"jobs", "", "List the jobs in the projects folder", batchJobs},
+	{"backup-csv", "<job>", "Write the job's backup.json as CSV", batchBackupCSV},
+	{"export-moisture", "<job>", "Export the job's moisture results to an Excel file", batchExportMoisture},
+	{"morning-worksheet", "", "Export the cans in the oven as a morning worksheet", batchMorningWorksheet},
+	{"rebuild", "<job>", "Rewrite the job's Moisture sheet cells in its working Lab file from backup.json", batchRebuild},
+}
+
+// BatchCommands returns the operations RunBatch knows, for usage text
+func BatchCommands() []BatchCommand {
+	return batchCommands
+}
+
+// RunBatch runs the named operation with args and prints its results to out. Config,
+// logging and ProjectRoot must already be set up, as for the TUI.
+func RunBatch(name string, args []string, out io.Writer) error {
+	for _, command := range batchCommands {
+		if command.Name != name {
+			continue
+		}
+		logger.Info.Printf("Running batch command %s %s", name, strings.Join(args, " "))
+		if err := command.run(args, out); err != nil {
+			logger.Error.Printf("Batch command %s failed: %v", name, err)
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown batch command %q", name)
+}
+
+// batchJobArg returns the single job number a command takes
+func batchJobArg(args []string) (string, error) {
+	if len(args) != 1 || strings.TrimSpace(args[0]) == "" {
+		return "", fmt.Errorf("expected one job number")
+	}
+	return strings.TrimSpace(args[0]), nil
+}
+
+// batchJobs prints one tab-separated line per discovered job
+func batchJobs(args []string, out io.Writer) error {
+	jobs, err := DiscoverJobs()
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		status := ""
+		if job.OnHold {
+			status = "on hold"
+		}
+		fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%s\n",
+			job.ProjectNumber, job.ProjectName, job.FormatDueDate(), status, job.LabFilePath)
+	}
+	return nil
+}
+
+// backupCSVHeader names the columns WriteBackupCSV writes
+var backupCSVHeader = []string{
+	"job_number", "boring_number", "depth", "can_number", "can_weight", "wet_weight",
+	"dry_weight", "moisture_content", "suction_can_no", "notes", "skip_reason", "entered_by", "weighed_by", "needs_recheck", "timestamp",
+}
+
+// WriteBackupCSV writes every sample in the job's backup as a CSV row. Moisture content is
+// computed for samples with a dry weight and left blank for the rest.
+func WriteBackupCSV(jobNumber string, out io.Writer) error {
+	backup, err := LoadBackupData(BackupPath(jobNumber))
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(out)
+	w.Write(backupCSVHeader)
+	for _, sample := range backup.Samples {
+		moistureContent := ""
+		wetWtAndCan, wetErr := strconv.ParseFloat(sample.WetWeight, 64)
+		wtOfCan, canErr := strconv.ParseFloat(sample.CanWeight, 64)
+		dryWtAndCan, dryErr := strconv.ParseFloat(sample.DryWeight, 64)
+		if !sample.IsSkipped() && wetErr == nil && canErr == nil && dryErr == nil {
+			_, _, mc := calculateMoisture(wetWtAndCan, wtOfCan, dryWtAndCan)
+			moistureContent = strconv.FormatFloat(mc, 'f', 1, 64)
+		}
+		w.Write([]string{
+			sample.JobNumber, sample.BoringNumber, sample.Depth, sample.CanNumber, sample.CanWeight,
+			sample.WetWeight, sample.DryWeight, moistureContent, sample.SuctionCanNo, sample.Notes,
+			sample.SkipReason, sample.EnteredBy, sample.WeighedBy, strconv.FormatBool(sample.NeedsRecheck), sample.Timestamp,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func batchBackupCSV(args []string, out io.Writer) error {
+	jobNumber, err := batchJobArg(args)
+	if err != nil {
+		return err
+	}
+	return WriteBackupCSV(jobNumber, out)
+}
+
+func batchExportMoisture(args []string, out io.Writer) error {
+	jobNumber, err := batchJobArg(args)
+	if err != nil {
+		return err
+	}
+	path, err := ExportMoistureSheet(jobNumber)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, path)
+	return nil
+}
+
+func batchMorningWorksheet(args []string, out io.Writer) error {
+	path, err := ExportMorningWorksheet()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, path)
+	return nil
+}
+
+// RebuildResult counts what RebuildLabFileFromBackup wrote
+type RebuildResult struct {
+	Samples    int      // Can, wet and can weights written
+	DryWeights int      // Dry weights and their calculations written
+	Notes      int      // Sample notes written
+	Skipped    int      // Recorded as not tested, nothing to write
+	Failed     []string // "Boring|Depth: reason" for samples that couldn't be written
+}
+
+// RebuildLabFileFromBackup writes every sample recorded in the job's backup.json back into
+// its working Lab file's Moisture sheets, e.g. after the working copy was replaced or
+// damaged. The working copy is made from the job's Lab file if it doesn't exist.
+func RebuildLabFileFromBackup(jobNumber string) (*RebuildResult, error) {
+	backup, err := LoadBackupData(BackupPath(jobNumber))
+	if err != nil {
+		return nil, err
+	}
+	if len(backup.Samples) == 0 {
+		return nil, fmt.Errorf("job %s has no samples in %s", jobNumber, BackupPath(jobNumber))
+	}
+
+	job, err := findJob(jobNumber)
+	if err != nil {
+		return nil, err
+	}
+	// A pull session keeps the workbook in memory and would save over the rebuild
+	release, err := tryFileLock(pullSessionLockPath(jobNumber))
+	if errors.Is(err, ErrFileLocked) {
+		return nil, fmt.Errorf("job %s is open for pulling; stop that session before rebuilding", jobNumber)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock job %s for the rebuild: %v", jobNumber, err)
+	}
+	defer release()
+
+	writer, err := InitMoistureTestFile(jobNumber, job.LabFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RebuildResult{}
+	fail := func(sample SampleBackupData, err error) {
+		result.Failed = append(result.Failed, fmt.Sprintf("%s|%s: %v", sample.BoringNumber, sample.Depth, err))
+	}
+
+	var dry []SampleBackupData
+	for _, sample := range backup.Samples {
+		if sample.IsSkipped() {
+			result.Skipped++
+			continue
+		}
+		if err := writer.WriteMoistureSample(sample.BoringNumber, sample.Depth, sample.CanNumber, sample.CanWeight, sample.WetWeight); err != nil {
+			fail(sample, err)
+			continue
+		}
+		result.Samples++
+		if sample.Notes != "" {
+			if err := writer.WriteSampleNote(sample.BoringNumber, sample.Depth, sample.Notes); err != nil {
+				fail(sample, err)
+			} else {
+				result.Notes++
+			}
+		}
+		if sample.DryWeight != "" {
+			dry = append(dry, sample)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close Lab file for job %s: %v", jobNumber, err)
+	}
+
+	// Dry weights are written through the file on disk, after the wet weights they need
+	for _, sample := range dry {
+		sheet, column := sample.MoistureSheet, sample.MoistureColumn
+		if sheet == "" || column == "" {
+			if sheet, column, err = LookupMoistureLocation(jobNumber, job.LabFilePath, sample.BoringNumber, sample.Depth); err != nil {
+				fail(sample, err)
+				continue
+			}
+		}
+		can := OvenCanData{
+			CanNumber:      sample.CanNumber,
+			JobNumber:      jobNumber,
+			BoringNumber:   sample.BoringNumber,
+			Depth:          sample.Depth,
+			MoistureSheet:  sheet,
+			MoistureColumn: column,
+		}
+		if _, err := WriteDryWeightToMoistureSheet(can, sample.DryWeight); err != nil {
+			fail(sample, err)
+			continue
+		}
+		result.DryWeights++
+	}
+
+	logger.Info.Printf("Rebuilt job %s from backup: %d samples, %d dry weights, %d notes, %d skipped, %d failed",
+		jobNumber, result.Samples, result.DryWeights, result.Notes, result.Skipped, len(result.Failed))
+	return result, nil
+}
+
+// findJob returns the discovered job with the given number
+func findJob(jobNumber string) (models.Job, error) {
+	jobs, err := DiscoverJobs()
+	if err != nil {
+		return models.Job{}, err
+	}
+	for _, job := range jobs {
+		if job.ProjectNumber == jobNumber {
+			return job, nil
+		}
+	}
+	return models.Job{}, fmt.Errorf("job %s not found in %s", jobNumber, ProjectsDir())
+}
+
+func batchRebuild(args []string, out io.Writer) error {
+	jobNumber, err := batchJobArg(args)
+	if err != nil {
+		return err
+	}
+	result, err := RebuildLabFileFromBackup(jobNumber)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Rebuilt job %s: %d samples, %d dry weights, %d notes, %d skipped\n",
+		jobNumber, result.Samples, result.DryWeights, result.Notes, result.Skipped)
+	sort.Strings(result.Failed)
+	for _, failure := range result.Failed {
+		fmt.Fprintf(out, "FAILED %s\n", failure)
+	}
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d samples could not be written", len(result.Failed))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package pkg
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+func TestRunBatchBackupCSVAndRebuild(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	srcPath := filepath.Join(root, "projects", "25490", "Lab_25490.xlsm")
+	if err := os.MkdirAll(filepath.Dir(srcPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "Moisture")
+	f.SetCellValue("Moisture", "A9", "Boring No")
+	f.SetCellValue("Moisture", "B9", "B-1")
+	f.SetCellValue("Moisture", "A10", "Depth")
+	f.SetCellValue("Moisture", "B10", "0 - 1")
+	if err := f.SaveAs(srcPath); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if err := SaveSampleBackup("25490", "B-1", "0 - 1", "101", "50", "150", "", "Moisture|9", "B", "", ""); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+	if err := UpdateSampleDryWeight("25490", "B-1", "0 - 1", "130", ""); err != nil {
+		t.Fatalf("UpdateSampleDryWeight failed: %v", err)
+	}
+	if err := SaveSkippedSample("25490", "B-2", "0 - 1", "No sample", ""); err != nil {
+		t.Fatalf("SaveSkippedSample failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := RunBatch("backup-csv", []string{"25490"}, &out); err != nil {
+		t.Fatalf("backup-csv failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "job_number,boring_number,depth,") {
+		t.Fatalf("backup-csv output:\n%s", out.String())
+	}
+	// Water 20 g over 80 g of soil
+	if !strings.HasPrefix(lines[1], "25490,B-1,0 - 1,101,50,150,130,25.0,") {
+		t.Errorf("B-1 row = %s", lines[1])
+	}
+	if !strings.Contains(lines[2], "No sample") {
+		t.Errorf("skipped row = %s", lines[2])
+	}
+
+	// There is no working copy (e.g. it was lost), so rebuild makes one from backup.json
+	if _, err := os.Stat(filepath.Join(root, "ex_project", "25490", "Lab_25490.xlsm")); !os.IsNotExist(err) {
+		t.Fatalf("expected no working copy before rebuild (stat err: %v)", err)
+	}
+	// Not while the job is open for pulling, whose workbook in memory would be saved over it
+	if err := ClaimPullSession("25490"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RunBatch("rebuild", []string{"25490"}, &out); err == nil || !strings.Contains(err.Error(), "open for pulling") {
+		t.Errorf("rebuild during a pull session = %v, want it refused", err)
+	}
+	ReleasePullSession("25490")
+
+	out.Reset()
+	if err := RunBatch("rebuild", []string{"25490"}, &out); err != nil {
+		t.Fatalf("rebuild failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "1 samples, 1 dry weights, 0 notes, 1 skipped") {
+		t.Errorf("rebuild output = %q", out.String())
+	}
+	rebuilt, err := excelize.OpenFile(WorkingLabFilePath("25490"))
+	if err != nil {
+		t.Fatalf("failed to open rebuilt Lab file: %v", err)
+	}
+	defer rebuilt.Close()
+	for cell, want := range map[string]string{"B11": "101", "B12": "150", "B13": "130", "B15": "50", "B17": "25"} {
+		if got, _ := rebuilt.GetCellValue("Moisture", cell); got != want {
+			t.Errorf("rebuilt Moisture!%s = %q, want %q", cell, got, want)
+		}
+	}
+
+	if err := RunBatch("rebuild", nil, &out); err == nil {
+		t.Error("rebuild without a job number should fail")
+	}
+	if err := RunBatch("nope", nil, &out); err == nil {
+		t.Error("an unknown batch command should fail")
+	}
+}
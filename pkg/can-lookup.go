@@ -0,0 +1,123 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"lms-tui/logger"
+)
+
+// CanLocation is one place a can number turns up: a sample in a job's backup, or the oven
+type CanLocation struct {
+	JobNumber    string
+	BoringNumber string
+	Depth        string
+	Suction      bool // Used as the sample's soil suction can rather than its moisture can
+	InOven       bool
+	TimeIn       string // When it went in the oven, if it is in the oven
+	DryWeight    string // Recorded during Morning Count
+	Timestamp    string // When the sample was saved
+}
+
+// Status describes where the can is in the moisture workflow
+func (l CanLocation) Status() string {
+	switch {
+	case l.Suction:
+		return "Suction can"
+	case l.InOven:
+		return "In oven since " + l.TimeIn
+	case l.DryWeight != "":
+		return "Dry weight recorded (" + l.DryWeight + " g)"
+	default:
+		return "Pulled, not in oven"
+	}
+}
+
+// FindCan looks for a can number in every job's backup.json and in oven_tracking.json,
+// so a can that turns up without context can be traced to its job and sample. It is
+// read-only and newest samples come first.
+func FindCan(canNumber string) []CanLocation {
+	locations := []CanLocation{}
+	if canNumber == "" {
+		return locations
+	}
+
+	// Cans in the oven, keyed by sample so backup entries can be marked
+	inOven := map[string]OvenCanData{}
+	tracking, err := LoadOvenTracking()
+	if err != nil {
+		logger.Error.Printf("Can lookup could not read oven tracking: %v", err)
+	} else {
+		for _, can := range tracking.Cans {
+			if can.CanNumber == canNumber {
+				inOven[can.JobNumber+"|"+can.BoringNumber+"|"+can.Depth] = can
+			}
+		}
+	}
+
+	exProjectDir := filepath.Join(ProjectRoot, "ex_project")
+	entries, err := os.ReadDir(exProjectDir)
+	if err != nil && !os.IsNotExist(err) {
+		logger.Error.Printf("Can lookup could not read ex_project directory: %v", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		backupFile := BackupPath(entry.Name())
+		if _, err := os.Stat(backupFile); err != nil {
+			continue
+		}
+		backup, err := LoadBackupData(backupFile)
+		if err != nil {
+			logger.Error.Printf("Skipping %s in can lookup: %v", backupFile, err)
+			continue
+		}
+
+		for _, sample := range backup.Samples {
+			if sample.CanNumber != canNumber && sample.SuctionCanNo != canNumber {
+				continue
+			}
+			location := CanLocation{
+				JobNumber:    entry.Name(),
+				BoringNumber: sample.BoringNumber,
+				Depth:        sample.Depth,
+				Suction:      sample.CanNumber != canNumber,
+				DryWeight:    sample.DryWeight,
+				Timestamp:    sample.Timestamp,
+			}
+			key := location.JobNumber + "|" + location.BoringNumber + "|" + location.Depth
+			if can, ok := inOven[key]; ok && !location.Suction {
+				location.InOven = true
+				location.TimeIn = can.TimeIn
+				delete(inOven, key)
+			}
+			locations = append(locations, location)
+		}
+	}
+
+	// Oven entries whose sample is not in any backup still say where the can came from
+	for _, can := range inOven {
+		locations = append(locations, CanLocation{
+			JobNumber:    can.JobNumber,
+			BoringNumber: can.BoringNumber,
+			Depth:        can.Depth,
+			InOven:       true,
+			TimeIn:       can.TimeIn,
+			Timestamp:    can.TimeIn,
+		})
+	}
+
+	sort.SliceStable(locations, func(i, j int) bool {
+		ti, errI := ParseTimestamp(locations[i].Timestamp)
+		tj, errJ := ParseTimestamp(locations[j].Timestamp)
+		if errI != nil || errJ != nil {
+			return errI == nil
+		}
+		return ti.After(tj)
+	})
+
+	logger.Info.Printf("Can lookup for #%s found %d locations", canNumber, len(locations))
+	return locations
+}
@@ -0,0 +1,51 @@
+package pkg
+
+import (
+	"testing"
+)
+
+func TestFindCan(t *testing.T) {
+	useTempProjectRoot(t)
+
+	// Can 101 is used in two jobs: dried in one, still in the oven in the other.
+	// Can 301 is a suction can, and 401 is in the oven with no backup entry.
+	if err := SaveSampleBackup("25001", "B-1", "0 - 1", "101", "50", "150", "301", "Moisture|9", "B", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateSampleDryWeight("25001", "B-1", "0 - 1", "130", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveSampleBackup("25002", "B-4", "2 - 3", "101", "50", "150", "", "Moisture|9", "C", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddCanToOven("101", "25002", "B-4", "2 - 3", "Moisture|9", "C", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddCanToOven("401", "25003", "B-2", "0 - 1", "Moisture|9", "B", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	byJob := map[string]CanLocation{}
+	for _, location := range FindCan("101") {
+		byJob[location.JobNumber] = location
+	}
+	if len(byJob) != 2 {
+		t.Fatalf("FindCan(101) = %v, want locations in 2 jobs", byJob)
+	}
+	if location := byJob["25001"]; location.InOven || location.DryWeight != "130" || location.Status() != "Dry weight recorded (130 g)" {
+		t.Errorf("25001 location = %+v (%s)", location, location.Status())
+	}
+	if location := byJob["25002"]; !location.InOven || location.BoringNumber != "B-4" || location.Depth != "2 - 3" {
+		t.Errorf("25002 location = %+v", location)
+	}
+
+	if locations := FindCan("301"); len(locations) != 1 || !locations[0].Suction || locations[0].JobNumber != "25001" {
+		t.Errorf("FindCan(301) = %+v, want the suction can in 25001", locations)
+	}
+	if locations := FindCan("401"); len(locations) != 1 || !locations[0].InOven || locations[0].JobNumber != "25003" {
+		t.Errorf("FindCan(401) = %+v, want the oven entry for 25003", locations)
+	}
+	if locations := FindCan("999"); len(locations) != 0 {
+		t.Errorf("FindCan(999) = %+v, want none", locations)
+	}
+}
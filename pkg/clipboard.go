@@ -0,0 +1,83 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"lms-tui/logger"
+)
+
+// clipboardCommand returns Config.ClipboardCommand, or wl-copy on Wayland and
+// xclip everywhere else when it is not set
+func clipboardCommand() []string {
+	if command := strings.Fields(Config.ClipboardCommand); len(command) > 0 {
+		return command
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		return []string{"wl-copy"}
+	}
+	return []string{"xclip", "-selection", "clipboard"}
+}
+
+// clipboardExitWait is how long CopyToClipboard waits for the clipboard command to fail,
+// e.g. xclip with no display, before taking it that the copy worked
+const clipboardExitWait = 500 * time.Millisecond
+
+// CopyToClipboard puts text on the system clipboard by piping it to the clipboard command
+func CopyToClipboard(text string) error {
+	command := clipboardCommand()
+	if _, err := exec.LookPath(command[0]); err != nil {
+		logger.Error.Printf("Clipboard command '%s' is not available: %v", command[0], err)
+		return fmt.Errorf("'%s' is not available on this machine, so nothing was copied", command[0])
+	}
+
+	// xclip and wl-copy leave a process running to serve the clipboard, which would hold an
+	// output pipe open, so stderr goes to a file and Wait returns when the command exits
+	stderr, err := os.CreateTemp("", "lms-clipboard-*.log")
+	if err != nil {
+		logger.Error.Printf("Failed to copy to clipboard: %v", err)
+		return fmt.Errorf("failed to copy to clipboard: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+	defer stderr.Close()
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		logger.Error.Printf("Failed to copy to clipboard: %v", err)
+		return fmt.Errorf("failed to copy to clipboard: %v", err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	select {
+	case err := <-exited:
+		if err != nil {
+			output, _ := os.ReadFile(stderr.Name())
+			detail := strings.TrimSpace(string(output))
+			logger.Error.Printf("Clipboard command '%s' failed: %v: %s", command[0], err, detail)
+			if detail == "" {
+				detail = err.Error()
+			}
+			return fmt.Errorf("failed to copy to clipboard: %s", detail)
+		}
+	case <-time.After(clipboardExitWait):
+		// Still running, serving the clipboard
+	}
+
+	logger.Info.Printf("Copied to clipboard: %s", text)
+	return nil
+}
+
+// SampleClipboardText is how a sample is copied for cross-referencing with its tag
+func SampleClipboardText(jobNumber string, sample SampleData) string {
+	tests := strings.Join(sample.Tests, ", ")
+	if tests == "" {
+		tests = "-"
+	}
+	return fmt.Sprintf("Job %s  Boring %s  Depth %s  Tests: %s", jobNumber, sample.BoringNumber, sample.Depth, tests)
+}
@@ -0,0 +1,49 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCopyToClipboard(t *testing.T) {
+	root := useTempProjectRoot(t)
+	original := Config.ClipboardCommand
+	t.Cleanup(func() { Config.ClipboardCommand = original })
+
+	// tee stands in for the clipboard tool: it reads the text on stdin like xclip does
+	clipboardFile := filepath.Join(root, "clipboard.txt")
+	Config.ClipboardCommand = "tee " + clipboardFile
+	text := SampleClipboardText("25490", SampleData{BoringNumber: "B-1", Depth: "0 - 1", Tests: []string{"Moisture Content", "Soil Suction"}})
+	if err := CopyToClipboard(text); err != nil {
+		t.Fatalf("CopyToClipboard failed: %v", err)
+	}
+	// The command runs in the background, so give it a moment to write
+	want := "Job 25490  Boring B-1  Depth 0 - 1  Tests: Moisture Content, Soil Suction"
+	var data []byte
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if data, _ = os.ReadFile(clipboardFile); string(data) == want {
+			break
+		}
+	}
+	if string(data) != want {
+		t.Errorf("clipboard = %q, want %q", data, want)
+	}
+
+	Config.ClipboardCommand = "no-such-clipboard-tool"
+	if err := CopyToClipboard(text); err == nil {
+		t.Error("expected an error when the clipboard tool is missing")
+	}
+
+	// A tool that fails straight away, like xclip with no display, is reported with its message
+	failing := filepath.Join(root, "failing-clipboard")
+	if err := os.WriteFile(failing, []byte("#!/bin/sh\necho \"Error: Can't open display\" >&2\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	Config.ClipboardCommand = failing
+	if err := CopyToClipboard(text); err == nil || !strings.Contains(err.Error(), "Can't open display") {
+		t.Errorf("CopyToClipboard with a failing tool = %v, want its error message", err)
+	}
+}
@@ -2,35 +2,217 @@ package pkg
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"lms-tui/logger"
 )
 
 // AppConfig holds all application configuration settings
 type AppConfig struct {
-	CheckDuplicateCans       bool   `json:"check_duplicate_cans"`
-	AutoSaveIntervalSeconds  int    `json:"auto_save_interval_seconds"`
-	MaxSamplesPerJob         int    `json:"max_samples_per_job"`
-	EnableNumericValidation  bool   `json:"enable_numeric_validation"`
-	BackupOnSave             bool   `json:"backup_on_save"`
-	LogLevel                 string `json:"log_level"`
+	CheckDuplicateCans      bool         `json:"check_duplicate_cans"`
+	CheckSuctionCanOverlap  bool         `json:"check_suction_can_overlap"` // With check_duplicate_cans, a suction can # may not match a moisture can # used this session
+	AutoSaveIntervalSeconds int          `json:"auto_save_interval_seconds"`
+	MaxSamplesPerJob        int          `json:"max_samples_per_job"`
+	EnableNumericValidation bool         `json:"enable_numeric_validation"`
+	BackupOnSave            bool         `json:"backup_on_save"`
+	LogLevel                string       `json:"log_level"`
+	LogDir                  string       `json:"log_dir"` // Folder for lms.log; empty uses logs/ in the app directory
+	OvenDryTimeHours        int          `json:"oven_dry_time_hours"`
+	RecentOvenHours         int          `json:"recent_oven_hours"`         // Morning Count's recent filter shows cans put in within this many hours
+	CanNumberMin            int          `json:"can_number_min"`            // 0 disables the range check
+	CanNumberMax            int          `json:"can_number_max"`            // 0 disables the range check
+	MoistureContentWarnMax  float64      `json:"moisture_content_warn_max"` // Morning Count asks before saving anything higher; 0 disables
+	MoistureFormulas        bool         `json:"moisture_formulas"`         // Write water, dry soil and moisture content as Excel formulas instead of values
+	ConfirmEachSample       bool         `json:"confirm_each_sample"`       // Pull screen shows a summary to confirm before each save
+	AcceptDecimalComma      bool         `json:"accept_decimal_comma"`      // Read "123,45" as 123.45; turn off where commas only group thousands
+	KeyRemaps               []KeyRemap   `json:"key_remaps"`
+	Timezone                string       `json:"timezone"` // IANA name, e.g. "America/Chicago"; empty uses local time
+	OpenFolderCommand       string       `json:"open_folder_command"`
+	PrintCommand            string       `json:"print_command"`          // Prints a file given its path, e.g. "lp -d lab"
+	PrintConverter          string       `json:"print_converter"`        // Turns an .xlsx into a PDF for print_command; the output folder and file are appended
+	ClipboardCommand        string       `json:"clipboard_command"`      // Reads text on stdin, e.g. "xclip -selection clipboard"; empty picks wl-copy or xclip
+	Ovens                   []string     `json:"ovens"`                  // Oven IDs in the lab; empty for a single oven
+	WorkstationOven         string       `json:"workstation_oven"`       // Oven that cans pulled at this workstation go into
+	ProjectRoot             string       `json:"project_root"`           // Overrides the built-in ProjectRoot when set; relative to the app directory
+	DryRun                  bool         `json:"dry_run"`                // Training mode: validate and log but never write Excel files
+	UndoStackSize           int          `json:"undo_stack_size"`        // How many saved samples the pull screen can undo
+	SuctionRowsPerSheet     int          `json:"suction_rows_per_sheet"` // Samples per sheet in the separate suction file (matches the printed form)
+	MinPINLength            int          `json:"min_pin_length"`
+	KeepOriginalLabFile     bool         `json:"keep_original_lab_file"` // Snapshot the Lab file as Lab_<job>.orig on first copy
+	BoringPattern           string       `json:"boring_pattern"`         // Regexp for Main Form cells that start a new boring, e.g. "^(B|BH|TP)-"
+	SheetLayouts            SheetLayouts `json:"sheet_layouts"`          // Where values go on each Lab sheet type, only changes if the Lab template is revised
+	Tests                   []TestType   `json:"tests"`                  // Tests the Main Form can mark, in column order
+}
+
+// Test categories decide which fields the pull screen asks for
+const (
+	TestCategoryMoisture = "moisture" // Covered by the Moisture fields every sample gets
+	TestCategorySuction  = "suction"  // Needs a soil suction can
+	TestCategoryOther    = "other"    // Done elsewhere; the pull screen notes it
+)
+
+// TestType is a test the Main Form can mark with an "x", and how to recognize it by name
+type TestType struct {
+	Name     string   `json:"name"`
+	Column   int      `json:"column"`   // 0-based Main Form column holding the "x"
+	Category string   `json:"category"` // moisture, suction or other
+	Keywords []string `json:"keywords"` // A test name containing any of these belongs to Category
+}
+
+// SheetLayouts is where values go on each type of Lab file sheet
+type SheetLayouts struct {
+	Moisture    MoistureRowOffsets `json:"moisture"`
+	SoilSuction SoilSuctionLayout  `json:"soil_suction"`
+}
+
+// MoistureRowOffsets are the rows of a Moisture sheet block, counted from its "Boring No" row
+type MoistureRowOffsets struct {
+	CanNo           int `json:"can_no"`
+	WetWtAndCan     int `json:"wet_wt_and_can"`
+	DryWtAndCan     int `json:"dry_wt_and_can"`
+	WtOfWater       int `json:"wt_of_water"`
+	WtOfCan         int `json:"wt_of_can"`
+	DryWtOfSoil     int `json:"dry_wt_of_soil"`
+	MoistureContent int `json:"moisture_content"`
+}
+
+// check reports an offset that isn't below the "Boring No" row or is shared with another,
+// either of which would write one value over another
+func (o MoistureRowOffsets) check() error {
+	offsets := []struct {
+		name   string
+		offset int
+	}{
+		{"can_no", o.CanNo},
+		{"wet_wt_and_can", o.WetWtAndCan},
+		{"dry_wt_and_can", o.DryWtAndCan},
+		{"wt_of_water", o.WtOfWater},
+		{"wt_of_can", o.WtOfCan},
+		{"dry_wt_of_soil", o.DryWtOfSoil},
+		{"moisture_content", o.MoistureContent},
+	}
+	seen := map[int]string{}
+	for _, row := range offsets {
+		if row.offset <= 0 {
+			return fmt.Errorf("%s %d must be above 0", row.name, row.offset)
+		}
+		if other, ok := seen[row.offset]; ok {
+			return fmt.Errorf("%s and %s are both %d", other, row.name, row.offset)
+		}
+		seen[row.offset] = row.name
+	}
+	return nil
+}
+
+// SoilSuctionLayout is where samples sit on a Soil Suction sheet, one per row from FirstRow
+type SoilSuctionLayout struct {
+	FirstRow     int    `json:"first_row"`
+	BoringColumn string `json:"boring_column"`
+	DepthColumn  string `json:"depth_column"`
+	CanNoColumn  string `json:"can_no_column"` // Where the suction can number is written
+}
+
+// check reports a first row above the sheet, a column that isn't a column letter, or two
+// columns that are the same, any of which would read or write the wrong cells
+func (l SoilSuctionLayout) check() error {
+	if l.FirstRow <= 0 {
+		return fmt.Errorf("first_row %d must be above 0", l.FirstRow)
+	}
+	columns := []struct {
+		name   string
+		column string
+	}{
+		{"boring_column", l.BoringColumn},
+		{"depth_column", l.DepthColumn},
+		{"can_no_column", l.CanNoColumn},
+	}
+	seen := map[int]string{}
+	for _, c := range columns {
+		index := columnLetterToIndex(c.column)
+		if index == 0 {
+			return fmt.Errorf("%s %q is not a column letter", c.name, c.column)
+		}
+		if other, ok := seen[index]; ok {
+			return fmt.Errorf("%s and %s are both %s", other, c.name, c.column)
+		}
+		seen[index] = c.name
+	}
+	return nil
+}
+
+// KeyRemap maps one key to another before screens see it.
+// Keys are a single character (e.g. "*") or a tcell key name (e.g. "Up", "Ctrl-J").
+type KeyRemap struct {
+	From string `json:"from"`
+	To   string `json:"to"`
 }
 
 // Default configuration values
 var defaultConfig = AppConfig{
-	CheckDuplicateCans:       true,
-	AutoSaveIntervalSeconds:  30,
-	MaxSamplesPerJob:         1000,
-	EnableNumericValidation:  true,
-	BackupOnSave:             true,
-	LogLevel:                 "info",
+	CheckDuplicateCans:      true,
+	CheckSuctionCanOverlap:  true,
+	AutoSaveIntervalSeconds: 30,
+	MaxSamplesPerJob:        1000,
+	EnableNumericValidation: true,
+	BackupOnSave:            true,
+	LogLevel:                "info",
+	OvenDryTimeHours:        24,
+	RecentOvenHours:         16,
+	MoistureContentWarnMax:  100,
+	AcceptDecimalComma:      true,
+	OpenFolderCommand:       "xdg-open",
+	PrintCommand:            "lp",
+	PrintConverter:          "soffice --headless --convert-to pdf --outdir",
+	UndoStackSize:           5,
+	SuctionRowsPerSheet:     37,
+	MinPINLength:            4,
+	KeepOriginalLabFile:     true,
+	BoringPattern:           defaultBoringPattern,
+	SheetLayouts: SheetLayouts{
+		Moisture: MoistureRowOffsets{
+			CanNo:           2,
+			WetWtAndCan:     3,
+			DryWtAndCan:     4,
+			WtOfWater:       5,
+			WtOfCan:         6,
+			DryWtOfSoil:     7,
+			MoistureContent: 8,
+		},
+		SoilSuction: SoilSuctionLayout{
+			FirstRow:     10,
+			BoringColumn: "B",
+			DepthColumn:  "C",
+			CanNoColumn:  "D",
+		},
+	},
+	Tests: []TestType{
+		{Name: "Atterberg Limit", Column: 2, Category: TestCategoryOther},
+		{Name: "Atterberg Limit (w/ lime)", Column: 3, Category: TestCategoryOther},
+		{Name: "Moisture Content", Column: 4, Category: TestCategoryMoisture, Keywords: []string{"Moisture"}},
+		{Name: "Absorption Pressure Swell", Column: 5, Category: TestCategoryOther},
+		{Name: "QU", Column: 6, Category: TestCategoryOther},
+		{Name: "Gradation", Column: 7, Category: TestCategoryOther},
+		{Name: "Soil Suction", Column: 9, Category: TestCategorySuction, Keywords: []string{"Soil Suction"}},
+	},
+	KeyRemaps: []KeyRemap{
+		{From: "Ctrl-J", To: "Enter"}, // Numpad Enter
+		{From: "*", To: "Up"},
+		{From: "-", To: "Down"},
+	},
 }
 
 // Global configuration instance
 var Config AppConfig
 
+// loadedConfigPath is the file LoadConfig read, so diagnostics can re-check it
+var loadedConfigPath string
+
 // Configuration variables for backward compatibility
 var (
 	// CheckDuplicateCans controls whether to check for duplicate can numbers
@@ -40,9 +222,12 @@ var (
 
 // LoadConfig loads configuration from config.json file
 func LoadConfig(configPath string) error {
+	loadedConfigPath = configPath
+
 	// Set defaults first
 	Config = defaultConfig
 	CheckDuplicateCans = defaultConfig.CheckDuplicateCans
+	fillDefaultSlices()
 
 	// Try to read config file
 	data, err := os.ReadFile(configPath)
@@ -55,12 +240,19 @@ func LoadConfig(configPath string) error {
 		return err
 	}
 
-	// Parse JSON
-	if err := json.Unmarshal(data, &Config); err != nil {
+	// Parse JSON. The slices start empty: Unmarshal decodes into existing elements, so a
+	// tests entry leaving out a field would keep the default test's value at the same index
+	Config.Tests, Config.KeyRemaps, Config.Ovens = nil, nil, nil
+	err = json.Unmarshal(data, &Config)
+	fillDefaultSlices()
+	if err != nil {
 		logger.Error.Printf("Failed to parse config file: %v", err)
 		return err
 	}
 
+	renamedConfigKeys(data)
+	Config.Validate()
+
 	// Update backward compatibility variable
 	CheckDuplicateCans = Config.CheckDuplicateCans
 
@@ -70,6 +262,145 @@ func LoadConfig(configPath string) error {
 	return nil
 }
 
+// fillDefaultSlices puts back the default lists config.json left out or left empty. They are
+// cloned so editing Config never changes the defaults
+func fillDefaultSlices() {
+	if len(Config.Tests) == 0 {
+		Config.Tests = slices.Clone(defaultConfig.Tests)
+	}
+	if len(Config.KeyRemaps) == 0 {
+		Config.KeyRemaps = slices.Clone(defaultConfig.KeyRemaps)
+	}
+	if len(Config.Ovens) == 0 {
+		Config.Ovens = slices.Clone(defaultConfig.Ovens)
+	}
+}
+
+// renamedConfigKeys reads settings config.json still holds under an old name, so a file
+// written before the rename keeps working. The new name wins when both are set, and the
+// file is written with the new name the next time settings are saved.
+func renamedConfigKeys(data []byte) {
+	var old struct {
+		MoistureContentWarnThreshold *float64 `json:"moisture_content_warn_threshold"`
+		MoistureContentWarnMax       *float64 `json:"moisture_content_warn_max"`
+	}
+	if err := json.Unmarshal(data, &old); err != nil || old.MoistureContentWarnThreshold == nil {
+		return
+	}
+	if old.MoistureContentWarnMax != nil {
+		logger.Error.Printf("WARNING: Config: moisture_content_warn_threshold was renamed moisture_content_warn_max; ignoring the old key since both are set")
+		return
+	}
+	logger.Error.Printf("WARNING: Config: moisture_content_warn_threshold was renamed moisture_content_warn_max; using its value %g", *old.MoistureContentWarnThreshold)
+	Config.MoistureContentWarnMax = *old.MoistureContentWarnThreshold
+}
+
+// logLevels are the levels logger.SetLevel understands
+var logLevels = []string{"debug", "info", "error"}
+
+// Validate replaces out-of-range values with defaults so a bad config.json can't produce
+// odd behavior, logging each correction. It returns the corrections made. Settings it can
+// leave as they are but that probably aren't meant, like a test outside the Main Form, are
+// only logged as warnings.
+func (c *AppConfig) Validate() []string {
+	var corrections []string
+	correct := func(format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		logger.Error.Printf("Config: %s", message)
+		corrections = append(corrections, message)
+	}
+
+	if c.AutoSaveIntervalSeconds < 0 {
+		correct("auto_save_interval_seconds %d is negative, using %d", c.AutoSaveIntervalSeconds, defaultConfig.AutoSaveIntervalSeconds)
+		c.AutoSaveIntervalSeconds = defaultConfig.AutoSaveIntervalSeconds
+	}
+	if c.MaxSamplesPerJob <= 0 {
+		correct("max_samples_per_job %d must be above 0, using %d", c.MaxSamplesPerJob, defaultConfig.MaxSamplesPerJob)
+		c.MaxSamplesPerJob = defaultConfig.MaxSamplesPerJob
+	}
+	if level := strings.ToLower(strings.TrimSpace(c.LogLevel)); slices.Contains(logLevels, level) {
+		c.LogLevel = level
+	} else {
+		correct("log_level %q is not one of %s, using %q", c.LogLevel, strings.Join(logLevels, ", "), defaultConfig.LogLevel)
+		c.LogLevel = defaultConfig.LogLevel
+	}
+	if c.OvenDryTimeHours <= 0 {
+		correct("oven_dry_time_hours %d must be above 0, using %d", c.OvenDryTimeHours, defaultConfig.OvenDryTimeHours)
+		c.OvenDryTimeHours = defaultConfig.OvenDryTimeHours
+	}
+	if c.RecentOvenHours <= 0 {
+		correct("recent_oven_hours %d must be above 0, using %d", c.RecentOvenHours, defaultConfig.RecentOvenHours)
+		c.RecentOvenHours = defaultConfig.RecentOvenHours
+	}
+	if c.CanNumberMin < 0 || c.CanNumberMax < 0 || (c.CanNumberMax > 0 && c.CanNumberMin > c.CanNumberMax) {
+		correct("can number range %d-%d is invalid, turning the range check off", c.CanNumberMin, c.CanNumberMax)
+		c.CanNumberMin, c.CanNumberMax = 0, 0
+	}
+	if c.MoistureContentWarnMax < 0 {
+		correct("moisture_content_warn_max %g is negative, turning the warning off", c.MoistureContentWarnMax)
+		c.MoistureContentWarnMax = 0
+	}
+	if c.UndoStackSize < 0 {
+		correct("undo_stack_size %d is negative, using %d", c.UndoStackSize, defaultConfig.UndoStackSize)
+		c.UndoStackSize = defaultConfig.UndoStackSize
+	}
+	if c.SuctionRowsPerSheet <= 0 {
+		correct("suction_rows_per_sheet %d must be above 0, using %d", c.SuctionRowsPerSheet, defaultConfig.SuctionRowsPerSheet)
+		c.SuctionRowsPerSheet = defaultConfig.SuctionRowsPerSheet
+	}
+	if c.MinPINLength <= 0 {
+		correct("min_pin_length %d must be above 0, using %d", c.MinPINLength, defaultConfig.MinPINLength)
+		c.MinPINLength = defaultConfig.MinPINLength
+	}
+	if _, err := regexp.Compile(c.BoringPattern); err != nil || c.BoringPattern == "" {
+		correct("boring_pattern %q is empty or not a valid regexp, using %q", c.BoringPattern, defaultBoringPattern)
+		c.BoringPattern = defaultBoringPattern
+	}
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			correct("timezone %q is not a known IANA name, using local time", c.Timezone)
+			c.Timezone = ""
+		}
+	}
+	if err := c.SheetLayouts.Moisture.check(); err != nil {
+		correct("sheet_layouts.moisture %v, using the standard Moisture block layout", err)
+		c.SheetLayouts.Moisture = defaultConfig.SheetLayouts.Moisture
+	}
+	suction := &c.SheetLayouts.SoilSuction
+	for _, column := range []*string{&suction.BoringColumn, &suction.DepthColumn, &suction.CanNoColumn} {
+		*column = strings.ToUpper(strings.TrimSpace(*column))
+	}
+	if err := suction.check(); err != nil {
+		correct("sheet_layouts.soil_suction %v, using the standard Soil Suction layout", err)
+		c.SheetLayouts.SoilSuction = defaultConfig.SheetLayouts.SoilSuction
+	}
+	if len(c.Tests) == 0 {
+		correct("tests is empty, using the standard Main Form tests")
+		c.Tests = slices.Clone(defaultConfig.Tests)
+	}
+	for i := range c.Tests {
+		test := &c.Tests[i]
+		if test.Column < 0 {
+			logger.Error.Printf("WARNING: Config: test %q column %d is negative, the test won't be detected", test.Name, test.Column)
+		}
+		switch test.Category {
+		case TestCategoryMoisture, TestCategorySuction, TestCategoryOther:
+		default:
+			correct("test %q category %q is not moisture, suction or other, using other", test.Name, test.Category)
+			test.Category = TestCategoryOther
+		}
+	}
+	return corrections
+}
+
+// ConfigPath returns the file LoadConfig read, so settings changed in the app are saved back to it
+func ConfigPath() string {
+	if loadedConfigPath == "" {
+		return DefaultConfigPath()
+	}
+	return loadedConfigPath
+}
+
 // SaveConfig saves current configuration to file
 func SaveConfig(configPath string) error {
 	// Ensure directory exists
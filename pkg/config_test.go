@@ -0,0 +1,168 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	if corrections := (&AppConfig{}).Validate(); len(corrections) == 0 {
+		t.Error("zero config: expected corrections")
+	}
+	valid := defaultConfig
+	if corrections := valid.Validate(); len(corrections) != 0 {
+		t.Errorf("default config corrected: %v", corrections)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *AppConfig)
+		check  func(c AppConfig) bool
+	}{
+		{"negative auto-save interval", func(c *AppConfig) { c.AutoSaveIntervalSeconds = -5 },
+			func(c AppConfig) bool { return c.AutoSaveIntervalSeconds == defaultConfig.AutoSaveIntervalSeconds }},
+		{"zero max samples", func(c *AppConfig) { c.MaxSamplesPerJob = 0 },
+			func(c AppConfig) bool { return c.MaxSamplesPerJob == defaultConfig.MaxSamplesPerJob }},
+		{"empty log level", func(c *AppConfig) { c.LogLevel = "" },
+			func(c AppConfig) bool { return c.LogLevel == "info" }},
+		{"unknown log level", func(c *AppConfig) { c.LogLevel = "verbose" },
+			func(c AppConfig) bool { return c.LogLevel == "info" }},
+		{"zero oven dry time", func(c *AppConfig) { c.OvenDryTimeHours = 0 },
+			func(c AppConfig) bool { return c.OvenDryTimeHours == defaultConfig.OvenDryTimeHours }},
+		{"zero recent oven hours", func(c *AppConfig) { c.RecentOvenHours = 0 },
+			func(c AppConfig) bool { return c.RecentOvenHours == defaultConfig.RecentOvenHours }},
+		{"inverted can range", func(c *AppConfig) { c.CanNumberMin, c.CanNumberMax = 500, 100 },
+			func(c AppConfig) bool { return c.CanNumberMin == 0 && c.CanNumberMax == 0 }},
+		{"negative can minimum", func(c *AppConfig) { c.CanNumberMin = -1 },
+			func(c AppConfig) bool { return c.CanNumberMin == 0 && c.CanNumberMax == 0 }},
+		{"negative moisture warning", func(c *AppConfig) { c.MoistureContentWarnMax = -10 },
+			func(c AppConfig) bool { return c.MoistureContentWarnMax == 0 }},
+		{"negative undo stack", func(c *AppConfig) { c.UndoStackSize = -1 },
+			func(c AppConfig) bool { return c.UndoStackSize == defaultConfig.UndoStackSize }},
+		{"zero suction rows", func(c *AppConfig) { c.SuctionRowsPerSheet = 0 },
+			func(c AppConfig) bool { return c.SuctionRowsPerSheet == defaultConfig.SuctionRowsPerSheet }},
+		{"zero PIN length", func(c *AppConfig) { c.MinPINLength = 0 },
+			func(c AppConfig) bool { return c.MinPINLength == defaultConfig.MinPINLength }},
+		{"invalid boring pattern", func(c *AppConfig) { c.BoringPattern = "^(B-" },
+			func(c AppConfig) bool { return c.BoringPattern == defaultBoringPattern }},
+		{"zero moisture row offset", func(c *AppConfig) { c.SheetLayouts.Moisture.WtOfCan = 0 },
+			func(c AppConfig) bool { return c.SheetLayouts.Moisture == defaultConfig.SheetLayouts.Moisture }},
+		{"negative moisture row offset", func(c *AppConfig) { c.SheetLayouts.Moisture.CanNo = -2 },
+			func(c AppConfig) bool { return c.SheetLayouts.Moisture == defaultConfig.SheetLayouts.Moisture }},
+		{"duplicate moisture row offsets", func(c *AppConfig) { c.SheetLayouts.Moisture.DryWtAndCan = c.SheetLayouts.Moisture.WetWtAndCan },
+			func(c AppConfig) bool { return c.SheetLayouts.Moisture == defaultConfig.SheetLayouts.Moisture }},
+		{"zero soil suction first row", func(c *AppConfig) { c.SheetLayouts.SoilSuction.FirstRow = 0 },
+			func(c AppConfig) bool { return c.SheetLayouts.SoilSuction == defaultConfig.SheetLayouts.SoilSuction }},
+		{"soil suction column not a letter", func(c *AppConfig) { c.SheetLayouts.SoilSuction.CanNoColumn = "4" },
+			func(c AppConfig) bool { return c.SheetLayouts.SoilSuction == defaultConfig.SheetLayouts.SoilSuction }},
+		{"duplicate soil suction columns", func(c *AppConfig) { c.SheetLayouts.SoilSuction.CanNoColumn = "C" },
+			func(c AppConfig) bool { return c.SheetLayouts.SoilSuction == defaultConfig.SheetLayouts.SoilSuction }},
+		{"unknown timezone", func(c *AppConfig) { c.Timezone = "America/Chicgo" },
+			func(c AppConfig) bool { return c.Timezone == "" }},
+	}
+	for _, tt := range tests {
+		c := defaultConfig
+		tt.modify(&c)
+		corrections := c.Validate()
+		if len(corrections) != 1 {
+			t.Errorf("%s: got %d corrections %v, want 1", tt.name, len(corrections), corrections)
+		}
+		if !tt.check(c) {
+			t.Errorf("%s: value not corrected: %+v", tt.name, c)
+		}
+	}
+
+	// A test outside the Main Form is only a warning, so it doesn't block saving other settings
+	c := defaultConfig
+	c.Tests = []TestType{{Name: "Hidden", Column: -1, Category: TestCategoryOther}}
+	if corrections := c.Validate(); len(corrections) != 0 {
+		t.Errorf("negative test column: got corrections %v, want none", corrections)
+	}
+
+	// Log levels are accepted in any case
+	c = defaultConfig
+	c.LogLevel = " DEBUG "
+	if corrections := c.Validate(); len(corrections) != 0 || c.LogLevel != "debug" {
+		t.Errorf("log level %q with corrections %v, want \"debug\" and none", c.LogLevel, corrections)
+	}
+}
+
+func TestLoadConfigLeavesDefaultsAlone(t *testing.T) {
+	savedConfig, savedPath := Config, loadedConfigPath
+	t.Cleanup(func() { Config, loadedConfigPath = savedConfig, savedPath })
+
+	defaultRemaps := slices.Clone(defaultConfig.KeyRemaps)
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"key_remaps": [{"from": "/", "to": "Up"}], "ovens": ["A"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfig(configPath); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(Config.KeyRemaps) != 1 || Config.KeyRemaps[0].From != "/" {
+		t.Errorf("KeyRemaps = %v, want the file's remap", Config.KeyRemaps)
+	}
+	if !slices.Equal(defaultConfig.KeyRemaps, defaultRemaps) {
+		t.Errorf("default KeyRemaps changed to %v by loading a config file", defaultConfig.KeyRemaps)
+	}
+}
+
+func TestLoadConfigPartialTestEntry(t *testing.T) {
+	savedConfig, savedPath := Config, loadedConfigPath
+	t.Cleanup(func() { Config, loadedConfigPath = savedConfig, savedPath })
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	// The third entry sits where the default Moisture Content test has a keyword and category
+	partial := `{"tests": [{"name": "a", "column": 2}, {"name": "b", "column": 3}, {"name": "c", "column": 4}]}`
+	if err := os.WriteFile(configPath, []byte(partial), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfig(configPath); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(Config.Tests) != 3 {
+		t.Fatalf("Tests = %+v, want the file's 3 tests", Config.Tests)
+	}
+	if got := Config.Tests[2]; got.Name != "c" || len(got.Keywords) != 0 || got.Category != TestCategoryOther {
+		t.Errorf("Tests[2] = %+v, want test c with no keywords and category other", got)
+	}
+
+	// A file without the lists keeps the defaults
+	if err := os.WriteFile(configPath, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfig(configPath); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(Config.Tests) != len(defaultConfig.Tests) || len(Config.KeyRemaps) != len(defaultConfig.KeyRemaps) {
+		t.Errorf("Tests = %d, KeyRemaps = %d, want the %d default tests and %d default remaps",
+			len(Config.Tests), len(Config.KeyRemaps), len(defaultConfig.Tests), len(defaultConfig.KeyRemaps))
+	}
+}
+
+func TestLoadConfigReadsRenamedWarnThreshold(t *testing.T) {
+	savedConfig, savedPath := Config, loadedConfigPath
+	t.Cleanup(func() { Config, loadedConfigPath = savedConfig, savedPath })
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	for _, tt := range []struct {
+		config string
+		want   float64
+	}{
+		{`{"moisture_content_warn_threshold": 60}`, 60},
+		{`{"moisture_content_warn_threshold": 60, "moisture_content_warn_max": 80}`, 80},
+		{`{}`, defaultConfig.MoistureContentWarnMax},
+	} {
+		if err := os.WriteFile(configPath, []byte(tt.config), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := LoadConfig(configPath); err != nil {
+			t.Fatalf("LoadConfig(%s) failed: %v", tt.config, err)
+		}
+		if Config.MoistureContentWarnMax != tt.want {
+			t.Errorf("%s: MoistureContentWarnMax = %g, want %g", tt.config, Config.MoistureContentWarnMax, tt.want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"lms-tui/logger"
+)
+
+// JobActivity summarizes the samples entered for one job on a given day
+type JobActivity struct {
+	JobNumber   string
+	SampleCount int
+	FirstEntry  time.Time
+	LastEntry   time.Time
+	EnteredBy   map[string]int // Samples per user ID; "" for samples saved before users were recorded
+	Rechecks    []string       // "Boring|Depth" of the day's samples flagged for recheck
+}
+
+// DailyActivity is the end-of-day summary across all jobs
+type DailyActivity struct {
+	Date         time.Time
+	Jobs         []JobActivity // Sorted by job number
+	TotalSamples int
+	EnteredBy    map[string]int // Samples per user ID across all jobs
+	Rechecks     int            // Samples flagged for recheck across all jobs
+}
+
+// FormatEnteredBy lists sample counts per user, most first, e.g. "jsmith 12, akim 3".
+// Samples with no user recorded are listed as "unrecorded".
+func FormatEnteredBy(counts map[string]int) string {
+	users := make([]string, 0, len(counts))
+	for user := range counts {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if counts[users[i]] != counts[users[j]] {
+			return counts[users[i]] > counts[users[j]]
+		}
+		return users[i] < users[j]
+	})
+	parts := make([]string, len(users))
+	for i, user := range users {
+		name := user
+		if name == "" {
+			name = "unrecorded"
+		}
+		parts[i] = fmt.Sprintf("%s %d", name, counts[user])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CollectDailyActivity scans every ex_project/<job>/backup.json and counts the samples
+// entered on the given date (in the configured timezone). It only reads the JSON backups,
+// never the Lab files, so it stays fast with many jobs.
+func CollectDailyActivity(date time.Time) (*DailyActivity, error) {
+	loc := Location()
+	year, month, day := date.In(loc).Date()
+	activity := &DailyActivity{Date: time.Date(year, month, day, 0, 0, 0, 0, loc), EnteredBy: map[string]int{}}
+
+	exProjectDir := filepath.Join(ProjectRoot, "ex_project")
+	entries, err := os.ReadDir(exProjectDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return activity, nil
+		}
+		logger.Error.Printf("Failed to read ex_project directory: %v", err)
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		backupFile := BackupPath(entry.Name())
+		if _, err := os.Stat(backupFile); err != nil {
+			continue
+		}
+		backup, err := LoadBackupData(backupFile)
+		if err != nil {
+			logger.Error.Printf("Skipping %s in daily activity: %v", backupFile, err)
+			continue
+		}
+
+		job := JobActivity{JobNumber: entry.Name(), EnteredBy: map[string]int{}}
+		for _, sample := range backup.Samples {
+			// Skipped samples were not tested, so they are not work done
+			if sample.IsSkipped() {
+				continue
+			}
+			timestamp, err := ParseTimestamp(sample.Timestamp)
+			if err != nil {
+				continue
+			}
+			y, m, d := timestamp.In(loc).Date()
+			if y != year || m != month || d != day {
+				continue
+			}
+			if job.SampleCount == 0 || timestamp.Before(job.FirstEntry) {
+				job.FirstEntry = timestamp
+			}
+			if timestamp.After(job.LastEntry) {
+				job.LastEntry = timestamp
+			}
+			job.SampleCount++
+			job.EnteredBy[sample.EnteredBy]++
+			activity.EnteredBy[sample.EnteredBy]++
+			if sample.NeedsRecheck {
+				job.Rechecks = append(job.Rechecks, sample.BoringNumber+"|"+sample.Depth)
+				activity.Rechecks++
+			}
+		}
+
+		if job.SampleCount > 0 {
+			activity.Jobs = append(activity.Jobs, job)
+			activity.TotalSamples += job.SampleCount
+		}
+	}
+
+	sort.Slice(activity.Jobs, func(i, j int) bool {
+		return activity.Jobs[i].JobNumber < activity.Jobs[j].JobNumber
+	})
+
+	logger.Info.Printf("Collected daily activity for %s: %d samples across %d jobs",
+		activity.Date.Format("2006-01-02"), activity.TotalSamples, len(activity.Jobs))
+	return activity, nil
+}
@@ -0,0 +1,49 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCollectDailyActivity(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	today := time.Now().In(Location())
+	yesterday := today.AddDate(0, 0, -1)
+	write := func(job string, timestamps ...time.Time) {
+		backup := &BackupData{JobNumber: job}
+		for i, ts := range timestamps {
+			backup.Samples = append(backup.Samples, SampleBackupData{
+				JobNumber: job, BoringNumber: fmt.Sprintf("B-%d", i+1), Depth: "0 - 1",
+				CanNumber: "1", CanWeight: "50", WetWeight: "200", Timestamp: ts.Format(TimestampFormat),
+			})
+		}
+		dir := filepath.Join(root, "ex_project", job)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := SaveBackupDataToFile(backup, filepath.Join(dir, "backup.json")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("25490", today, today, yesterday)
+	write("26046", yesterday)
+	write("25313", today)
+
+	activity, err := CollectDailyActivity(today)
+	if err != nil {
+		t.Fatalf("CollectDailyActivity failed: %v", err)
+	}
+	if activity.TotalSamples != 3 {
+		t.Errorf("expected 3 samples today, got %d", activity.TotalSamples)
+	}
+	if len(activity.Jobs) != 2 || activity.Jobs[0].JobNumber != "25313" || activity.Jobs[1].JobNumber != "25490" {
+		t.Fatalf("unexpected jobs: %+v", activity.Jobs)
+	}
+	if activity.Jobs[1].SampleCount != 2 {
+		t.Errorf("expected 2 samples for 25490, got %d", activity.Jobs[1].SampleCount)
+	}
+}
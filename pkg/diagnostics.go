@@ -0,0 +1,169 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	excelize "github.com/xuri/excelize/v2"
+	"lms-tui/logger"
+)
+
+// DiagnosticResult is the outcome of one environment check
+type DiagnosticResult struct {
+	Name   string
+	Passed bool
+	Detail string // What was checked, or the error when it failed
+}
+
+// RunDiagnostics checks the environment the app depends on, so support can tell
+// whether a problem is the machine or the data
+func RunDiagnostics() []DiagnosticResult {
+	logger.Info.Println("Running diagnostics")
+
+	results := []DiagnosticResult{
+		checkProjectRoot(),
+		checkProjectsFolder(),
+		checkConfig(),
+		checkLogger(),
+		checkExcel(),
+		checkOvenTracking(),
+	}
+
+	for _, result := range results {
+		if result.Passed {
+			logger.Info.Printf("Diagnostic PASS: %s - %s", result.Name, result.Detail)
+		} else {
+			logger.Error.Printf("Diagnostic FAIL: %s - %s", result.Name, result.Detail)
+		}
+	}
+	return results
+}
+
+func diagnosticPass(name, detail string) DiagnosticResult {
+	return DiagnosticResult{Name: name, Passed: true, Detail: detail}
+}
+
+func diagnosticFail(name string, err error) DiagnosticResult {
+	return DiagnosticResult{Name: name, Passed: false, Detail: err.Error()}
+}
+
+// checkProjectRoot verifies ProjectRoot exists and a file can be written in it
+func checkProjectRoot() DiagnosticResult {
+	const name = "Project root writable"
+
+	info, err := os.Stat(ProjectRoot)
+	if err != nil {
+		return diagnosticFail(name, err)
+	}
+	if !info.IsDir() {
+		return diagnosticFail(name, fmt.Errorf("%s is not a directory", ProjectRoot))
+	}
+
+	probe, err := os.CreateTemp(ProjectRoot, ".lms-diagnostics-*")
+	if err != nil {
+		return diagnosticFail(name, err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	return diagnosticPass(name, ProjectRoot)
+}
+
+// checkProjectsFolder verifies the projects folder can be listed
+func checkProjectsFolder() DiagnosticResult {
+	const name = "Projects folder readable"
+
+	projectsDir := ProjectsDir()
+	entries, err := os.ReadDir(projectsDir)
+	if err != nil {
+		return diagnosticFail(name, err)
+	}
+	return diagnosticPass(name, fmt.Sprintf("%s (%d entries)", projectsDir, len(entries)))
+}
+
+// checkConfig re-parses the config file LoadConfig used
+func checkConfig() DiagnosticResult {
+	const name = "Config parsed"
+
+	if loadedConfigPath == "" {
+		return diagnosticFail(name, fmt.Errorf("config was never loaded"))
+	}
+	data, err := os.ReadFile(loadedConfigPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return diagnosticPass(name, fmt.Sprintf("%s not found, using defaults", loadedConfigPath))
+		}
+		return diagnosticFail(name, err)
+	}
+	var parsed AppConfig
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return diagnosticFail(name, fmt.Errorf("%s: %v", loadedConfigPath, err))
+	}
+	return diagnosticPass(name, loadedConfigPath)
+}
+
+// checkLogger verifies the log file can be appended to
+func checkLogger() DiagnosticResult {
+	const name = "Logger writing"
+
+	if logger.FilePath == "" {
+		return diagnosticFail(name, fmt.Errorf("logger was not initialized with a file"))
+	}
+	f, err := os.OpenFile(logger.FilePath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return diagnosticFail(name, err)
+	}
+	f.Close()
+	return diagnosticPass(name, logger.FilePath)
+}
+
+// checkExcel opens the first Lab file found under projects/ with excelize
+func checkExcel() DiagnosticResult {
+	const name = "Excel file opens"
+
+	projectsDir := ProjectsDir()
+	sample := ""
+	filepath.WalkDir(projectsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && labFileExt(d.Name()) != "" {
+			sample = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if sample == "" {
+		return diagnosticFail(name, fmt.Errorf("no .xlsm or .xlsx files found under %s", projectsDir))
+	}
+
+	f, err := excelize.OpenFile(sample)
+	if err != nil {
+		return diagnosticFail(name, fmt.Errorf("%s: %v", sample, err))
+	}
+	sheets := len(f.GetSheetList())
+	f.Close()
+	return diagnosticPass(name, fmt.Sprintf("%s (%d sheets)", sample, sheets))
+}
+
+// checkOvenTracking verifies oven_tracking.json is valid JSON
+func checkOvenTracking() DiagnosticResult {
+	const name = "Oven tracking valid"
+
+	trackingFile := GetOvenTrackingFilePath()
+	data, err := os.ReadFile(trackingFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return diagnosticPass(name, fmt.Sprintf("%s not created yet", trackingFile))
+		}
+		return diagnosticFail(name, err)
+	}
+	var tracking OvenTrackingData
+	if err := json.Unmarshal(data, &tracking); err != nil {
+		return diagnosticFail(name, fmt.Errorf("%s: %v", trackingFile, err))
+	}
+	return diagnosticPass(name, fmt.Sprintf("%s (%d cans)", trackingFile, len(tracking.Cans)))
+}
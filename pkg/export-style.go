@@ -0,0 +1,49 @@
+package pkg
+
+import (
+	"fmt"
+
+	"lms-tui/logger"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+// ExportColumn is one column of a generated export sheet
+type ExportColumn struct {
+	Header string
+	Width  float64
+}
+
+// suctionExportColumns is the layout of the separate soil suction file: Date, Boring, Depth,
+// Can No, then Top/Bottom readings left blank for the tech
+var suctionExportColumns = []ExportColumn{
+	{"Date", 12}, {"Boring", 12}, {"Depth", 12}, {"Can No", 12},
+	{"Top", 12}, {"Bottom", 12}, {"Top", 12}, {"Bottom", 12},
+}
+
+// writeExportHeader writes the header row with the lab's standard export styling
+// (bold on gray) and sets each column's width
+func writeExportHeader(f *excelize.File, sheetName string, columns []ExportColumn) {
+	for i, column := range columns {
+		colLetter := getColumnLetter(i + 1)
+		f.SetCellValue(sheetName, fmt.Sprintf("%s1", colLetter), column.Header)
+		f.SetColWidth(sheetName, colLetter, colLetter, column.Width)
+	}
+
+	style, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#CCCCCC"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", WrapText: true},
+	})
+	if err != nil {
+		logger.Error.Printf("Failed to create header style for %s: %v", sheetName, err)
+		return
+	}
+	f.SetCellStyle(sheetName, "A1", fmt.Sprintf("%s1", getColumnLetter(len(columns))), style)
+}
+
+// twoDecimalStyle returns a cell style that shows numbers with two decimals
+func twoDecimalStyle(f *excelize.File) (int, error) {
+	format := "0.00"
+	return f.NewStyle(&excelize.Style{CustomNumFmt: &format})
+}
@@ -1,11 +1,15 @@
 package pkg
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -18,21 +22,80 @@ import (
 
 // JobData represents the structured data from an Excel job file
 type JobData struct {
-	JobNumber    string       `json:"job_number"`
-	ProjectName  string       `json:"project_name"`
-	Engineer     string       `json:"engineer"`
-	Date         string       `json:"date"`
-	DueDate      string       `json:"due_date"`
-	PageInfo     string       `json:"page_info"`
-	TotalSamples int          `json:"total_samples"`
-	Samples      []SampleData `json:"samples"`
+	JobNumber        string       `json:"job_number"`
+	ProjectName      string       `json:"project_name"`
+	Engineer         string       `json:"engineer"`
+	Date             string       `json:"date"`
+	DueDate          string       `json:"due_date"`
+	PageInfo         string       `json:"page_info"`
+	TotalSamples     int          `json:"total_samples"`
+	StatedTotal      int          `json:"stated_total"` // "Total" from the Main Form header, 0 if not stated
+	Samples          []SampleData `json:"samples"`
+	UnlabeledRows    []int        `json:"unlabeled_rows,omitempty"`    // Main Form rows with a depth before any boring label; left out of Samples
+	UnrecognizedRows []int        `json:"unrecognized_rows,omitempty"` // Rows whose boring label doesn't match boring_pattern, and the blank-label rows under them; left out of Samples
+}
+
+// SkippedRowWarnings describes the Main Form rows left out of Samples because they have no
+// boring to belong to, one line per reason; empty when every row was read
+func (d *JobData) SkippedRowWarnings() []string {
+	rowList := func(rows []int) string {
+		text := make([]string, len(rows))
+		for i, row := range rows {
+			text[i] = strconv.Itoa(row)
+		}
+		return strings.Join(text, ", ")
+	}
+	var warnings []string
+	if len(d.UnlabeledRows) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Main Form row(s) %s have a depth but come before any boring label, so they were left out - add the boring in the Main Form",
+			rowList(d.UnlabeledRows)))
+	}
+	if len(d.UnrecognizedRows) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Main Form row(s) %s have a boring label that doesn't match boring_pattern %q (or continue one), so they were left out",
+			rowList(d.UnrecognizedRows), boringPattern().String()))
+	}
+	return warnings
 }
 
 // SampleData represents a single sample/boring entry
 type SampleData struct {
-	BoringNumber         string   `json:"boring_number"`
-	Depth                string   `json:"depth"`
-	Tests                []string `json:"tests"`
+	BoringNumber string   `json:"boring_number"`
+	Depth        string   `json:"depth"`
+	Tests        []string `json:"tests"`
+}
+
+// defaultBoringPattern matches the usual "B-1" style boring labels
+const defaultBoringPattern = `^B-`
+
+// boringPatternCache holds the last compiled boring_pattern so each row doesn't recompile it
+var boringPatternCache struct {
+	source string
+	re     *regexp.Regexp
+}
+
+// boringPattern returns the compiled boring_pattern from config, falling back to the
+// default when it is unset or doesn't compile
+func boringPattern() *regexp.Regexp {
+	source := Config.BoringPattern
+	if source == "" {
+		source = defaultBoringPattern
+	}
+	if boringPatternCache.re != nil && boringPatternCache.source == source {
+		return boringPatternCache.re
+	}
+	re, err := regexp.Compile(source)
+	if err != nil {
+		logger.Error.Printf("Invalid boring_pattern %q, using %q: %v", source, defaultBoringPattern, err)
+		source = defaultBoringPattern
+		re = regexp.MustCompile(defaultBoringPattern)
+	}
+	boringPatternCache.source, boringPatternCache.re = source, re
+	return re
+}
+
+// isBoringLabel reports whether a Main Form first-column cell starts a new boring
+func isBoringLabel(cell string) bool {
+	return cell != "" && boringPattern().MatchString(cell)
 }
 
 // ExcelToJSON converts Excel data to JSON format and logs it
@@ -50,7 +113,11 @@ func ExcelToJSON(filePath string) (*JobData, error) {
 	}
 	defer f.Close()
 
-	sheetName := f.GetSheetName(0)
+	// Samples are on the first sheet unless .lmsmeta.json says otherwise
+	sheetName := mainFormSheetOverride(f, fullPath)
+	if sheetName == "" {
+		sheetName = f.GetSheetName(0)
+	}
 	rows, err := f.GetRows(sheetName)
 	if err != nil {
 		logger.Error.Printf("Failed to read rows: %v", err)
@@ -61,12 +128,23 @@ func ExcelToJSON(filePath string) (*JobData, error) {
 		Samples: []SampleData{},
 	}
 
+	// Blank-boring rows carry on the boring above them. Rows before the first boring label
+	// have no boring to belong to, and rows under an unrecognized label belong to it, not to
+	// the last boring that was recognized.
+	currentBoring := ""
+	underUnrecognized := false
+
 	// Parse the header information
 	for rowIdx, row := range rows {
 		if len(row) == 0 {
 			continue
 		}
 
+		// The header states the expected sample count as "Total" followed by the number
+		if rowIdx < 6 && jobData.StatedTotal == 0 {
+			jobData.StatedTotal = parseStatedTotal(row)
+		}
+
 		// Look for specific labels in the first column
 		firstCell := strings.TrimSpace(row[0])
 
@@ -83,7 +161,7 @@ func ExcelToJSON(filePath string) (*JobData, error) {
 			}
 		case strings.Contains(firstCell, "Due Date") && len(row) > 9:
 			jobData.DueDate = strings.TrimSpace(row[9])
-		case strings.HasPrefix(firstCell, "B-") || (rowIdx > 6 && firstCell == ""):
+		case isBoringLabel(firstCell) || (rowIdx > 6 && firstCell == ""):
 			// This is a sample row
 			if len(row) > 1 {
 				sample := SampleData{
@@ -91,51 +169,46 @@ func ExcelToJSON(filePath string) (*JobData, error) {
 				}
 
 				// Check if this is a new boring or continuation
-				if strings.HasPrefix(firstCell, "B-") {
-					sample.BoringNumber = firstCell
+				if firstCell != "" {
+					currentBoring = firstCell
+					underUnrecognized = false
 				}
+				sample.BoringNumber = currentBoring
 
 				// Get depth
 				if len(row) > 1 && strings.TrimSpace(row[1]) != "" {
 					sample.Depth = strings.TrimSpace(row[1])
 				}
 
-				// Check for test markers (x's in various columns)
-				testNames := []string{
-					"Atterberg Limit",
-					"Atterberg Limit (w/ lime)",
-					"Moisture Content",
-					"Absorption Pressure Swell",
-					"QU",
-					"Gradation",
-					"Soil Suction",
-				}
-				testCols := []int{2, 3, 4, 5, 6, 7, 9} // Approximate column indices
-
-				for i, col := range testCols {
-					if col < len(row) && strings.TrimSpace(row[col]) == "x" {
-						if i < len(testNames) {
-							sample.Tests = append(sample.Tests, testNames[i])
-						}
+				// Check for test markers (x's in the configured test columns)
+				for _, test := range testTypes() {
+					if test.Column >= 0 && test.Column < len(row) && strings.TrimSpace(row[test.Column]) == "x" {
+						sample.Tests = append(sample.Tests, test.Name)
 					}
 				}
 
 				// Only add if we have a depth (valid sample)
-				if sample.Depth != "" {
+				if sample.Depth != "" && underUnrecognized {
+					logger.Error.Printf("WARNING: Row %d (depth %s) continues an unrecognized boring label; it was left out", rowIdx+1, sample.Depth)
+					jobData.UnrecognizedRows = append(jobData.UnrecognizedRows, rowIdx+1)
+				} else if sample.Depth != "" && sample.BoringNumber == "" {
+					// Without a boring it would get an empty boring number and no Moisture column
+					logger.Error.Printf("WARNING: Row %d (depth %s) comes before any boring label; it was left out until a boring is added in the Main Form",
+						rowIdx+1, sample.Depth)
+					jobData.UnlabeledRows = append(jobData.UnlabeledRows, rowIdx+1)
+				} else if sample.Depth != "" {
 					jobData.Samples = append(jobData.Samples, sample)
 					jobData.TotalSamples++
 				}
 			}
-		}
-	}
-
-	// Assign boring numbers to samples that don't have them (they belong to the previous boring)
-	currentBoring := ""
-	for i := range jobData.Samples {
-		if jobData.Samples[i].BoringNumber != "" {
-			currentBoring = jobData.Samples[i].BoringNumber
-		} else {
-			jobData.Samples[i].BoringNumber = currentBoring
+		case rowIdx > 6 && len(row) > 1 && strings.TrimSpace(row[1]) != "" &&
+			!strings.Contains(strings.ToLower(row[1]), "depth"):
+			// Looks like a sample row, but its boring label isn't one we recognize
+			logger.Error.Printf("WARNING: Row %d boring label %q doesn't match boring_pattern %q; its samples were skipped",
+				rowIdx+1, firstCell, boringPattern().String())
+			jobData.UnrecognizedRows = append(jobData.UnrecognizedRows, rowIdx+1)
+			currentBoring = ""
+			underUnrecognized = true
 		}
 	}
 
@@ -145,11 +218,37 @@ func ExcelToJSON(filePath string) (*JobData, error) {
 		return nil, fmt.Errorf("Excel file validation failed: %v", err)
 	}
 
+	if jobData.StatedTotal > 0 && jobData.StatedTotal != jobData.TotalSamples {
+		logger.Info.Printf("Job %s: Main Form states %d samples but %d were parsed - rows may have been missed",
+			jobData.JobNumber, jobData.StatedTotal, jobData.TotalSamples)
+	}
+
 	logger.Info.Printf("Excel data loaded and validated: Job %s with %d samples", jobData.JobNumber, len(jobData.Samples))
 
 	return jobData, nil
 }
 
+// parseStatedTotal returns the number after a "Total" label in a header row, or 0 if there is none
+func parseStatedTotal(row []string) int {
+	for col, cell := range row {
+		label := strings.ToLower(strings.TrimSpace(cell))
+		if label != "total" && label != "total samples" && label != "total samples:" && label != "total:" {
+			continue
+		}
+		for _, next := range row[col+1:] {
+			next = strings.TrimSpace(next)
+			if next == "" {
+				continue
+			}
+			if total, err := strconv.Atoi(next); err == nil && total > 0 {
+				return total
+			}
+			break
+		}
+	}
+	return 0
+}
+
 // validateJobData validates the Excel job data structure
 func validateJobData(jobData *JobData) error {
 	if jobData == nil {
@@ -182,8 +281,19 @@ func validateJobData(jobData *JobData) error {
 	return nil
 }
 
-// ProjectRoot is the root directory of the project
-const ProjectRoot = "/home/marco-mascorro/developer/reed"
+// ProjectRoot is the root directory of the project. Every pkg file operation
+// (projects, ex_project, oven tracking) is resolved under it, so pointing it
+// somewhere else with SetProjectRoot moves all reads and writes together.
+var ProjectRoot = "/home/marco-mascorro/developer/reed"
+
+// SetProjectRoot points all file operations at dir and returns a func that
+// restores the previous root. Tests use it to work in a temp directory.
+func SetProjectRoot(dir string) func() {
+	previous := ProjectRoot
+	ProjectRoot = dir
+	logger.Info.Printf("Project root set to %s", dir)
+	return func() { ProjectRoot = previous }
+}
 
 // GetProjectPath returns the full path relative to the project root
 func GetProjectPath(relativePath string) string {
@@ -300,6 +410,10 @@ type MoistureTestWriter struct {
 	FilePath     string
 	file         *excelize.File
 	sampleColMap map[string]string // Maps "BoringNo|Depth" to "SheetName|ColumnLetter"
+	sheetWidths  map[string]int    // Used width of each Moisture sheet when the file was opened
+	DryRun       bool              // Log writes without touching the Lab file (training mode)
+
+	PendingWrites []PendingWrite // Changes made in memory whose save failed, e.g. the share dropped
 }
 
 // InitMoistureTestFile creates the ex_project directory, copies the Lab file, and initializes the moisture writer
@@ -316,17 +430,27 @@ func InitMoistureTestFile(jobNumber string, sourceLabFilePath string) (*Moisture
 	srcPath := sourceLabFilePath
 	logger.Info.Printf("Using source Lab file: %s", srcPath)
 
-	// Destination uses the job number (which may include suffix like "25490_03")
-	dstPath := filepath.Join(dirPath, fmt.Sprintf("Lab_%s.xlsm", jobNumber))
+	// Destination uses the job number (which may include suffix like "25490_03") and keeps
+	// the source's extension, since excelize saves in the format the file was opened as
+	ext := labFileExt(srcPath)
+	if ext == "" {
+		ext = labFileExtensions[0]
+	}
+	dstPath := filepath.Join(dirPath, fmt.Sprintf("Lab_%s%s", jobNumber, strings.ToLower(ext)))
 
 	writer := &MoistureTestWriter{
 		JobNumber:    jobNumber,
 		FilePath:     dstPath,
 		sampleColMap: make(map[string]string),
+		DryRun:       Config.DryRun,
 	}
 
-	// Check if destination file exists, if not copy from source
-	if _, err := os.Stat(dstPath); os.IsNotExist(err) {
+	// In dry run the source Lab file is only read for mappings and never copied
+	openPath := dstPath
+	if writer.DryRun {
+		openPath = srcPath
+		logger.Info.Printf("[dry run] Reading mappings from %s without copying it to ex_project", srcPath)
+	} else if _, err := os.Stat(dstPath); os.IsNotExist(err) {
 		// Copy the source file
 		srcData, err := os.ReadFile(srcPath)
 		if err != nil {
@@ -338,29 +462,31 @@ func InitMoistureTestFile(jobNumber string, sourceLabFilePath string) (*Moisture
 			return nil, err
 		}
 		logger.Info.Printf("Copied Lab file to: %s", dstPath)
+		if Config.KeepOriginalLabFile {
+			snapshotOriginalLabFile(dstPath, srcData)
+		}
 	}
 
 	// Open the file
 	var err error
-	writer.file, err = excelize.OpenFile(dstPath)
+	writer.file, err = excelize.OpenFile(openPath)
 	if err != nil {
 		logger.Error.Printf("Failed to open Lab file: %v", err)
 		return nil, err
 	}
 
+	// The Lab template's macros must survive our saves; warn if a working copy has lost them
+	if openPath != srcPath && hasVBAProject(srcPath) && !hasVBAProject(openPath) {
+		logger.Error.Printf("WARNING: %s has macros but the working copy %s does not; macros were stripped", srcPath, openPath)
+	}
+
 	// First, get all samples from the Main Form to know what we need to map
 	allSamples := []struct {
 		Boring string
 		Depth  string
 	}{}
 
-	mainFormSheetName := ""
-	for _, name := range writer.file.GetSheetList() {
-		if name == "Main Form" || name == "!Main Form" {
-			mainFormSheetName = name
-			break
-		}
-	}
+	mainFormSheetName := findMainFormSheet(writer.file, srcPath)
 
 	if mainFormSheetName != "" {
 		mainRows, err := writer.file.GetRows(mainFormSheetName)
@@ -372,11 +498,15 @@ func InitMoistureTestFile(jobNumber string, sourceLabFilePath string) (*Moisture
 				if len(row) > 1 {
 					// Check if first column has a boring number
 					firstCell := strings.TrimSpace(row[0])
-					if strings.HasPrefix(firstCell, "B-") {
+					depth := strings.TrimSpace(row[1])
+					if isBoringLabel(firstCell) {
 						currentBoring = firstCell
+					} else if firstCell != "" && i > 6 && depth != "" && !strings.Contains(strings.ToLower(depth), "depth") {
+						// An unrecognized label starts a boring of its own, so rows under it are
+						// not the previous boring's (ExcelToJSON leaves them out the same way)
+						currentBoring = ""
 					}
 					// Check if second column has a depth
-					depth := strings.TrimSpace(row[1])
 					if depth != "" && currentBoring != "" && !strings.Contains(strings.ToLower(depth), "depth") {
 						allSamples = append(allSamples, struct {
 							Boring string
@@ -389,20 +519,57 @@ func InitMoistureTestFile(jobNumber string, sourceLabFilePath string) (*Moisture
 		logger.Info.Printf("Found %d samples in Main Form", len(allSamples))
 	}
 
+	// Build sample column map from all Moisture sheets (Moisture, Moisture2, Moisture3, etc.)
+	writer.sampleColMap = mapMoistureColumns(writer.file)
+	writer.sheetWidths = moistureSheetWidths(writer.file)
+
+	// Log any samples from Main Form that don't have mappings; UnmappedSamples reports them to the tech
+	for _, sample := range allSamples {
+		key := fmt.Sprintf("%s|%s", sample.Boring, sample.Depth)
+		if _, exists := writer.sampleColMap[key]; !exists {
+			logger.Error.Printf("WARNING: Sample %s is not in any Moisture sheet block!", key)
+		}
+	}
+
+	logger.Info.Printf("Initialized moisture writer with %d sample mappings across multiple sheets", len(writer.sampleColMap))
+	return writer, nil
+}
+
+// moistureSheetPattern matches the Lab file's Moisture sheets: "Moisture", "Moisture2", ...
+var moistureSheetPattern = regexp.MustCompile(`^Moisture\d*$`)
+
+// isMoistureSheet reports whether a sheet holds moisture sample blocks
+func isMoistureSheet(sheetName string) bool {
+	return moistureSheetPattern.MatchString(sheetName)
+}
+
+// minMoistureSheetRows is the fewest rows a Moisture sheet can have and still hold a full
+// sample block (title row, then "Boring No" through "Moisture Content")
+const minMoistureSheetRows = 10
+
+// mapMoistureColumns maps "BoringNo|Depth" to "SheetName|ColumnLetter|BaseRow" across
+// every Moisture sheet in the Lab file
+func mapMoistureColumns(f *excelize.File) map[string]string {
+	colMap := make(map[string]string)
+
 	// Build sample column map from all Moisture sheets (Moisture, Moisture2, Moisture3, etc.)
 	// The sheet has multiple blocks of samples. Each block has:
 	// - A "Boring No" row with boring numbers across columns
 	// - A "Depth" row (immediately below) with depth values
-	// - Data rows below that (Can No at +2, Wet wt at +3, Wt of can at +6)
-	sheetNames := writer.file.GetSheetList()
+	// - Data rows below that, at the offsets in Config.SheetLayouts.Moisture
+	sheetNames := f.GetSheetList()
 	for _, sheetName := range sheetNames {
-		// Check if this is a Moisture sheet
-		if sheetName == "Moisture" || strings.HasPrefix(sheetName, "Moisture") && !strings.Contains(sheetName, " ") {
-			rows, err := writer.file.GetRows(sheetName)
+		if isMoistureSheet(sheetName) {
+			rows, err := f.GetRows(sheetName)
 			if err != nil {
 				logger.Error.Printf("Failed to read %s sheet: %v", sheetName, err)
 				continue
 			}
+			if len(rows) < minMoistureSheetRows {
+				logger.Error.Printf("WARNING: Moisture sheet %q has only %d rows (expected at least %d); its samples may not be mapped",
+					sheetName, len(rows), minMoistureSheetRows)
+			}
+			blocksFound := 0
 
 			// Scan ALL rows to find "Boring No" headers (there may be multiple blocks)
 			for rowIdx := 0; rowIdx < len(rows)-1; rowIdx++ {
@@ -412,8 +579,10 @@ func InitMoistureTestFile(jobNumber string, sourceLabFilePath string) (*Moisture
 					boringRow := row
 					depthRow := rows[rowIdx+1]
 					baseRow := rowIdx + 1 // Convert to 1-based Excel row number
+					blocksFound++
 
 					logger.Info.Printf("Found Moisture block at row %d in %s", baseRow, sheetName)
+					checkMoistureRowLabels(rows, rowIdx, sheetName)
 
 					// Map each column to its boring/depth combination
 					for colIdx := 1; colIdx < len(boringRow) && colIdx < len(depthRow); colIdx++ {
@@ -424,25 +593,93 @@ func InitMoistureTestFile(jobNumber string, sourceLabFilePath string) (*Moisture
 							key := fmt.Sprintf("%s|%s", boring, depth)
 							// Store sheet name, column letter, AND base row for this block
 							// Format: "SheetName|ColumnLetter|BaseRow"
-							writer.sampleColMap[key] = fmt.Sprintf("%s|%s|%d", sheetName, colLetter, baseRow)
+							colMap[key] = fmt.Sprintf("%s|%s|%d", sheetName, colLetter, baseRow)
 							logger.Info.Printf("Mapped sample %s to %s column %s (block at row %d)", key, sheetName, colLetter, baseRow)
 						}
 					}
 				}
 			}
+			if blocksFound == 0 {
+				logger.Error.Printf("WARNING: Moisture sheet %q has no \"Boring No\" blocks; no samples were mapped from it", sheetName)
+			}
 		}
 	}
+	return colMap
+}
 
-	// Log any samples from Main Form that don't have mappings
-	for _, sample := range allSamples {
-		key := fmt.Sprintf("%s|%s", sample.Boring, sample.Depth)
-		if _, exists := writer.sampleColMap[key]; !exists {
-			logger.Error.Printf("WARNING: Sample %s is not in any Moisture sheet block!", key)
+// moistureRows returns the configured Moisture block row offsets, falling back to the
+// Lab template's layout when config was never loaded
+func moistureRows() MoistureRowOffsets {
+	if Config.SheetLayouts.Moisture == (MoistureRowOffsets{}) {
+		return defaultConfig.SheetLayouts.Moisture
+	}
+	return Config.SheetLayouts.Moisture
+}
+
+// checkMoistureRowLabels warns when the column A label at a configured row offset doesn't
+// match what the writers expect there, so a revised Lab template is caught before data lands
+// on the wrong row. headerIdx is the 0-based index of the block's "Boring No" row.
+func checkMoistureRowLabels(rows [][]string, headerIdx int, sheetName string) {
+	offsets := moistureRows()
+	expected := []struct {
+		offset int
+		label  string // Normalized start of the label (lowercase, no spaces or periods)
+	}{
+		{offsets.CanNo, "canno"},
+		{offsets.WetWtAndCan, "wetwt"},
+		{offsets.DryWtAndCan, "drywtofsoilandcan"},
+		{offsets.WtOfWater, "wtofwater"},
+		{offsets.WtOfCan, "wtofcan"},
+		{offsets.DryWtOfSoil, "drywtofsoil"},
+		{offsets.MoistureContent, "moisture"},
+	}
+
+	normalize := strings.NewReplacer(" ", "", ".", "")
+	for _, e := range expected {
+		label := ""
+		if idx := headerIdx + e.offset; idx < len(rows) && len(rows[idx]) > 0 {
+			label = rows[idx][0]
+		}
+		if !strings.HasPrefix(normalize.Replace(strings.ToLower(label)), e.label) {
+			logger.Error.Printf("WARNING: %s row %d is labeled %q, expected %q (block at row %d); check sheet_layouts.moisture in config.json",
+				sheetName, headerIdx+1+e.offset, label, e.label, headerIdx+1)
 		}
 	}
+}
 
-	logger.Info.Printf("Initialized moisture writer with %d sample mappings across multiple sheets", len(writer.sampleColMap))
-	return writer, nil
+// MoistureLocation is where a sample's moisture data goes in the Lab file
+type MoistureLocation struct {
+	Sheet   string
+	Column  string
+	BaseRow int // The block's "Boring No" row
+}
+
+// LoadMoistureLocations reads a Lab file without copying it and returns where each
+// sample ("BoringNo|Depth") lands on the Moisture sheets
+func LoadMoistureLocations(labFilePath string) (map[string]MoistureLocation, error) {
+	fullPath := labFilePath
+	if !filepath.IsAbs(labFilePath) {
+		fullPath = GetProjectPath(labFilePath)
+	}
+
+	f, err := excelize.OpenFile(fullPath)
+	if err != nil {
+		logger.Error.Printf("Failed to open Lab file for moisture locations: %v", err)
+		return nil, err
+	}
+	defer f.Close()
+
+	locations := make(map[string]MoistureLocation)
+	for key, mapping := range mapMoistureColumns(f) {
+		parts := strings.Split(mapping, "|")
+		if len(parts) != 3 {
+			continue
+		}
+		location := MoistureLocation{Sheet: parts[0], Column: parts[1]}
+		fmt.Sscanf(parts[2], "%d", &location.BaseRow)
+		locations[key] = location
+	}
+	return locations, nil
 }
 
 // getColumnLetter converts a 1-based column index to Excel column letter (1=A, 2=B, etc.)
@@ -456,6 +693,80 @@ func getColumnLetter(colIdx int) string {
 	return result
 }
 
+// columnLetterToIndex converts an Excel column letter to its 1-based index (A=1, AA=27, etc.),
+// the reverse of getColumnLetter. Returns 0 when s is not a column letter.
+func columnLetterToIndex(s string) int {
+	if s == "" {
+		return 0
+	}
+	idx := 0
+	for _, r := range strings.ToUpper(s) {
+		if r < 'A' || r > 'Z' {
+			return 0
+		}
+		idx = idx*26 + int(r-'A'+1)
+	}
+	return idx
+}
+
+// checkColumnInSheet makes sure a column lies within the sheet's used width, since excelize
+// happily writes to any column and a bad column would land data off the form. It reads the
+// whole sheet, so the moisture writer checks against the widths it recorded instead.
+func checkColumnInSheet(f *excelize.File, sheetName, colLetter string) error {
+	width, err := sheetWidth(f, sheetName)
+	if err != nil {
+		return err
+	}
+	return checkColumnWidth(sheetName, colLetter, width)
+}
+
+// checkColumnWidth makes sure a column lies within a sheet width
+func checkColumnWidth(sheetName, colLetter string, width int) error {
+	colIdx := columnLetterToIndex(colLetter)
+	if colIdx == 0 {
+		return fmt.Errorf("invalid column %q for sheet %s", colLetter, sheetName)
+	}
+	if colIdx > width {
+		return fmt.Errorf("column %s is outside sheet %s (last used column is %s)", colLetter, sheetName, getColumnLetter(width))
+	}
+	return nil
+}
+
+// sheetWidth returns the number of columns used by the sheet's widest row
+func sheetWidth(f *excelize.File, sheetName string) (int, error) {
+	rows, err := f.GetRows(sheetName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read sheet %s: %v", sheetName, err)
+	}
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	return width, nil
+}
+
+// moistureSheetWidths records the used width of each Moisture sheet
+func moistureSheetWidths(f *excelize.File) map[string]int {
+	widths := make(map[string]int)
+	for _, sheetName := range f.GetSheetList() {
+		if !isMoistureSheet(sheetName) {
+			continue
+		}
+		width, err := sheetWidth(f, sheetName)
+		if err != nil {
+			logger.Error.Printf("Failed to read %s sheet: %v", sheetName, err)
+			continue
+		}
+		widths[sheetName] = width
+	}
+	return widths
+}
+
+// checkColumn makes sure a mapped column lies within its Moisture sheet as it was opened
+func (w *MoistureTestWriter) checkColumn(sheetName, colLetter string) error {
+	return checkColumnWidth(sheetName, colLetter, w.sheetWidths[sheetName])
+}
+
 // WriteMoistureSample writes a single sample's moisture data to the appropriate Moisture sheet
 func (w *MoistureTestWriter) WriteMoistureSample(boringNumber, depth, canNo, canWeight, wetWeight string) error {
 	// Find the sheet and column for this sample
@@ -477,22 +788,30 @@ func (w *MoistureTestWriter) WriteMoistureSample(boringNumber, depth, canNo, can
 	baseRow := 0
 	fmt.Sscanf(parts[2], "%d", &baseRow)
 
-	// Write data to the correct cells in the Moisture sheet
-	// Offsets from base row (which is the "Boring No" row):
-	// +2: Can No.
-	// +3: Wet wt. and can
-	// +6: Wt. of can (Can Weight)
-	canNoRow := baseRow + 2
-	wetWtRow := baseRow + 3
-	canWtRow := baseRow + 6
+	// Write data to the correct cells in the Moisture sheet: Can No., Wet wt. and can,
+	// and Wt. of can, each offset from the base ("Boring No") row
+	offsets := moistureRows()
+	canNoRow := baseRow + offsets.CanNo
+	wetWtRow := baseRow + offsets.WetWtAndCan
+	canWtRow := baseRow + offsets.WtOfCan
+
+	if w.DryRun {
+		logger.Info.Printf("[dry run] Would write moisture sample to %s column %s (rows %d,%d,%d): Boring=%s, Depth=%s, Can#=%s, CanWt=%s, WetWt=%s",
+			sheetName, colLetter, canNoRow, wetWtRow, canWtRow, boringNumber, depth, canNo, canWeight, wetWeight)
+		return nil
+	}
+
+	if err := w.checkColumn(sheetName, colLetter); err != nil {
+		logger.Error.Printf("Refusing to write moisture sample %s: %v", key, err)
+		return err
+	}
 
 	w.file.SetCellValue(sheetName, fmt.Sprintf("%s%d", colLetter, canNoRow), canNo)
 	w.file.SetCellValue(sheetName, fmt.Sprintf("%s%d", colLetter, wetWtRow), wetWeight)
 	w.file.SetCellValue(sheetName, fmt.Sprintf("%s%d", colLetter, canWtRow), canWeight)
 
-	// Save file
-	if err := w.file.Save(); err != nil {
-		logger.Error.Printf("Failed to save moisture data: %v", err)
+	// Save file, holding the change if the share is gone
+	if err := w.saveOrHold("moisture sample " + key); err != nil {
 		return err
 	}
 
@@ -502,8 +821,178 @@ func (w *MoistureTestWriter) WriteMoistureSample(boringNumber, depth, canNo, can
 	return nil
 }
 
-// Close closes the Excel file
+// ClearMoistureSample blanks a sample's Can No., Wet wt. and Wt. of can cells and removes
+// its note, undoing WriteMoistureSample and WriteSampleNote
+func (w *MoistureTestWriter) ClearMoistureSample(boringNumber, depth string) error {
+	key := fmt.Sprintf("%s|%s", boringNumber, depth)
+	location, err := w.blankMoistureSample(boringNumber, depth)
+	if err != nil || location == "" {
+		return err
+	}
+
+	// Save file, holding the change if the share is gone
+	if err := w.saveOrHold("cleared moisture sample " + key); err != nil {
+		return err
+	}
+
+	logger.Info.Printf("Cleared moisture sample in %s: Boring=%s, Depth=%s", location, boringNumber, depth)
+	return nil
+}
+
+// DiscardMoistureSample takes back a moisture sample whose save failed and is being held:
+// its cells are blanked in the workbook in memory and the held write is dropped, so a later
+// save doesn't write a sample the tech backed out of. Nothing is saved here.
+func (w *MoistureTestWriter) DiscardMoistureSample(boringNumber, depth string) error {
+	key := fmt.Sprintf("%s|%s", boringNumber, depth)
+	if _, err := w.blankMoistureSample(boringNumber, depth); err != nil {
+		return err
+	}
+	w.dropPendingWrite("moisture sample " + key)
+	logger.Info.Printf("Discarded unsaved moisture sample %s for job %s", key, w.JobNumber)
+	return nil
+}
+
+// blankMoistureSample blanks a sample's cells and note in memory without saving, and returns
+// where they were for logging. The location is empty in dry run, where nothing was written.
+func (w *MoistureTestWriter) blankMoistureSample(boringNumber, depth string) (string, error) {
+	key := fmt.Sprintf("%s|%s", boringNumber, depth)
+	mapping, exists := w.sampleColMap[key]
+	if !exists {
+		logger.Error.Printf("No column mapping found for sample %s", key)
+		return "", fmt.Errorf("no column mapping for %s", key)
+	}
+
+	// Parse sheet name, column letter, and base row from mapping (format: "SheetName|ColumnLetter|BaseRow")
+	parts := strings.Split(mapping, "|")
+	if len(parts) != 3 {
+		logger.Error.Printf("Invalid mapping format for sample %s: %s", key, mapping)
+		return "", fmt.Errorf("invalid mapping format for %s", key)
+	}
+	sheetName := parts[0]
+	colLetter := parts[1]
+	baseRow := 0
+	fmt.Sscanf(parts[2], "%d", &baseRow)
+
+	// Same offsets as WriteMoistureSample
+	offsets := moistureRows()
+	canNoRow := baseRow + offsets.CanNo
+	wetWtRow := baseRow + offsets.WetWtAndCan
+	canWtRow := baseRow + offsets.WtOfCan
+
+	if w.DryRun {
+		logger.Info.Printf("[dry run] Would clear moisture sample in %s column %s (rows %d,%d,%d): Boring=%s, Depth=%s",
+			sheetName, colLetter, canNoRow, wetWtRow, canWtRow, boringNumber, depth)
+		return "", nil
+	}
+
+	if err := w.checkColumn(sheetName, colLetter); err != nil {
+		logger.Error.Printf("Refusing to clear moisture sample %s: %v", key, err)
+		return "", err
+	}
+
+	for _, row := range []int{canNoRow, wetWtRow, canWtRow} {
+		w.file.SetCellValue(sheetName, fmt.Sprintf("%s%d", colLetter, row), nil)
+	}
+	if err := w.file.DeleteComment(sheetName, fmt.Sprintf("%s%d", colLetter, canNoRow)); err != nil {
+		logger.Error.Printf("Failed to clear note on %s!%s%d: %v", sheetName, colLetter, canNoRow, err)
+		return "", err
+	}
+	return fmt.Sprintf("%s column %s (rows %d,%d,%d)", sheetName, colLetter, canNoRow, wetWtRow, canWtRow), nil
+}
+
+// WriteSampleNote attaches the tech's note as a comment on the sample's Can No. cell,
+// replacing any earlier note. An empty note just removes the comment, and does nothing
+// when there is none to remove.
+func (w *MoistureTestWriter) WriteSampleNote(boringNumber, depth, note string) error {
+	key := fmt.Sprintf("%s|%s", boringNumber, depth)
+	mapping, exists := w.sampleColMap[key]
+	if !exists {
+		if note == "" {
+			return nil
+		}
+		logger.Error.Printf("No column mapping found for sample %s", key)
+		return fmt.Errorf("no column mapping for %s", key)
+	}
+
+	// Parse sheet name, column letter, and base row from mapping (format: "SheetName|ColumnLetter|BaseRow")
+	parts := strings.Split(mapping, "|")
+	if len(parts) != 3 {
+		logger.Error.Printf("Invalid mapping format for sample %s: %s", key, mapping)
+		return fmt.Errorf("invalid mapping format for %s", key)
+	}
+	sheetName := parts[0]
+	colLetter := parts[1]
+	baseRow := 0
+	fmt.Sscanf(parts[2], "%d", &baseRow)
+
+	// Comment goes on the Can No. row
+	cell := fmt.Sprintf("%s%d", colLetter, baseRow+moistureRows().CanNo)
+	if note == "" && !hasComment(w.file, sheetName, cell) {
+		return nil
+	}
+
+	if w.DryRun {
+		logger.Info.Printf("[dry run] Would write note for %s to %s!%s: %q", key, sheetName, cell, note)
+		return nil
+	}
+
+	if err := w.checkColumn(sheetName, colLetter); err != nil {
+		logger.Error.Printf("Refusing to write note for %s: %v", key, err)
+		return err
+	}
+
+	// DeleteComment is a no-op when the cell has no comment
+	if err := w.file.DeleteComment(sheetName, cell); err != nil {
+		logger.Error.Printf("Failed to clear old note on %s!%s: %v", sheetName, cell, err)
+		return err
+	}
+	if note != "" {
+		if err := w.file.AddComment(sheetName, excelize.Comment{
+			Cell:   cell,
+			Author: "LMS",
+			Text:   note,
+		}); err != nil {
+			logger.Error.Printf("Failed to add note to %s!%s: %v", sheetName, cell, err)
+			return err
+		}
+	}
+
+	// Save file, holding the change if the share is gone
+	if err := w.saveOrHold("note for " + key); err != nil {
+		return err
+	}
+
+	logger.Info.Printf("Wrote note for %s to %s!%s: %q", key, sheetName, cell, note)
+	return nil
+}
+
+// hasComment reports whether the cell has a comment
+func hasComment(f *excelize.File, sheetName, cell string) bool {
+	comments, err := f.GetComments(sheetName)
+	if err != nil {
+		return false
+	}
+	for _, comment := range comments {
+		if comment.Cell == cell {
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes the Excel file. Held writes that still can't be saved are lost, so they are logged.
 func (w *MoistureTestWriter) Close() error {
+	if len(w.PendingWrites) > 0 {
+		if err := w.ReplayPendingWrites(); err != nil {
+			for _, pending := range w.PendingWrites {
+				logger.Error.Printf("WARNING: Closing job %s without saving %s (held since %s): %s",
+					w.JobNumber, pending.Description, pending.HeldAt.Format("3:04:05 PM"), pending.Err)
+			}
+		}
+		pendingWritersMu.Lock()
+		delete(pendingWriters, w)
+		pendingWritersMu.Unlock()
+	}
 	if w.file != nil {
 		return w.file.Close()
 	}
@@ -532,34 +1021,109 @@ func (w *MoistureTestWriter) GetSampleMapping(boringNumber, depth string) (strin
 	return fmt.Sprintf("%s|%s", parts[0], parts[2]), parts[1], true
 }
 
+// UnmappedSamples returns the samples that have no Moisture column, so saving them would
+// fail. Samples with no tests marked aren't pulled and need no column.
+func (w *MoistureTestWriter) UnmappedSamples(samples []SampleData) []SampleData {
+	var unmapped []SampleData
+	for _, sample := range samples {
+		if !sample.HasTests() {
+			continue
+		}
+		if _, _, ok := w.GetSampleMapping(sample.BoringNumber, sample.Depth); !ok {
+			unmapped = append(unmapped, sample)
+		}
+	}
+	return unmapped
+}
+
 // ProgressData represents saved progress for a job
 type ProgressData struct {
 	JobNumber          string `json:"job_number"`
 	CurrentSampleIndex int    `json:"current_sample_index"`
+	TotalSamples       int    `json:"total_samples,omitempty"` // Sample count when progress was saved, for job lists
 	LastSaved          string `json:"last_saved"`
+	SavedBy            string `json:"saved_by,omitempty"`
+	Completed          bool   `json:"completed"` // Set when the tech explicitly finishes the job
+	CompletedAt        string `json:"completed_at,omitempty"`
+	NewSamplesOnly     bool   `json:"new_samples_only,omitempty"` // Reopened for added samples, so pulling skips recorded ones
 }
 
 // SampleBackupData represents a single sample's backup data
 type SampleBackupData struct {
-	JobNumber     string `json:"job_number"`
-	BoringNumber  string `json:"boring_number"`
-	Depth         string `json:"depth"`
-	CanNumber     string `json:"can_number"`
-	CanWeight     string `json:"can_weight"`
-	WetWeight     string `json:"wet_weight"`
-	SuctionCanNo  string `json:"suction_can_no"`
-	Timestamp     string `json:"timestamp"`
+	JobNumber      string `json:"job_number"`
+	BoringNumber   string `json:"boring_number"`
+	Depth          string `json:"depth"`
+	CanNumber      string `json:"can_number"`
+	CanWeight      string `json:"can_weight"`
+	WetWeight      string `json:"wet_weight"`
+	SuctionCanNo   string `json:"suction_can_no"`
+	DryWeight      string `json:"dry_weight,omitempty"`      // Recorded during Morning Count
+	MoistureSheet  string `json:"moisture_sheet,omitempty"`  // "SheetName|BaseRow" from GetSampleMapping
+	MoistureColumn string `json:"moisture_column,omitempty"` // Column letter on the Moisture sheet
+	Notes          string `json:"notes,omitempty"`           // Free-text flag from the tech (cracked can, wet sample, etc.)
+	SkipReason     string `json:"skip_reason,omitempty"`     // Set instead of weights when the sample was not tested
+	EnteredBy      string `json:"entered_by,omitempty"`      // User who pulled (or last edited) the sample
+	WeighedBy      string `json:"weighed_by,omitempty"`      // User who recorded (or last edited) the dry weight
+	NeedsRecheck   bool   `json:"needs_recheck,omitempty"`   // Tech flagged the reading for a supervisor to check
+	Timestamp      string `json:"timestamp"`
 }
 
+// IsSkipped reports whether the sample was recorded as not tested
+func (s SampleBackupData) IsSkipped() bool {
+	return s.SkipReason != ""
+}
+
+// Where a pulled sample is in the moisture workflow
+const (
+	SampleStatusSkipped     = "Skipped"
+	SampleStatusInOven      = "In oven"
+	SampleStatusDryRecorded = "Dry recorded"
+	SampleStatusIncomplete  = "Incomplete"
+)
+
+// ProcessingStatus reports where the sample is in the moisture workflow. inOven says
+// whether its can is in oven tracking; a sample neither in the oven nor with a dry weight
+// is incomplete, e.g. its can was taken out without a Morning Count.
+func (s SampleBackupData) ProcessingStatus(inOven bool) string {
+	switch {
+	case s.IsSkipped():
+		return SampleStatusSkipped
+	case s.DryWeight != "":
+		return SampleStatusDryRecorded
+	case inOven:
+		return SampleStatusInOven
+	default:
+		return SampleStatusIncomplete
+	}
+}
+
+// BackupSchemaVersion is the backup file format written by this version. Bump it when the
+// format changes and add a step to migrateBackup that upgrades files from the previous one.
+const BackupSchemaVersion = 1
+
+// ErrBackupTooNew is returned when saving a backup written by a newer version of the app,
+// which could hold fields this version doesn't know and would drop
+var ErrBackupTooNew = errors.New("the backup was written by a newer version of the app")
+
 // BackupData represents the complete backup file structure
 type BackupData struct {
-	JobNumber    string             `json:"job_number"`
-	LastUpdated  string             `json:"last_updated"`
-	TotalSamples int                `json:"total_samples"`
-	Samples      []SampleBackupData `json:"samples"`
+	SchemaVersion int                `json:"schema_version"` // 0 for files written before versioning
+	JobNumber     string             `json:"job_number"`
+	LastUpdated   string             `json:"last_updated"`
+	TotalSamples  int                `json:"total_samples"`
+	Samples       []SampleBackupData `json:"samples"`
+}
+
+// BackupPath returns the job's backup file, ex_project/<job>/backup.json under ProjectRoot.
+// Every read and write of a job's backup goes through here so pulls and edits can't end up
+// on different files when the app is started from another directory.
+func BackupPath(jobNumber string) string {
+	return filepath.Join(ProjectRoot, "ex_project", jobNumber, "backup.json")
 }
 
-// LoadBackupData loads the backup data from a JSON file
+// LoadBackupData loads the backup data from a JSON file. An older file is upgraded in memory
+// only and reaches the current schema version the next time it is saved, so read-only
+// callers such as FindCan and the backup-csv export never write to the share.
 func LoadBackupData(backupFile string) (*BackupData, error) {
 	data, err := os.ReadFile(backupFile)
 	if err != nil {
@@ -582,16 +1146,127 @@ func LoadBackupData(backupFile string) (*BackupData, error) {
 		return nil, fmt.Errorf("backup file corrupted or invalid JSON format: %v", err)
 	}
 
+	// Upgrade older files before validating, since migration can fill fields validation needs
+	if from := backup.SchemaVersion; migrateBackup(&backup) {
+		logger.Info.Printf("Read %s as schema version %d; it is saved as version %d on the next change", backupFile, from, BackupSchemaVersion)
+	}
+
 	// Validate backup data
 	if err := validateBackupData(&backup); err != nil {
 		logger.Error.Printf("Backup data validation failed: %v", err)
 		return nil, fmt.Errorf("backup data validation failed: %v", err)
 	}
 
+	// Older backups may hold the same sample more than once
+	if removed := CollapseDuplicateSamples(&backup); removed > 0 {
+		logger.Info.Printf("Collapsed %d duplicate sample entries in %s", removed, backupFile)
+	}
+
 	logger.Info.Printf("Successfully loaded and validated backup data: %d samples", len(backup.Samples))
 	return &backup, nil
 }
 
+// migrateBackup upgrades backup in place from its schema version to BackupSchemaVersion, one
+// version at a time. Returns true if anything was upgraded. A file from a newer version is
+// left alone, and SaveBackupDataToFile refuses to save it so it isn't downgraded.
+func migrateBackup(backup *BackupData) bool {
+	if backup.SchemaVersion > BackupSchemaVersion {
+		logger.Error.Printf("WARNING: Backup for job %s has schema version %d, newer than this version (%d); reading it as is, it won't be saved over",
+			backup.JobNumber, backup.SchemaVersion, BackupSchemaVersion)
+		return false
+	}
+	if backup.SchemaVersion == BackupSchemaVersion {
+		return false
+	}
+
+	for backup.SchemaVersion < BackupSchemaVersion {
+		switch backup.SchemaVersion {
+		case 0:
+			// Unversioned files could hold the same sample more than once and some early
+			// ones left the job number off the file or its samples
+			if backup.JobNumber == "" {
+				for _, sample := range backup.Samples {
+					if sample.JobNumber != "" {
+						backup.JobNumber = sample.JobNumber
+						break
+					}
+				}
+			}
+			for i := range backup.Samples {
+				if backup.Samples[i].JobNumber == "" {
+					backup.Samples[i].JobNumber = backup.JobNumber
+				}
+			}
+			CollapseDuplicateSamples(backup)
+		}
+		backup.SchemaVersion++
+	}
+	return true
+}
+
+// CollapseDuplicateSamples merges entries that share a boring/depth, keeping the most recent
+// by timestamp in the position of the first entry. Returns the number of entries removed.
+func CollapseDuplicateSamples(backup *BackupData) int {
+	positions := map[string]int{}
+	collapsed := []SampleBackupData{}
+	for _, sample := range backup.Samples {
+		key := sample.BoringNumber + "|" + sample.Depth
+		pos, exists := positions[key]
+		if !exists {
+			positions[key] = len(collapsed)
+			collapsed = append(collapsed, sample)
+			continue
+		}
+		// Ties (and unparseable timestamps) go to the later entry
+		if !sampleTimestamp(sample).Before(sampleTimestamp(collapsed[pos])) {
+			collapsed[pos] = sample
+		}
+	}
+
+	removed := len(backup.Samples) - len(collapsed)
+	backup.Samples = collapsed
+	backup.TotalSamples = len(collapsed)
+	return removed
+}
+
+// sampleTimestamp parses a backup entry's timestamp, returning the zero time if it can't be read
+func sampleTimestamp(sample SampleBackupData) time.Time {
+	t, err := ParseTimestamp(sample.Timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// CleanupBackupFile collapses duplicate sample entries in a backup file and rewrites it
+// if anything changed. Returns the number of entries removed.
+func CleanupBackupFile(backupFile string) (int, error) {
+	data, err := os.ReadFile(backupFile)
+	if err != nil {
+		logger.Error.Printf("Failed to read backup file: %v", err)
+		return 0, err
+	}
+
+	var backup BackupData
+	if err := json.Unmarshal(data, &backup); err != nil {
+		logger.Error.Printf("Failed to unmarshal backup data: %v", err)
+		return 0, fmt.Errorf("backup file corrupted or invalid JSON format: %v", err)
+	}
+
+	removed := CollapseDuplicateSamples(&backup)
+	migrated := migrateBackup(&backup)
+	if removed == 0 && !migrated {
+		return 0, nil
+	}
+
+	if err := SaveBackupDataToFile(&backup, backupFile); err != nil {
+		return 0, err
+	}
+
+	logger.Info.Printf("Removed %d duplicate sample entries from %s", removed, backupFile)
+	return removed, nil
+}
+
 // validateBackupData validates the structure and content of backup data
 func validateBackupData(backup *BackupData) error {
 	if backup == nil {
@@ -627,6 +1302,10 @@ func validateSampleBackupData(sample *SampleBackupData, index int) error {
 	if sample.Depth == "" {
 		return fmt.Errorf("depth is empty")
 	}
+	// A skipped sample records a reason instead of weights
+	if sample.IsSkipped() {
+		return nil
+	}
 	if sample.CanNumber == "" {
 		return fmt.Errorf("can number is empty")
 	}
@@ -648,9 +1327,19 @@ func validateSampleBackupData(sample *SampleBackupData, index int) error {
 	return nil
 }
 
-// SaveBackupDataToFile saves the backup data to a JSON file
+// SaveBackupDataToFile saves the backup data to a JSON file. A backup from a newer version
+// of the app is refused with ErrBackupTooNew.
 func SaveBackupDataToFile(backup *BackupData, backupFile string) error {
-	backup.LastUpdated = time.Now().Format("2006-01-02 15:04:05")
+	if backup.SchemaVersion > BackupSchemaVersion {
+		logger.Error.Printf("Refusing to save %s: schema version %d is newer than this version (%d)",
+			backupFile, backup.SchemaVersion, BackupSchemaVersion)
+		return fmt.Errorf("%w (schema version %d, this version writes %d); update the app before changing this job",
+			ErrBackupTooNew, backup.SchemaVersion, BackupSchemaVersion)
+	}
+	if backup.SchemaVersion < BackupSchemaVersion {
+		backup.SchemaVersion = BackupSchemaVersion
+	}
+	backup.LastUpdated = Now()
 	backup.TotalSamples = len(backup.Samples)
 
 	jsonData, err := json.MarshalIndent(backup, "", "  ")
@@ -669,14 +1358,53 @@ func SaveBackupDataToFile(backup *BackupData, backupFile string) error {
 }
 
 // SaveSampleBackup saves a sample to the JSON backup file
-func SaveSampleBackup(jobNumber, boringNumber, depth, canNo, canWeight, wetWeight, suctionCanNo string) error {
+// moistureSheet/moistureColumn record where the sample lives so it can be recomputed without remapping;
+// enteredBy is the ID of the user pulling it
+func SaveSampleBackup(jobNumber, boringNumber, depth, canNo, canWeight, wetWeight, suctionCanNo, moistureSheet, moistureColumn, notes, enteredBy string) error {
+	return upsertSampleBackup(SampleBackupData{
+		JobNumber:      jobNumber,
+		BoringNumber:   boringNumber,
+		Depth:          depth,
+		CanNumber:      canNo,
+		CanWeight:      canWeight,
+		WetWeight:      wetWeight,
+		SuctionCanNo:   suctionCanNo,
+		MoistureSheet:  moistureSheet,
+		MoistureColumn: moistureColumn,
+		Notes:          notes,
+		EnteredBy:      enteredBy,
+		Timestamp:      Now(),
+	})
+}
+
+// SaveSkippedSample records that a sample was not tested (lost, not enough material, ...)
+// so the job can move past it without placeholder weights. enteredBy is the ID of the user skipping it.
+func SaveSkippedSample(jobNumber, boringNumber, depth, reason, enteredBy string) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("a reason is required to skip a sample")
+	}
+	return upsertSampleBackup(SampleBackupData{
+		JobNumber:    jobNumber,
+		BoringNumber: boringNumber,
+		Depth:        depth,
+		SkipReason:   strings.TrimSpace(reason),
+		EnteredBy:    enteredBy,
+		Timestamp:    Now(),
+	})
+}
+
+// upsertSampleBackup writes newSample to the job's backup file, replacing an existing
+// entry for the same boring/depth or appending a new one
+func upsertSampleBackup(newSample SampleBackupData) error {
+	jobNumber, boringNumber, depth := newSample.JobNumber, newSample.BoringNumber, newSample.Depth
+
 	dirPath := filepath.Join(ProjectRoot, "ex_project", jobNumber)
 	if err := os.MkdirAll(dirPath, 0755); err != nil {
 		logger.Error.Printf("Failed to create directory for backup: %v", err)
 		return err
 	}
 
-	backupFile := filepath.Join(dirPath, "backup.json")
+	backupFile := BackupPath(jobNumber)
 
 	// Load existing backup or create new one
 	var backup BackupData
@@ -684,65 +1412,341 @@ func SaveSampleBackup(jobNumber, boringNumber, depth, canNo, canWeight, wetWeigh
 		if err := json.Unmarshal(data, &backup); err != nil {
 			logger.Error.Printf("Failed to unmarshal existing backup: %v", err)
 			backup = BackupData{
-				JobNumber: jobNumber,
-				Samples:   []SampleBackupData{},
+				SchemaVersion: BackupSchemaVersion,
+				JobNumber:     jobNumber,
+				Samples:       []SampleBackupData{},
 			}
 		}
 	} else {
 		backup = BackupData{
-			JobNumber: jobNumber,
-			Samples:   []SampleBackupData{},
+			SchemaVersion: BackupSchemaVersion,
+			JobNumber:     jobNumber,
+			Samples:       []SampleBackupData{},
 		}
 	}
 
-	// Create new sample entry
-	newSample := SampleBackupData{
-		JobNumber:    jobNumber,
-		BoringNumber: boringNumber,
-		Depth:        depth,
-		CanNumber:    canNo,
-		CanWeight:    canWeight,
-		WetWeight:    wetWeight,
-		SuctionCanNo: suctionCanNo,
-		Timestamp:    time.Now().Format("2006-01-02 15:04:05"),
+	migrateBackup(&backup)
+	CollapseDuplicateSamples(&backup)
+
+	// Replace the existing entry when a sample is re-entered, otherwise append
+	replaced := false
+	for i := range backup.Samples {
+		if backup.Samples[i].BoringNumber == boringNumber && backup.Samples[i].Depth == depth {
+			logger.Info.Printf("Overwriting existing backup entry for %s|%s (was Can #%s)", boringNumber, depth, backup.Samples[i].CanNumber)
+			// A recheck flag stays until it is cleared, even when the sample is edited
+			newSample.NeedsRecheck = newSample.NeedsRecheck || backup.Samples[i].NeedsRecheck
+			backup.Samples[i] = newSample
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		backup.Samples = append(backup.Samples, newSample)
+	}
+	// Save to file, which refuses a backup from a newer version
+	if err := SaveBackupDataToFile(&backup, backupFile); err != nil {
+		return err
 	}
 
-	// Append to samples array
-	backup.Samples = append(backup.Samples, newSample)
-	backup.TotalSamples = len(backup.Samples)
-	backup.LastUpdated = time.Now().Format("2006-01-02 15:04:05")
+	if newSample.IsSkipped() {
+		logger.Info.Printf("Saved skipped sample backup: Job=%s, Boring=%s, Depth=%s, Reason=%s", jobNumber, boringNumber, depth, newSample.SkipReason)
+	} else {
+		logger.Info.Printf("Saved sample backup: Job=%s, Boring=%s, Depth=%s", jobNumber, boringNumber, depth)
+	}
+	return nil
+}
 
-	// Save to file
-	jsonData, err := json.MarshalIndent(backup, "", "  ")
+// FindSampleBackup returns the backup entry for a boring/depth in the job's backup file,
+// or nil if the sample has not been saved yet
+func FindSampleBackup(jobNumber, boringNumber, depth string) (*SampleBackupData, error) {
+	backupFile := BackupPath(jobNumber)
+
+	backup, err := LoadBackupData(backupFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range backup.Samples {
+		if backup.Samples[i].BoringNumber == boringNumber && backup.Samples[i].Depth == depth {
+			sample := backup.Samples[i]
+			return &sample, nil
+		}
+	}
+	return nil, nil
+}
+
+// CountRecordedSamples returns how many samples in the job's backup file were tested
+// and how many were skipped
+func CountRecordedSamples(jobNumber string) (int, int, error) {
+	backupFile := BackupPath(jobNumber)
+
+	backup, err := LoadBackupData(backupFile)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tested, skipped := 0, 0
+	for _, sample := range backup.Samples {
+		if sample.IsSkipped() {
+			skipped++
+		} else {
+			tested++
+		}
+	}
+	return tested, skipped, nil
+}
+
+// testTypes returns the configured Main Form tests, falling back to the defaults when unset
+func testTypes() []TestType {
+	if len(Config.Tests) == 0 {
+		return defaultConfig.Tests
+	}
+	return Config.Tests
+}
+
+// TestCategory returns the category of a test name: the category of the configured test
+// with that name, else of the first whose keywords it contains, else other
+func TestCategory(test string) string {
+	for _, t := range testTypes() {
+		if t.Name == test {
+			return t.Category
+		}
+	}
+	for _, t := range testTypes() {
+		for _, keyword := range t.Keywords {
+			if keyword != "" && strings.Contains(test, keyword) {
+				return t.Category
+			}
+		}
+	}
+	return TestCategoryOther
+}
+
+// hasTestCategory reports whether any of the sample's tests is in category
+func (s SampleData) hasTestCategory(category string) bool {
+	for _, test := range s.Tests {
+		if TestCategory(test) == category {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSoilSuction reports whether the sample needs a soil suction test
+func (s SampleData) HasSoilSuction() bool {
+	return s.hasTestCategory(TestCategorySuction)
+}
+
+// HasOtherTests reports whether the sample has tests besides moisture and soil suction
+func (s SampleData) HasOtherTests() bool {
+	return s.hasTestCategory(TestCategoryOther)
+}
+
+// HasTests reports whether any test is marked for the sample on the Main Form
+func (s SampleData) HasTests() bool {
+	return len(s.Tests) > 0
+}
+
+// NextSampleWithTests returns the index of the first sample at or after from that has a test
+// marked, or len(samples) if there is none. ExcelToJSON keeps samples with no tests marked so
+// the job lists every row of the Main Form, but there is nothing to pull for them, so the
+// pull workflow passes over them instead of asking for moisture data.
+func NextSampleWithTests(samples []SampleData, from int) int {
+	if from < 0 {
+		from = 0
+	}
+	for i := from; i < len(samples); i++ {
+		if samples[i].HasTests() {
+			return i
+		}
+	}
+	return len(samples)
+}
+
+// CountRemainingSamples counts the samples with no entry in the job's backup yet, split into
+// moisture-only and suction-bearing samples since suction entry takes longer.
+// Skipped samples are resolved, and samples with no tests marked are never pulled, so
+// neither is remaining.
+func CountRemainingSamples(jobNumber string, samples []SampleData) (int, int, error) {
+	backupFile := BackupPath(jobNumber)
+
+	backup, err := LoadBackupData(backupFile)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	resolved := make(map[string]bool)
+	for _, sample := range backup.Samples {
+		resolved[sample.BoringNumber+"|"+sample.Depth] = true
+	}
+
+	moisture, suction := 0, 0
+	for _, sample := range samples {
+		if !sample.HasTests() || resolved[sample.BoringNumber+"|"+sample.Depth] {
+			continue
+		}
+		if sample.HasSoilSuction() {
+			suction++
+		} else {
+			moisture++
+		}
+	}
+	return moisture, suction, nil
+}
+
+// UpdateSampleDryWeight records the dry weight for a sample in the job's backup file, and
+// weighedBy as the ID of the user who weighed it
+func UpdateSampleDryWeight(jobNumber, boringNumber, depth, dryWeight, weighedBy string) error {
+	backupFile := BackupPath(jobNumber)
+
+	backup, err := LoadBackupData(backupFile)
 	if err != nil {
-		logger.Error.Printf("Failed to marshal backup data: %v", err)
 		return err
 	}
 
-	if err := os.WriteFile(backupFile, jsonData, 0644); err != nil {
-		logger.Error.Printf("Failed to write backup file: %v", err)
+	sampleFound := false
+	for i := range backup.Samples {
+		if backup.Samples[i].BoringNumber == boringNumber && backup.Samples[i].Depth == depth {
+			backup.Samples[i].DryWeight = dryWeight
+			backup.Samples[i].WeighedBy = weighedBy
+			sampleFound = true
+		}
+	}
+	if !sampleFound {
+		logger.Error.Printf("Sample %s|%s not found in backup for job %s", boringNumber, depth, jobNumber)
+		return fmt.Errorf("sample %s|%s not found in backup", boringNumber, depth)
+	}
+
+	if backup.JobNumber == "" {
+		backup.JobNumber = jobNumber
+	}
+	if err := SaveBackupDataToFile(backup, backupFile); err != nil {
 		return err
 	}
 
-	logger.Info.Printf("Saved sample backup: Job=%s, Boring=%s, Depth=%s", jobNumber, boringNumber, depth)
+	logger.Info.Printf("Recorded dry weight %s for Job=%s, Boring=%s, Depth=%s", dryWeight, jobNumber, boringNumber, depth)
 	return nil
 }
 
-// SaveProgress saves the current sample index to a progress file
-func SaveProgress(jobNumber string, currentSampleIndex int) error {
-	dirPath := filepath.Join(ProjectRoot, "ex_project", jobNumber)
-	if err := os.MkdirAll(dirPath, 0755); err != nil {
-		logger.Error.Printf("Failed to create directory for progress: %v", err)
+// SetSampleNeedsRecheck flags a sample in the job's backup file for a supervisor to
+// recheck, or clears the flag
+func SetSampleNeedsRecheck(jobNumber, boringNumber, depth string, needsRecheck bool) error {
+	backupFile := BackupPath(jobNumber)
+
+	backup, err := LoadBackupData(backupFile)
+	if err != nil {
 		return err
 	}
 
-	progressFile := filepath.Join(dirPath, "progress.json")
-	progress := ProgressData{
+	sampleFound := false
+	for i := range backup.Samples {
+		if backup.Samples[i].BoringNumber == boringNumber && backup.Samples[i].Depth == depth {
+			backup.Samples[i].NeedsRecheck = needsRecheck
+			sampleFound = true
+		}
+	}
+	if !sampleFound {
+		logger.Error.Printf("Sample %s|%s not found in backup for job %s", boringNumber, depth, jobNumber)
+		return fmt.Errorf("sample %s|%s not found in backup", boringNumber, depth)
+	}
+
+	if backup.JobNumber == "" {
+		backup.JobNumber = jobNumber
+	}
+	if err := SaveBackupDataToFile(backup, backupFile); err != nil {
+		return err
+	}
+
+	logger.Info.Printf("Set needs recheck to %v for Job=%s, Boring=%s, Depth=%s", needsRecheck, jobNumber, boringNumber, depth)
+	return nil
+}
+
+// RemoveSampleBackup deletes a sample's entry from the job's backup file (used by undo).
+// Returns false if the sample was not in the backup.
+func RemoveSampleBackup(jobNumber, boringNumber, depth string) (bool, error) {
+	backupFile := BackupPath(jobNumber)
+
+	backup, err := LoadBackupData(backupFile)
+	if err != nil {
+		return false, err
+	}
+
+	kept := backup.Samples[:0]
+	removed := false
+	for _, sample := range backup.Samples {
+		if sample.BoringNumber == boringNumber && sample.Depth == depth {
+			removed = true
+			continue
+		}
+		kept = append(kept, sample)
+	}
+	if !removed {
+		logger.Info.Printf("Sample %s|%s not in backup for job %s, nothing to remove", boringNumber, depth, jobNumber)
+		return false, nil
+	}
+	backup.Samples = kept
+
+	if backup.JobNumber == "" {
+		backup.JobNumber = jobNumber
+	}
+	if err := SaveBackupDataToFile(backup, backupFile); err != nil {
+		return false, err
+	}
+
+	logger.Info.Printf("Removed sample backup: Job=%s, Boring=%s, Depth=%s", jobNumber, boringNumber, depth)
+	return true, nil
+}
+
+// LookupMoistureLocation opens the job's working Lab file and finds where a sample lives
+// on the Moisture sheets. Returns "SheetName|BaseRow" and the column letter, matching the
+// format stored in oven tracking.
+func LookupMoistureLocation(jobNumber, labFilePath, boringNumber, depth string) (string, string, error) {
+	writer, err := InitMoistureTestFile(jobNumber, labFilePath)
+	if err != nil {
+		return "", "", err
+	}
+	defer writer.Close()
+
+	moistureSheet, moistureColumn, found := writer.GetSampleMapping(boringNumber, depth)
+	if !found {
+		logger.Error.Printf("No moisture mapping found for %s|%s in job %s", boringNumber, depth, jobNumber)
+		return "", "", fmt.Errorf("no moisture column mapping for %s|%s", boringNumber, depth)
+	}
+	return moistureSheet, moistureColumn, nil
+}
+
+// SaveProgress saves the current sample index (and the job's sample count) to a progress file
+func SaveProgress(jobNumber string, currentSampleIndex, totalSamples int) error {
+	progress := &ProgressData{
 		JobNumber:          jobNumber,
 		CurrentSampleIndex: currentSampleIndex,
-		LastSaved:          fmt.Sprintf("%v", os.Getenv("USER")),
+		TotalSamples:       totalSamples,
+		LastSaved:          Now(),
+		SavedBy:            os.Getenv("USER"),
+	}
+	// A job reopened for added samples stays that way until it is completed again
+	if existing, err := LoadProgressData(jobNumber); err == nil {
+		progress.NewSamplesOnly = existing.NewSamplesOnly
+	}
+
+	if err := writeProgressData(progress); err != nil {
+		return err
+	}
+
+	logger.Info.Printf("Saved progress for job %s: sample index %d", jobNumber, currentSampleIndex)
+	return nil
+}
+
+// writeProgressData writes ex_project/<job>/progress.json
+func writeProgressData(progress *ProgressData) error {
+	dirPath := filepath.Join(ProjectRoot, "ex_project", progress.JobNumber)
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		logger.Error.Printf("Failed to create directory for progress: %v", err)
+		return err
 	}
 
+	progressFile := filepath.Join(dirPath, "progress.json")
+
 	jsonData, err := json.MarshalIndent(progress, "", "  ")
 	if err != nil {
 		logger.Error.Printf("Failed to marshal progress data: %v", err)
@@ -753,8 +1757,73 @@ func SaveProgress(jobNumber string, currentSampleIndex int) error {
 		logger.Error.Printf("Failed to write progress file: %v", err)
 		return err
 	}
+	return nil
+}
 
-	logger.Info.Printf("Saved progress for job %s: sample index %d", jobNumber, currentSampleIndex)
+// LoadProgressData reads progress.json as-is for job lists (no Lab file validation).
+// A job that has never been pulled returns empty progress.
+func LoadProgressData(jobNumber string) (*ProgressData, error) {
+	progressFile := filepath.Join(ProjectRoot, "ex_project", jobNumber, "progress.json")
+
+	data, err := os.ReadFile(progressFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ProgressData{JobNumber: jobNumber}, nil
+		}
+		logger.Error.Printf("Failed to read progress file: %v", err)
+		return nil, err
+	}
+	if len(data) == 0 {
+		return &ProgressData{JobNumber: jobNumber}, nil
+	}
+
+	var progress ProgressData
+	if err := json.Unmarshal(data, &progress); err != nil {
+		logger.Error.Printf("Failed to unmarshal progress data (file may be corrupted): %v", err)
+		return nil, fmt.Errorf("progress file corrupted or invalid JSON format: %v", err)
+	}
+	if progress.JobNumber == "" {
+		progress.JobNumber = jobNumber
+	}
+	return &progress, nil
+}
+
+// MarkJobComplete records that the tech explicitly finished the job
+func MarkJobComplete(jobNumber string) error {
+	progress, err := LoadProgressData(jobNumber)
+	if err != nil {
+		return err
+	}
+
+	progress.Completed = true
+	progress.NewSamplesOnly = false
+	progress.CompletedAt = Now()
+	progress.LastSaved = progress.CompletedAt
+	progress.SavedBy = os.Getenv("USER")
+	if err := writeProgressData(progress); err != nil {
+		return err
+	}
+
+	logger.Info.Printf("Marked job %s complete", jobNumber)
+	return nil
+}
+
+// ReopenJob clears the completed flag so more samples can be pulled, keeping the saved index
+func ReopenJob(jobNumber string) error {
+	progress, err := LoadProgressData(jobNumber)
+	if err != nil {
+		return err
+	}
+
+	progress.Completed = false
+	progress.CompletedAt = ""
+	progress.LastSaved = Now()
+	progress.SavedBy = os.Getenv("USER")
+	if err := writeProgressData(progress); err != nil {
+		return err
+	}
+
+	logger.Info.Printf("Reopened job %s", jobNumber)
 	return nil
 }
 
@@ -818,9 +1887,69 @@ type LabFileInfo struct {
 	Suffix   string // The suffix part (e.g., "02", "03", or "" for base file)
 }
 
+// labFileExtensions are the Lab file types the app reads, in order of preference when a
+// job folder has the same Lab file in both formats
+var labFileExtensions = []string{".xlsm", ".xlsx"}
+
+// labFileExt returns the Lab file extension of fileName, or "" if it isn't a Lab file type
+func labFileExt(fileName string) string {
+	for _, ext := range labFileExtensions {
+		if strings.HasSuffix(strings.ToLower(fileName), ext) {
+			return fileName[len(fileName)-len(ext):]
+		}
+	}
+	return ""
+}
+
+// findLabFileNames returns the job's Lab file names (Lab_<job>.xlsm, Lab_<job>_02.xlsx, ...)
+// in directory order. When one name exists as both .xlsm and .xlsx, only the .xlsm is kept.
+func findLabFileNames(entries []os.DirEntry, jobNumber string) []string {
+	basePattern := fmt.Sprintf("Lab_%s", jobNumber)
+	var names []string
+	seen := map[string]int{} // Name without extension -> index in names
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		fileName := entry.Name()
+		ext := labFileExt(fileName)
+		// Check if it matches Lab_<jobNumber>.xlsm or Lab_<jobNumber>_XX.xlsm (or .xlsx)
+		if ext == "" || !strings.HasPrefix(fileName, basePattern) {
+			continue
+		}
+		stem := strings.TrimSuffix(fileName, ext)
+		if i, exists := seen[stem]; exists {
+			if strings.EqualFold(ext, ".xlsm") {
+				logger.Info.Printf("Both %s and %s exist, using %s", names[i], fileName, fileName)
+				names[i] = fileName
+			} else {
+				logger.Info.Printf("Both %s and %s exist, using %s", names[i], fileName, names[i])
+			}
+			continue
+		}
+		seen[stem] = len(names)
+		names = append(names, fileName)
+	}
+	return names
+}
+
+// WorkingLabFilePath returns the job's working copy of its Lab file in ex_project, which
+// keeps the source file's extension. Defaults to .xlsm when no copy exists yet.
+func WorkingLabFilePath(jobNumber string) string {
+	dirPath := filepath.Join(ProjectRoot, "ex_project", jobNumber)
+	for _, ext := range labFileExtensions {
+		path := filepath.Join(dirPath, fmt.Sprintf("Lab_%s%s", jobNumber, ext))
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dirPath, fmt.Sprintf("Lab_%s%s", jobNumber, labFileExtensions[0]))
+}
+
 // FindAllLabFiles finds all Lab files for a job (returns Lab_XXXXX.xlsm, Lab_XXXXX_02.xlsm, etc.)
 func FindAllLabFiles(jobNumber string) ([]LabFileInfo, error) {
-	projectDir := filepath.Join(ProjectRoot, "projects", jobNumber)
+	projectDir := filepath.Join(ProjectsDir(), jobNumber)
 
 	// Check if directory exists
 	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
@@ -833,37 +1962,39 @@ func FindAllLabFiles(jobNumber string) ([]LabFileInfo, error) {
 		return nil, fmt.Errorf("failed to read project directory: %v", err)
 	}
 
-	// Find all Lab files matching the pattern Lab_<jobNumber>*.xlsm
+	// Legacy projects can name their Lab file in .lmsmeta.json
+	if labFile, err := labFileOverride(projectDir); err != nil {
+		return nil, err
+	} else if labFile != "" {
+		return []LabFileInfo{{
+			FilePath: labFile,
+			FileName: filepath.Base(labFile),
+		}}, nil
+	}
+
+	// Find all Lab files matching the pattern Lab_<jobNumber>*.xlsm (or .xlsx)
 	var labFiles []LabFileInfo
 	basePattern := fmt.Sprintf("Lab_%s", jobNumber)
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
+	for _, fileName := range findLabFileNames(entries, jobNumber) {
+		// Extract suffix if present
+		nameWithoutExt := strings.TrimSuffix(fileName, labFileExt(fileName))
+		suffix := ""
 
-		fileName := entry.Name()
-		// Check if it matches Lab_<jobNumber>.xlsm or Lab_<jobNumber>_XX.xlsm
-		if strings.HasPrefix(fileName, basePattern) && strings.HasSuffix(fileName, ".xlsm") {
-			// Extract suffix if present
-			nameWithoutExt := strings.TrimSuffix(fileName, ".xlsm")
-			suffix := ""
-
-			if nameWithoutExt != basePattern {
-				// Has a suffix - extract it
-				parts := strings.Split(nameWithoutExt, "_")
-				if len(parts) >= 3 {
-					suffix = parts[len(parts)-1]
-				}
+		if nameWithoutExt != basePattern {
+			// Has a suffix - extract it
+			parts := strings.Split(nameWithoutExt, "_")
+			if len(parts) >= 3 {
+				suffix = parts[len(parts)-1]
 			}
-
-			fullPath := filepath.Join(projectDir, fileName)
-			labFiles = append(labFiles, LabFileInfo{
-				FilePath: fullPath,
-				FileName: fileName,
-				Suffix:   suffix,
-			})
 		}
+
+		fullPath := filepath.Join(projectDir, fileName)
+		labFiles = append(labFiles, LabFileInfo{
+			FilePath: fullPath,
+			FileName: fileName,
+			Suffix:   suffix,
+		})
 	}
 
 	if len(labFiles) == 0 {
@@ -898,7 +2029,7 @@ func FindAllLabFiles(jobNumber string) ([]LabFileInfo, error) {
 
 // FindLatestLabFile finds the latest Lab file for a job (handles Lab_XXXXX.xlsm and Lab_XXXXX_02.xlsm, etc.)
 func FindLatestLabFile(jobNumber string) (string, error) {
-	projectDir := filepath.Join(ProjectRoot, "projects", jobNumber)
+	projectDir := filepath.Join(ProjectsDir(), jobNumber)
 
 	// Check if directory exists
 	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
@@ -911,22 +2042,15 @@ func FindLatestLabFile(jobNumber string) (string, error) {
 		return "", fmt.Errorf("failed to read project directory: %v", err)
 	}
 
-	// Find all Lab files matching the pattern Lab_<jobNumber>*.xlsm
-	var labFiles []string
-	basePattern := fmt.Sprintf("Lab_%s", jobNumber)
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		fileName := entry.Name()
-		// Check if it matches Lab_<jobNumber>.xlsm or Lab_<jobNumber>_XX.xlsm
-		if strings.HasPrefix(fileName, basePattern) && strings.HasSuffix(fileName, ".xlsm") {
-			labFiles = append(labFiles, fileName)
-		}
+	// Legacy projects can name their Lab file in .lmsmeta.json
+	if labFile, err := labFileOverride(projectDir); err != nil || labFile != "" {
+		return labFile, err
 	}
 
+	// Find all Lab files matching the pattern Lab_<jobNumber>*.xlsm (or .xlsx)
+	labFiles := findLabFileNames(entries, jobNumber)
+	basePattern := fmt.Sprintf("Lab_%s", jobNumber)
+
 	if len(labFiles) == 0 {
 		return "", fmt.Errorf("no Lab files found for job %s", jobNumber)
 	}
@@ -941,8 +2065,8 @@ func FindLatestLabFile(jobNumber string) (string, error) {
 	highestSuffix := -1
 
 	for _, fileName := range labFiles {
-		// Remove .xlsm extension
-		nameWithoutExt := strings.TrimSuffix(fileName, ".xlsm")
+		// Remove .xlsm/.xlsx extension
+		nameWithoutExt := strings.TrimSuffix(fileName, labFileExt(fileName))
 
 		// Check if it's the base file (no suffix) or has a suffix
 		if nameWithoutExt == basePattern {
@@ -977,15 +2101,26 @@ func FindLatestLabFile(jobNumber string) (string, error) {
 	return fullPath, nil
 }
 
-// DiscoverJobs scans the projects folder for Lab_*.xlsm files and returns job information
+// ErrProjectsDirMissing is returned by DiscoverJobs when the projects folder doesn't exist,
+// which usually means project_root points at the wrong place rather than that there are no jobs
+var ErrProjectsDirMissing = errors.New("projects folder not found")
+
+// ProjectsDir returns the folder DiscoverJobs scans for job folders
+func ProjectsDir() string {
+	return filepath.Join(ProjectRoot, "projects")
+}
+
+// DiscoverJobs scans the projects folder for Lab_*.xlsm and Lab_*.xlsx files and returns job information.
+// It returns ErrProjectsDirMissing when the projects folder doesn't exist, and no jobs and no error
+// when it exists but holds no jobs.
 func DiscoverJobs() ([]models.Job, error) {
-	projectsDir := filepath.Join(ProjectRoot, "projects")
+	projectsDir := ProjectsDir()
 	var jobs []models.Job
 
 	// Check if projects directory exists
 	if _, err := os.Stat(projectsDir); os.IsNotExist(err) {
 		logger.Error.Printf("Projects directory does not exist: %s", projectsDir)
-		return jobs, nil
+		return jobs, ErrProjectsDirMissing
 	}
 
 	// Read all directories in the projects folder
@@ -995,6 +2130,11 @@ func DiscoverJobs() ([]models.Job, error) {
 		return nil, err
 	}
 
+	// Folders are read in name order, so when two resolve to the same job number the first
+	// one is used every time and the others are reported on it
+	usedFolder := map[string]string{}     // Job number -> folder it was discovered in
+	otherFolders := map[string][]string{} // Job number -> folders holding another copy
+
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
@@ -1006,9 +2146,24 @@ func DiscoverJobs() ([]models.Job, error) {
 		labFiles, err := FindAllLabFiles(jobNumber)
 		if err != nil {
 			logger.Error.Printf("Skipping job %s: %v", jobNumber, err)
+			// A renamed copy such as "25490 copy" still holds Lab_25490.xlsm
+			for _, copied := range strayLabFileJobNumbers(filepath.Join(projectsDir, jobNumber)) {
+				otherFolders[copied] = append(otherFolders[copied], jobNumber)
+			}
 			continue
 		}
 
+		// Hold status is per job folder, so it applies to every Lab file version
+		meta, err := LoadProjectMeta(filepath.Join(projectsDir, jobNumber))
+		if err != nil {
+			logger.Error.Printf("Ignoring overrides for job %s: %v", jobNumber, err)
+		}
+		// Display corrections the office made without editing the Lab file
+		metaOverride, err := LoadMetaOverride(filepath.Join(projectsDir, jobNumber))
+		if err != nil {
+			logger.Error.Printf("WARNING: Ignoring %s for job %s: %v", MetaOverrideFileName, jobNumber, err)
+		}
+
 		// Create a job entry for each Lab file version
 		for _, labFileInfo := range labFiles {
 
@@ -1018,25 +2173,76 @@ func DiscoverJobs() ([]models.Job, error) {
 				displayJobNumber = fmt.Sprintf("%s_%s", jobNumber, labFileInfo.Suffix)
 			}
 
-			// Extract job info from Excel file
-			job, err := extractJobInfoFromExcel(labFileInfo.FilePath, displayJobNumber, jobNumber)
+			// Extract job info from Excel file. A folder named for a version (e.g. "25490_02")
+			// still belongs to the base job.
+			baseJobNumber, _ := SplitJobNumber(displayJobNumber)
+			job, err := extractJobInfoFromExcel(labFileInfo.FilePath, displayJobNumber, baseJobNumber)
 			if err != nil {
 				logger.Error.Printf("Failed to extract job info from %s: %v", labFileInfo.FilePath, err)
 				continue
 			}
 
+			if folder, exists := usedFolder[job.ProjectNumber]; exists {
+				logger.Error.Printf("WARNING: Job %s is in both projects/%s and projects/%s; using projects/%s",
+					job.ProjectNumber, folder, jobNumber, folder)
+				otherFolders[job.ProjectNumber] = append(otherFolders[job.ProjectNumber], jobNumber)
+				continue
+			}
+			usedFolder[job.ProjectNumber] = jobNumber
+
 			// Set the Lab file path
 			job.LabFilePath = labFileInfo.FilePath
+			job.FolderName = jobNumber
+			if meta != nil && meta.OnHold {
+				job.OnHold = true
+				job.HoldReason = meta.HoldReason
+			}
+			if metaOverride != nil {
+				if err := metaOverride.Apply(&job); err != nil {
+					logger.Error.Printf("WARNING: Part of %s for job %s was not applied: %v", MetaOverrideFileName, job.ProjectNumber, err)
+				} else {
+					logger.Info.Printf("Applied %s to job %s", MetaOverrideFileName, job.ProjectNumber)
+				}
+			}
 
 			jobs = append(jobs, job)
 			logger.Info.Printf("Successfully discovered job: %s - %s", job.ProjectNumber, job.ProjectName)
 		}
 	}
 
+	for i := range jobs {
+		if folders := otherFolders[jobs[i].ProjectNumber]; len(folders) > 0 {
+			jobs[i].DuplicateFolders = folders
+			logger.Error.Printf("WARNING: Job %s also found in projects/%s; using projects/%s",
+				jobs[i].ProjectNumber, strings.Join(folders, ", projects/"), usedFolder[jobs[i].ProjectNumber])
+		}
+	}
+
+	GroupJobVersions(jobs)
+	SortJobsHeldLast(jobs)
 	logger.Info.Printf("Total discovered %d jobs in projects folder", len(jobs))
 	return jobs, nil
 }
 
+// strayLabFileJobNumbers returns the job numbers of Lab files in a folder whose name doesn't
+// match them, e.g. "25490" for Lab_25490.xlsm in "25490 copy" or "25490_02" for Lab_25490_02.xlsx
+func strayLabFileJobNumbers(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var jobNumbers []string
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := labFileExt(name)
+		if entry.IsDir() || ext == "" || !strings.HasPrefix(name, "Lab_") {
+			continue
+		}
+		jobNumbers = append(jobNumbers, strings.TrimSuffix(strings.TrimPrefix(name, "Lab_"), ext))
+	}
+	return jobNumbers
+}
+
 // extractJobInfoFromExcel reads job information from the Excel file
 func extractJobInfoFromExcel(filePath string, displayJobNumber string, baseJobNumber string) (models.Job, error) {
 	job := models.Job{
@@ -1054,15 +2260,8 @@ func extractJobInfoFromExcel(filePath string, displayJobNumber string, baseJobNu
 	}
 	defer f.Close()
 
-	// Try to find the "Main Form" or "!Main Form" sheet first, otherwise use first sheet
-	sheetName := ""
-	sheetList := f.GetSheetList()
-	for _, name := range sheetList {
-		if name == "Main Form" || name == "!Main Form" {
-			sheetName = name
-			break
-		}
-	}
+	// Try to find the Main Form sheet (or the .lmsmeta.json override) first, otherwise use first sheet
+	sheetName := findMainFormSheet(f, filePath)
 	if sheetName == "" {
 		sheetName = f.GetSheetName(0)
 		logger.Info.Printf("Main Form sheet not found, using first sheet: %s", sheetName)
@@ -1138,6 +2337,17 @@ func extractJobInfoFromExcel(filePath string, displayJobNumber string, baseJobNu
 
 // parseExcelDate attempts to parse various date formats from Excel
 func parseExcelDate(dateStr string) (time.Time, error) {
+	dateStr = strings.TrimSpace(dateStr)
+
+	// Date-typed cells often come back as a serial number (e.g., "45000")
+	if serial, err := strconv.ParseFloat(dateStr, 64); err == nil {
+		t, err := excelize.ExcelDateToTime(serial, false)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unable to parse date serial %s: %v", dateStr, err)
+		}
+		return t, nil
+	}
+
 	// Try various date formats
 	formats := []string{
 		"01/02/2006",
@@ -1167,14 +2377,52 @@ type SoilSuctionWriter struct {
 	separatePath     string            // Path to separate suction file
 	separateNextRow  int               // Next row in separate file
 	separateSheetNum int               // Current sheet number (1 = "Soil Suction", 2 = "Soil Suction 2", etc.)
+	DryRun           bool              // Log writes without touching either Excel file (training mode)
+}
+
+// soilSuctionSheetPattern matches the Soil Suction sheets: "Soil Suction", "Soil Suction2", "Soil Suction 2", ...
+var soilSuctionSheetPattern = regexp.MustCompile(`^Soil Suction( ?\d+)?$`)
+
+// isSoilSuctionSheet reports whether a sheet holds soil suction sample rows
+func isSoilSuctionSheet(sheetName string) bool {
+	return soilSuctionSheetPattern.MatchString(sheetName)
+}
+
+// suctionSheetLastRow is the last row a separate suction file sheet can use before rolling
+// over to a new sheet: the configured samples per sheet (37 on the printed form) plus the header
+func suctionSheetLastRow() int {
+	rowsPerSheet := Config.SuctionRowsPerSheet
+	if rowsPerSheet <= 0 {
+		rowsPerSheet = defaultConfig.SuctionRowsPerSheet
+	}
+	return rowsPerSheet + 1
+}
+
+// SoilSuctionFilePath returns the job's separate soil suction file, the sheet the tech fills
+// in with Top/Bottom readings: ex_project/<job>/SoilSuction_<job>.xlsx under ProjectRoot
+func SoilSuctionFilePath(jobNumber string) string {
+	return filepath.Join(ProjectRoot, "ex_project", jobNumber, fmt.Sprintf("SoilSuction_%s.xlsx", jobNumber))
+}
+
+// SoilSuctionSheet returns the job's separate soil suction file for printing, or an error
+// when it hasn't been written. In dry run it never is, so only the path is returned.
+func SoilSuctionSheet(jobNumber string) (string, error) {
+	separatePath := SoilSuctionFilePath(jobNumber)
+	if Config.DryRun {
+		return separatePath, nil
+	}
+	if _, err := os.Stat(separatePath); err != nil {
+		logger.Error.Printf("No soil suction file for job %s: %v", jobNumber, err)
+		return "", fmt.Errorf("job %s has no soil suction file yet: %v", jobNumber, err)
+	}
+	return separatePath, nil
 }
 
 // InitSoilSuctionFile initializes the soil suction writer using the same file handle as moisture writer
 func InitSoilSuctionFile(jobNumber string, sharedFile *excelize.File) (*SoilSuctionWriter, error) {
 	// The Lab file should already be copied by InitMoistureTestFile
-	dirPath := filepath.Join(ProjectRoot, "ex_project", jobNumber)
-	filePath := filepath.Join(dirPath, fmt.Sprintf("Lab_%s.xlsm", jobNumber))
-	separatePath := filepath.Join(dirPath, fmt.Sprintf("SoilSuction_%s.xlsx", jobNumber))
+	filePath := WorkingLabFilePath(jobNumber)
+	separatePath := SoilSuctionFilePath(jobNumber)
 
 	writer := &SoilSuctionWriter{
 		JobNumber:        jobNumber,
@@ -1184,41 +2432,18 @@ func InitSoilSuctionFile(jobNumber string, sharedFile *excelize.File) (*SoilSuct
 		separatePath:     separatePath,
 		separateNextRow:  2, // Start after header
 		separateSheetNum: 1, // First sheet
+		DryRun:           Config.DryRun,
 	}
 
-	// Create or open separate soil suction file
-	if _, err := os.Stat(separatePath); os.IsNotExist(err) {
+	// Create or open separate soil suction file (never created in dry run)
+	if writer.DryRun {
+		logger.Info.Printf("[dry run] Skipping separate soil suction file %s", separatePath)
+	} else if _, err := os.Stat(separatePath); os.IsNotExist(err) {
 		// Create new file with headers
 		writer.separateFile = excelize.NewFile()
 		sheetName := "Soil Suction"
 		writer.separateFile.SetSheetName("Sheet1", sheetName)
-
-		// Set headers: Date, Boring, Depth, Can No, Top, Bottom, Top, Bottom
-		writer.separateFile.SetCellValue(sheetName, "A1", "Date")
-		writer.separateFile.SetCellValue(sheetName, "B1", "Boring")
-		writer.separateFile.SetCellValue(sheetName, "C1", "Depth")
-		writer.separateFile.SetCellValue(sheetName, "D1", "Can No")
-		writer.separateFile.SetCellValue(sheetName, "E1", "Top")
-		writer.separateFile.SetCellValue(sheetName, "F1", "Bottom")
-		writer.separateFile.SetCellValue(sheetName, "G1", "Top")
-		writer.separateFile.SetCellValue(sheetName, "H1", "Bottom")
-
-		// Style headers
-		style, _ := writer.separateFile.NewStyle(&excelize.Style{
-			Font: &excelize.Font{Bold: true},
-			Fill: excelize.Fill{Type: "pattern", Color: []string{"#CCCCCC"}, Pattern: 1},
-		})
-		writer.separateFile.SetCellStyle(sheetName, "A1", "H1", style)
-
-		// Set column widths
-		writer.separateFile.SetColWidth(sheetName, "A", "A", 12)
-		writer.separateFile.SetColWidth(sheetName, "B", "B", 12)
-		writer.separateFile.SetColWidth(sheetName, "C", "C", 12)
-		writer.separateFile.SetColWidth(sheetName, "D", "D", 12)
-		writer.separateFile.SetColWidth(sheetName, "E", "E", 12)
-		writer.separateFile.SetColWidth(sheetName, "F", "F", 12)
-		writer.separateFile.SetColWidth(sheetName, "G", "G", 12)
-		writer.separateFile.SetColWidth(sheetName, "H", "H", 12)
+		writeExportHeader(writer.separateFile, sheetName, suctionExportColumns)
 
 		if err := writer.separateFile.SaveAs(separatePath); err != nil {
 			logger.Error.Printf("Failed to create separate soil suction Excel file: %v", err)
@@ -1247,8 +2472,8 @@ func InitSoilSuctionFile(jobNumber string, sharedFile *excelize.File) (*SoilSuct
 		rows, _ := writer.separateFile.GetRows(currentSheetName)
 		writer.separateNextRow = len(rows) + 1
 
-		// Check if current sheet is full (37 samples + 1 header = 38 rows)
-		if writer.separateNextRow > 38 {
+		// Check if current sheet is full (samples per sheet + 1 header row)
+		if writer.separateNextRow > suctionSheetLastRow() {
 			// Need to create a new sheet
 			writer.separateSheetNum++
 			writer.separateNextRow = 2
@@ -1257,39 +2482,65 @@ func InitSoilSuctionFile(jobNumber string, sharedFile *excelize.File) (*SoilSuct
 		logger.Info.Printf("Opened existing separate soil suction file, sheet %d, next row: %d", writer.separateSheetNum, writer.separateNextRow)
 	}
 
-	// Build sample row map from all Soil Suction sheets (Soil Suction, Soil Suction2, etc.)
-	// Column B has Boring No., Column C has Depth
-	// Starting from row 10
-	sheetNames := writer.file.GetSheetList()
-	for _, sheetName := range sheetNames {
-		// Check if this is a Soil Suction sheet
-		if sheetName == "Soil Suction" || strings.HasPrefix(sheetName, "Soil Suction") {
-			rows, err := writer.file.GetRows(sheetName)
+	var includedSheets []string
+	writer.sampleRowMap, includedSheets = mapSoilSuctionRows(writer.file)
+
+	logger.Info.Printf("Initialized soil suction writer with %d sample mappings from sheets %q", len(writer.sampleRowMap), includedSheets)
+	return writer, nil
+}
+
+// soilSuctionLayout returns the configured Soil Suction sheet layout, falling back to the
+// Lab template's layout when config was never loaded
+func soilSuctionLayout() SoilSuctionLayout {
+	if Config.SheetLayouts.SoilSuction == (SoilSuctionLayout{}) {
+		return defaultConfig.SheetLayouts.SoilSuction
+	}
+	return Config.SheetLayouts.SoilSuction
+}
+
+// mapSoilSuctionRows maps "BoringNo|Depth" to "SheetName|RowNumber" across every Soil Suction
+// sheet (Soil Suction, Soil Suction2, etc.) and returns the sheets it read. Boring No. and
+// Depth are read from the columns in sheet_layouts.soil_suction, starting at its first row.
+func mapSoilSuctionRows(f *excelize.File) (map[string]string, []string) {
+	layout := soilSuctionLayout()
+	boringIdx := columnLetterToIndex(layout.BoringColumn) - 1
+	depthIdx := columnLetterToIndex(layout.DepthColumn) - 1
+
+	rowMap := make(map[string]string)
+	includedSheets := []string{}
+	for _, sheetName := range f.GetSheetList() {
+		if isSoilSuctionSheet(sheetName) {
+			includedSheets = append(includedSheets, sheetName)
+			rows, err := f.GetRows(sheetName)
 			if err != nil {
 				logger.Error.Printf("Failed to read %s sheet: %v", sheetName, err)
 				continue
 			}
 
-			// Map each row to its boring/depth combination (starting from row 10, index 9)
-			for rowIdx := 9; rowIdx < len(rows); rowIdx++ {
+			// Map each row to its boring/depth combination
+			samplesFound := 0
+			for rowIdx := layout.FirstRow - 1; rowIdx < len(rows); rowIdx++ {
 				row := rows[rowIdx]
-				if len(row) >= 3 {
-					boring := strings.TrimSpace(row[1]) // Column B (index 1)
-					depth := strings.TrimSpace(row[2])  // Column C (index 2)
+				if len(row) > max(boringIdx, depthIdx) {
+					boring := strings.TrimSpace(row[boringIdx])
+					depth := strings.TrimSpace(row[depthIdx])
 					if boring != "" && depth != "" {
 						key := fmt.Sprintf("%s|%s", boring, depth)
 						actualRow := rowIdx + 1 // Convert to 1-based Excel row number
 						// Store sheet name with row number
-						writer.sampleRowMap[key] = fmt.Sprintf("%s|%d", sheetName, actualRow)
+						rowMap[key] = fmt.Sprintf("%s|%d", sheetName, actualRow)
 						logger.Info.Printf("Mapped soil suction sample %s to %s row %d", key, sheetName, actualRow)
+						samplesFound++
 					}
 				}
 			}
+			if samplesFound == 0 {
+				logger.Error.Printf("WARNING: No samples found on %s in columns %s/%s from row %d; check sheet_layouts.soil_suction in config.json",
+					sheetName, layout.BoringColumn, layout.DepthColumn, layout.FirstRow)
+			}
 		}
 	}
-
-	logger.Info.Printf("Initialized soil suction writer with %d sample mappings across multiple sheets", len(writer.sampleRowMap))
-	return writer, nil
+	return rowMap, includedSheets
 }
 
 // WriteSoilSuctionSample writes a single sample's soil suction can number to the appropriate Soil Suction sheet
@@ -1311,8 +2562,16 @@ func (w *SoilSuctionWriter) WriteSoilSuctionSample(boringNumber, depth, suctionC
 	sheetName := parts[0]
 	rowNum := parts[1]
 
-	// Write can number to column D of the correct row in Lab file
-	w.file.SetCellValue(sheetName, fmt.Sprintf("D%s", rowNum), suctionCanNo)
+	cell := soilSuctionLayout().CanNoColumn + rowNum
+
+	if w.DryRun {
+		logger.Info.Printf("[dry run] Would write soil suction can number to %s row %s (%s): Boring=%s, Depth=%s, SuctionCan#=%s",
+			sheetName, rowNum, cell, boringNumber, depth, suctionCanNo)
+		return nil
+	}
+
+	// Write can number to the Can No. column of the correct row in Lab file
+	w.file.SetCellValue(sheetName, cell, suctionCanNo)
 
 	// Save Lab file
 	if err := w.file.Save(); err != nil {
@@ -1322,39 +2581,13 @@ func (w *SoilSuctionWriter) WriteSoilSuctionSample(boringNumber, depth, suctionC
 
 	// Also write to separate soil suction file
 	if w.separateFile != nil {
-		// Check if we need to create a new sheet (37 samples per sheet + 1 header = 38 rows max)
-		if w.separateNextRow > 38 {
+		// Check if we need to create a new sheet (samples per sheet + 1 header row)
+		if w.separateNextRow > suctionSheetLastRow() {
 			// Create new sheet
 			w.separateSheetNum++
 			newSheetName := fmt.Sprintf("Soil Suction %d", w.separateSheetNum)
 			w.separateFile.NewSheet(newSheetName)
-
-			// Set headers for new sheet
-			w.separateFile.SetCellValue(newSheetName, "A1", "Date")
-			w.separateFile.SetCellValue(newSheetName, "B1", "Boring")
-			w.separateFile.SetCellValue(newSheetName, "C1", "Depth")
-			w.separateFile.SetCellValue(newSheetName, "D1", "Can No")
-			w.separateFile.SetCellValue(newSheetName, "E1", "Top")
-			w.separateFile.SetCellValue(newSheetName, "F1", "Bottom")
-			w.separateFile.SetCellValue(newSheetName, "G1", "Top")
-			w.separateFile.SetCellValue(newSheetName, "H1", "Bottom")
-
-			// Style headers
-			style, _ := w.separateFile.NewStyle(&excelize.Style{
-				Font: &excelize.Font{Bold: true},
-				Fill: excelize.Fill{Type: "pattern", Color: []string{"#CCCCCC"}, Pattern: 1},
-			})
-			w.separateFile.SetCellStyle(newSheetName, "A1", "H1", style)
-
-			// Set column widths
-			w.separateFile.SetColWidth(newSheetName, "A", "A", 12)
-			w.separateFile.SetColWidth(newSheetName, "B", "B", 12)
-			w.separateFile.SetColWidth(newSheetName, "C", "C", 12)
-			w.separateFile.SetColWidth(newSheetName, "D", "D", 12)
-			w.separateFile.SetColWidth(newSheetName, "E", "E", 12)
-			w.separateFile.SetColWidth(newSheetName, "F", "F", 12)
-			w.separateFile.SetColWidth(newSheetName, "G", "G", 12)
-			w.separateFile.SetColWidth(newSheetName, "H", "H", 12)
+			writeExportHeader(w.separateFile, newSheetName, suctionExportColumns)
 
 			w.separateNextRow = 2
 			logger.Info.Printf("Created new sheet '%s' in separate soil suction file", newSheetName)
@@ -1385,9 +2618,47 @@ func (w *SoilSuctionWriter) WriteSoilSuctionSample(boringNumber, depth, suctionC
 		w.separateNextRow++
 	}
 
-	logger.Info.Printf("Wrote soil suction can number to %s row %s (D%s): Boring=%s, Depth=%s, SuctionCan#=%s",
-		sheetName, rowNum, rowNum, boringNumber, depth, suctionCanNo)
+	logger.Info.Printf("Wrote soil suction can number to %s row %s (%s): Boring=%s, Depth=%s, SuctionCan#=%s",
+		sheetName, rowNum, cell, boringNumber, depth, suctionCanNo)
+
+	return nil
+}
+
+// ClearSoilSuctionSample blanks a sample's suction can number on the Lab file's Soil Suction
+// sheet. The row already logged in the separate suction file is left as a record.
+func (w *SoilSuctionWriter) ClearSoilSuctionSample(boringNumber, depth string) error {
+	key := fmt.Sprintf("%s|%s", boringNumber, depth)
+	mapping, exists := w.sampleRowMap[key]
+	if !exists {
+		logger.Error.Printf("No row mapping found for soil suction sample %s", key)
+		return fmt.Errorf("no row mapping for %s", key)
+	}
+
+	// Parse sheet name and row number from mapping (format: "SheetName|RowNumber")
+	parts := strings.Split(mapping, "|")
+	if len(parts) != 2 {
+		logger.Error.Printf("Invalid mapping format for soil suction sample %s: %s", key, mapping)
+		return fmt.Errorf("invalid mapping format for %s", key)
+	}
+	sheetName := parts[0]
+	rowNum := parts[1]
+
+	cell := soilSuctionLayout().CanNoColumn + rowNum
+
+	if w.DryRun {
+		logger.Info.Printf("[dry run] Would clear soil suction can number in %s row %s (%s): Boring=%s, Depth=%s",
+			sheetName, rowNum, cell, boringNumber, depth)
+		return nil
+	}
+
+	w.file.SetCellValue(sheetName, cell, nil)
+	if err := w.file.Save(); err != nil {
+		logger.Error.Printf("Failed to save cleared soil suction data to Lab file: %v", err)
+		return err
+	}
 
+	logger.Info.Printf("Cleared soil suction can number in %s row %s (%s): Boring=%s, Depth=%s",
+		sheetName, rowNum, cell, boringNumber, depth)
 	return nil
 }
 
@@ -1403,13 +2674,35 @@ func (w *SoilSuctionWriter) Close() error {
 
 // OvenCanData represents a moisture can currently in the oven
 type OvenCanData struct {
-	CanNumber       string `json:"can_number"`
-	JobNumber       string `json:"job_number"`
-	BoringNumber    string `json:"boring_number"`
-	Depth           string `json:"depth"`
-	TimeIn          string `json:"time_in"`
-	MoistureSheet   string `json:"moisture_sheet"`   // Sheet name (e.g., "Moisture", "Moisture2")
-	MoistureColumn  string `json:"moisture_column"`  // Column letter (e.g., "B", "C")
+	CanNumber      string `json:"can_number"`
+	JobNumber      string `json:"job_number"`
+	BoringNumber   string `json:"boring_number"`
+	Depth          string `json:"depth"`
+	TimeIn         string `json:"time_in"`
+	MoistureSheet  string `json:"moisture_sheet"`     // Sheet name (e.g., "Moisture", "Moisture2")
+	MoistureColumn string `json:"moisture_column"`    // Column letter (e.g., "B", "C")
+	OvenID         string `json:"oven_id,omitempty"`  // Oven the can was loaded into (empty for single-oven labs)
+	BatchID        string `json:"batch_id,omitempty"` // Drying cohort tagged from the pull screen (empty when untagged)
+	BatchStartedAt string `json:"batch_started_at,omitempty"`
+}
+
+// ParseTimeIn parses the can's TimeIn timestamp
+func (c OvenCanData) ParseTimeIn() (time.Time, error) {
+	return ParseTimestamp(c.TimeIn)
+}
+
+// CansAddedWithin returns the cans put in the oven less than hours before now, in order.
+// A can whose time in can't be read is kept, so a bad timestamp never hides a can.
+func CansAddedWithin(cans []OvenCanData, hours int, now time.Time) []OvenCanData {
+	since := now.Add(-time.Duration(hours) * time.Hour)
+	recent := []OvenCanData{}
+	for _, can := range cans {
+		timeIn, err := can.ParseTimeIn()
+		if err != nil || timeIn.After(since) {
+			recent = append(recent, can)
+		}
+	}
+	return recent
 }
 
 // OvenTrackingData represents all cans currently in the oven
@@ -1433,17 +2726,27 @@ func LoadOvenTracking() (*OvenTrackingData, error) {
 			// Return empty tracking data if file doesn't exist
 			return &OvenTrackingData{
 				Cans:        []OvenCanData{},
-				LastUpdated: time.Now().Format("2006-01-02 15:04:05"),
+				LastUpdated: Now(),
 			}, nil
 		}
 		logger.Error.Printf("Failed to read oven tracking file: %v", err)
 		return nil, err
 	}
 
+	// Check if file is empty
+	if len(bytes.TrimSpace(data)) == 0 {
+		logger.Info.Printf("Oven tracking file is empty, returning new tracking")
+		return &OvenTrackingData{
+			Cans:        []OvenCanData{},
+			LastUpdated: Now(),
+		}, nil
+	}
+
 	var tracking OvenTrackingData
 	if err := json.Unmarshal(data, &tracking); err != nil {
 		logger.Error.Printf("Failed to unmarshal oven tracking data: %v", err)
-		return nil, err
+		// Don't let a damaged file hide the cans that are really in the oven
+		return recoverOvenTracking(filePath, data, err)
 	}
 
 	logger.Info.Printf("Loaded oven tracking data: %d cans in oven", len(tracking.Cans))
@@ -1454,7 +2757,7 @@ func LoadOvenTracking() (*OvenTrackingData, error) {
 func SaveOvenTracking(tracking *OvenTrackingData) error {
 	filePath := GetOvenTrackingFilePath()
 
-	tracking.LastUpdated = time.Now().Format("2006-01-02 15:04:05")
+	tracking.LastUpdated = Now()
 
 	jsonData, err := json.MarshalIndent(tracking, "", "  ")
 	if err != nil {
@@ -1462,7 +2765,8 @@ func SaveOvenTracking(tracking *OvenTrackingData) error {
 		return err
 	}
 
-	if err := os.WriteFile(filePath, jsonData, 0644); err != nil {
+	// Write atomically so a reader on another workstation never sees a half-written file
+	if err := writeFileAtomic(filePath, jsonData, 0644); err != nil {
 		logger.Error.Printf("Failed to write oven tracking file: %v", err)
 		return err
 	}
@@ -1471,69 +2775,84 @@ func SaveOvenTracking(tracking *OvenTrackingData) error {
 	return nil
 }
 
-// AddCanToOven adds a moisture can to the oven tracking
-func AddCanToOven(canNumber, jobNumber, boringNumber, depth, moistureSheet, moistureColumn string) error {
-	tracking, err := LoadOvenTracking()
-	if err != nil {
-		return err
-	}
+// AddCanToOven adds a moisture can to the oven tracking. If batch is active the can is
+// tagged with it and counted; pass nil when no batch is being loaded.
+func AddCanToOven(canNumber, jobNumber, boringNumber, depth, moistureSheet, moistureColumn string, batch *OvenBatch) error {
+	// Hold the lock for the whole read-modify-write so concurrent adds from
+	// other workstations are not overwritten
+	err := withFileLock(GetOvenTrackingFilePath(), func() error {
+		tracking, err := LoadOvenTracking()
+		if err != nil {
+			return err
+		}
 
-	// Check if can is already in oven
-	for _, can := range tracking.Cans {
-		if can.CanNumber == canNumber {
-			logger.Error.Printf("Can %s is already in the oven (Job: %s, Boring: %s, Depth: %s)",
-				canNumber, can.JobNumber, can.BoringNumber, can.Depth)
-			return fmt.Errorf("can %s is already in the oven", canNumber)
+		// Check if can is already in oven
+		for _, can := range tracking.Cans {
+			if can.CanNumber == canNumber {
+				logger.Error.Printf("Can %s is already in the oven (Job: %s, Boring: %s, Depth: %s)",
+					canNumber, can.JobNumber, can.BoringNumber, can.Depth)
+				return fmt.Errorf("can %s is already in the oven", canNumber)
+			}
 		}
-	}
 
-	newCan := OvenCanData{
-		CanNumber:      canNumber,
-		JobNumber:      jobNumber,
-		BoringNumber:   boringNumber,
-		Depth:          depth,
-		TimeIn:         time.Now().Format("2006-01-02 15:04:05"),
-		MoistureSheet:  moistureSheet,
-		MoistureColumn: moistureColumn,
-	}
+		newCan := OvenCanData{
+			CanNumber:      canNumber,
+			JobNumber:      jobNumber,
+			BoringNumber:   boringNumber,
+			Depth:          depth,
+			TimeIn:         Now(),
+			MoistureSheet:  moistureSheet,
+			MoistureColumn: moistureColumn,
+			OvenID:         Config.WorkstationOven,
+		}
+		newCan.BatchID, newCan.BatchStartedAt = batch.Take()
 
-	tracking.Cans = append(tracking.Cans, newCan)
+		tracking.Cans = append(tracking.Cans, newCan)
 
-	if err := SaveOvenTracking(tracking); err != nil {
+		return SaveOvenTracking(tracking)
+	})
+	if err != nil {
 		return err
 	}
 
-	logger.Info.Printf("Added can %s to oven (Job: %s, Boring: %s, Depth: %s, Sheet: %s, Column: %s)",
-		canNumber, jobNumber, boringNumber, depth, moistureSheet, moistureColumn)
+	logger.Info.Printf("Added can %s to oven %s (Job: %s, Boring: %s, Depth: %s, Sheet: %s, Column: %s)",
+		canNumber, Config.WorkstationOven, jobNumber, boringNumber, depth, moistureSheet, moistureColumn)
 	return nil
 }
 
 // RemoveCanFromOven removes a moisture can from the oven tracking
 func RemoveCanFromOven(canNumber string) (*OvenCanData, error) {
-	tracking, err := LoadOvenTracking()
-	if err != nil {
-		return nil, err
-	}
-
 	var removedCan *OvenCanData
-	newCans := []OvenCanData{}
 
-	for _, can := range tracking.Cans {
-		if can.CanNumber == canNumber {
-			removedCan = &can
-		} else {
-			newCans = append(newCans, can)
+	// Re-read under the lock so cans added elsewhere since our last load are kept
+	err := withFileLock(GetOvenTrackingFilePath(), func() error {
+		tracking, err := LoadOvenTracking()
+		if err != nil {
+			return err
 		}
-	}
 
-	if removedCan == nil {
-		logger.Error.Printf("Can %s is not in the oven", canNumber)
-		return nil, fmt.Errorf("can %s is not in the oven", canNumber)
-	}
+		newCans := []OvenCanData{}
+
+		for _, can := range tracking.Cans {
+			if can.CanNumber == canNumber {
+				// Copy before taking the address so we don't alias the loop variable
+				c := can
+				removedCan = &c
+			} else {
+				newCans = append(newCans, can)
+			}
+		}
+
+		if removedCan == nil {
+			logger.Error.Printf("Can %s is not in the oven", canNumber)
+			return fmt.Errorf("can %s is not in the oven", canNumber)
+		}
 
-	tracking.Cans = newCans
+		tracking.Cans = newCans
 
-	if err := SaveOvenTracking(tracking); err != nil {
+		return SaveOvenTracking(tracking)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -1551,7 +2870,8 @@ func GetCansInOven() ([]OvenCanData, error) {
 	return tracking.Cans, nil
 }
 
-// IsCanInOven checks if a specific can number is currently in the oven
+// IsCanInOven checks if a specific can number is currently in the oven.
+// The returned can's OvenID says which oven it is tracked in.
 func IsCanInOven(canNumber string) (bool, *OvenCanData, error) {
 	tracking, err := LoadOvenTracking()
 	if err != nil {
@@ -1560,13 +2880,31 @@ func IsCanInOven(canNumber string) (bool, *OvenCanData, error) {
 
 	for _, can := range tracking.Cans {
 		if can.CanNumber == canNumber {
-			return true, &can, nil
+			c := can
+			return true, &c, nil
 		}
 	}
 
 	return false, nil, nil
 }
 
+// GetJobCansInOven returns the samples of a job whose moisture can is in the oven,
+// keyed by "BoringNumber|Depth"
+func GetJobCansInOven(jobNumber string) (map[string]OvenCanData, error) {
+	tracking, err := LoadOvenTracking()
+	if err != nil {
+		return nil, err
+	}
+
+	inOven := map[string]OvenCanData{}
+	for _, can := range tracking.Cans {
+		if can.JobNumber == jobNumber {
+			inOven[can.BoringNumber+"|"+can.Depth] = can
+		}
+	}
+	return inOven, nil
+}
+
 // GetOvenCanCount returns the number of cans currently in the oven
 func GetOvenCanCount() (int, error) {
 	tracking, err := LoadOvenTracking()
@@ -1576,24 +2914,55 @@ func GetOvenCanCount() (int, error) {
 	return len(tracking.Cans), nil
 }
 
+// ErrWorkingFileMissing is returned when an oven can's job no longer has a working Lab file,
+// e.g. its ex_project folder was cleaned up or moved while the can was still in the oven
+var ErrWorkingFileMissing = errors.New("the job's working Lab file is missing")
+
+// existingWorkingLabFile returns the job's working Lab file path, or ErrWorkingFileMissing
+// when it isn't there. A dropped share looks the same as a deleted file, so the file is
+// only reported missing once the share and its ex_project folder are known to be there.
+func existingWorkingLabFile(jobNumber string) (string, error) {
+	filePath := WorkingLabFilePath(jobNumber)
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		return filePath, nil
+	}
+	if status := CheckShare(); !status.Reachable {
+		logger.Error.Printf("Can't check the working Lab file for job %s, the share is unreachable: %s", jobNumber, status.Detail)
+		return "", fmt.Errorf("the share is unreachable, so job %s's working Lab file can't be checked: %s", jobNumber, status.Detail)
+	}
+	exProjectDir := filepath.Join(ProjectRoot, "ex_project")
+	if _, err := os.Stat(exProjectDir); err != nil {
+		logger.Error.Printf("Can't check the working Lab file for job %s: %v", jobNumber, err)
+		return "", fmt.Errorf("%s is not there, so the share may not be mounted: %v", exProjectDir, err)
+	}
+	logger.Error.Printf("Working Lab file for job %s is missing: %s", jobNumber, filePath)
+	return "", fmt.Errorf("%w: %s", ErrWorkingFileMissing, filePath)
+}
+
 // WriteDryWeightToMoistureSheet writes the dry weight to the moisture sheet for a can
 // and calculates: Wt. of water, Dry wt. of soil, and Moisture Content
-// Offsets from base row (which is the "Boring No" row):
-// +2: Can No.
-// +3: Wet wt. and can
-// +4: Dry wt. of soil and can (input)
-// +5: Wt. of water = Row +3 - Row +4
-// +6: Wt. of can
-// +7: Dry wt. of soil = Row +4 - Row +6
-// +8: Moisture Content = (Wt. of water / Dry wt. of soil) * 100
-func WriteDryWeightToMoistureSheet(can OvenCanData, dryWeight string) error {
-	// Open the Lab file for this job
-	filePath := filepath.Join(ProjectRoot, "ex_project", can.JobNumber, fmt.Sprintf("Lab_%s.xlsm", can.JobNumber))
+// Rows are offsets from the base ("Boring No") row, set by sheet_layouts.moisture in config:
+// Dry wt. of soil and can (input)
+// Wt. of water = Wet wt. and can - Dry wt. of soil and can
+// Dry wt. of soil = Dry wt. of soil and can - Wt. of can
+// Moisture Content = (Wt. of water / Dry wt. of soil) * 100
+// Returns the computed moisture content (rounded to the nearest tenth)
+func WriteDryWeightToMoistureSheet(can OvenCanData, dryWeight string) (float64, error) {
+	if Config.DryRun {
+		return dryRunMoistureContent(can, dryWeight)
+	}
+
+	// Open the Lab file for this job, which is gone if the job was cleaned up while
+	// the can was still in the oven
+	filePath, err := existingWorkingLabFile(can.JobNumber)
+	if err != nil {
+		return 0, err
+	}
 
 	f, err := excelize.OpenFile(filePath)
 	if err != nil {
 		logger.Error.Printf("Failed to open Lab file for job %s: %v", can.JobNumber, err)
-		return err
+		return 0, err
 	}
 	defer f.Close()
 
@@ -1607,12 +2976,13 @@ func WriteDryWeightToMoistureSheet(can OvenCanData, dryWeight string) error {
 	}
 
 	// Calculate actual row numbers based on base row
-	wetWtRow := baseRow + 3
-	dryWtAndCanRow := baseRow + 4
-	wtOfWaterRow := baseRow + 5
-	wtOfCanRow := baseRow + 6
-	dryWtOfSoilRow := baseRow + 7
-	moistureContentRow := baseRow + 8
+	offsets := moistureRows()
+	wetWtRow := baseRow + offsets.WetWtAndCan
+	dryWtAndCanRow := baseRow + offsets.DryWtAndCan
+	wtOfWaterRow := baseRow + offsets.WtOfWater
+	wtOfCanRow := baseRow + offsets.WtOfCan
+	dryWtOfSoilRow := baseRow + offsets.DryWtOfSoil
+	moistureContentRow := baseRow + offsets.MoistureContent
 
 	// Read existing values for calculations
 	wetWtAndCanCell := fmt.Sprintf("%s%d", can.MoistureColumn, wetWtRow)
@@ -1627,25 +2997,38 @@ func WriteDryWeightToMoistureSheet(can OvenCanData, dryWeight string) error {
 	fmt.Sscanf(wtOfCanStr, "%f", &wtOfCan)
 	fmt.Sscanf(dryWeight, "%f", &dryWtAndCan)
 
+	// Refuse impossible weights rather than write negative water or soil to the sheet
+	if err := checkMoistureWeights(wetWtAndCan, wtOfCan, dryWtAndCan); err != nil {
+		logger.Error.Printf("Rejected dry weight for can %s (Job: %s): %v", can.CanNumber, can.JobNumber, err)
+		return 0, err
+	}
+
 	// Calculate derived values
-	wtOfWater := wetWtAndCan - dryWtAndCan       // Wt. of water
-	dryWtOfSoil := dryWtAndCan - wtOfCan         // Dry wt. of soil
-	moistureContent := 0.0
-	if dryWtOfSoil > 0 {
-		moistureContent = (wtOfWater / dryWtOfSoil) * 100 // Moisture Content
-		moistureContent = math.Round(moistureContent * 10) / 10      // Round to nearest tenth decimal point
+	wtOfWater, dryWtOfSoil, moistureContent := calculateMoisture(wetWtAndCan, wtOfCan, dryWtAndCan)
+
+	if err := checkColumnInSheet(f, sheetName, can.MoistureColumn); err != nil {
+		logger.Error.Printf("Refusing to write dry weight for can %s (Job: %s): %v", can.CanNumber, can.JobNumber, err)
+		return 0, err
 	}
 
 	// Write all values to the moisture sheet
-	f.SetCellValue(sheetName, fmt.Sprintf("%s%d", can.MoistureColumn, dryWtAndCanRow), dryWtAndCan)      // Dry wt. of soil and can
-	f.SetCellValue(sheetName, fmt.Sprintf("%s%d", can.MoistureColumn, wtOfWaterRow), wtOfWater)          // Wt. of water
-	f.SetCellValue(sheetName, fmt.Sprintf("%s%d", can.MoistureColumn, dryWtOfSoilRow), dryWtOfSoil)      // Dry wt. of soil
-	f.SetCellValue(sheetName, fmt.Sprintf("%s%d", can.MoistureColumn, moistureContentRow), moistureContent)  // Moisture Content (rounded)
+	f.SetCellValue(sheetName, fmt.Sprintf("%s%d", can.MoistureColumn, dryWtAndCanRow), dryWtAndCan) // Dry wt. of soil and can
+	if Config.MoistureFormulas {
+		// Formulas keep the sheet live if a weight is corrected in Excel later
+		if err := setMoistureFormulas(f, sheetName, can.MoistureColumn, baseRow); err != nil {
+			logger.Error.Printf("Failed to write moisture formulas for can %s (Job: %s): %v", can.CanNumber, can.JobNumber, err)
+			return 0, err
+		}
+	} else {
+		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", can.MoistureColumn, wtOfWaterRow), wtOfWater)             // Wt. of water
+		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", can.MoistureColumn, dryWtOfSoilRow), dryWtOfSoil)         // Dry wt. of soil
+		f.SetCellValue(sheetName, fmt.Sprintf("%s%d", can.MoistureColumn, moistureContentRow), moistureContent) // Moisture Content (rounded)
+	}
 
 	// Save the file
 	if err := f.Save(); err != nil {
 		logger.Error.Printf("Failed to save moisture calculations to Lab file: %v", err)
-		return err
+		return 0, err
 	}
 
 	logger.Info.Printf("Wrote moisture calculations to %s column %s (rows %d,%d,%d,%d) (Job: %s, Can: %s):\n"+
@@ -1656,5 +3039,156 @@ func WriteDryWeightToMoistureSheet(can OvenCanData, dryWeight string) error {
 		sheetName, can.MoistureColumn, dryWtAndCanRow, wtOfWaterRow, dryWtOfSoilRow, moistureContentRow,
 		can.JobNumber, can.CanNumber,
 		dryWtAndCan, wtOfWater, dryWtOfSoil, moistureContent)
+	return moistureContent, nil
+}
+
+// PreviewMoistureContent computes the moisture content a dry weight would give without
+// writing anything, so the caller can question an unusual result first. Weights come from
+// the Lab file, or from backup.json in training mode where the Lab file is never written.
+func PreviewMoistureContent(can OvenCanData, dryWeight string) (float64, error) {
+	var wetWtAndCan, wtOfCan, dryWtAndCan float64
+	fmt.Sscanf(dryWeight, "%f", &dryWtAndCan)
+
+	if Config.DryRun {
+		sample, err := FindSampleBackup(can.JobNumber, can.BoringNumber, can.Depth)
+		if err != nil {
+			return 0, err
+		}
+		if sample == nil {
+			return 0, fmt.Errorf("no backup entry for %s at %s in job %s", can.BoringNumber, can.Depth, can.JobNumber)
+		}
+		fmt.Sscanf(sample.WetWeight, "%f", &wetWtAndCan)
+		fmt.Sscanf(sample.CanWeight, "%f", &wtOfCan)
+	} else {
+		filePath, err := existingWorkingLabFile(can.JobNumber)
+		if err != nil {
+			return 0, err
+		}
+		f, err := excelize.OpenFile(filePath)
+		if err != nil {
+			logger.Error.Printf("Failed to open Lab file for job %s: %v", can.JobNumber, err)
+			return 0, err
+		}
+		defer f.Close()
+
+		sheetParts := strings.Split(can.MoistureSheet, "|")
+		sheetName := can.MoistureSheet
+		baseRow := 9 // Default for old format compatibility
+		if len(sheetParts) == 2 {
+			sheetName = sheetParts[0]
+			fmt.Sscanf(sheetParts[1], "%d", &baseRow)
+		}
+		offsets := moistureRows()
+		wetWtAndCanStr, _ := f.GetCellValue(sheetName, fmt.Sprintf("%s%d", can.MoistureColumn, baseRow+offsets.WetWtAndCan))
+		wtOfCanStr, _ := f.GetCellValue(sheetName, fmt.Sprintf("%s%d", can.MoistureColumn, baseRow+offsets.WtOfCan))
+		fmt.Sscanf(wetWtAndCanStr, "%f", &wetWtAndCan)
+		fmt.Sscanf(wtOfCanStr, "%f", &wtOfCan)
+	}
+
+	if err := checkMoistureWeights(wetWtAndCan, wtOfCan, dryWtAndCan); err != nil {
+		return 0, err
+	}
+	_, _, moistureContent := calculateMoisture(wetWtAndCan, wtOfCan, dryWtAndCan)
+	return moistureContent, nil
+}
+
+// checkMoistureWeights rejects weights that would give negative water or no dry soil,
+// which almost always means a mistyped or misread scale
+func checkMoistureWeights(wetWtAndCan, wtOfCan, dryWtAndCan float64) error {
+	if dryWtAndCan > wetWtAndCan {
+		return fmt.Errorf("dry weight (%.2f g) can't exceed wet weight (%.2f g) - re-weigh the can", dryWtAndCan, wetWtAndCan)
+	}
+	if dryWtAndCan <= wtOfCan {
+		return fmt.Errorf("dry weight (%.2f g) must be more than the can weight (%.2f g) - re-weigh the can", dryWtAndCan, wtOfCan)
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+// calculateMoisture returns Wt. of water, Dry wt. of soil and the Moisture Content
+// (rounded to the nearest tenth) for a can's wet, can and dry weights
+func calculateMoisture(wetWtAndCan, wtOfCan, dryWtAndCan float64) (float64, float64, float64) {
+	wtOfWater := wetWtAndCan - dryWtAndCan // Wt. of water
+	dryWtOfSoil := dryWtAndCan - wtOfCan   // Dry wt. of soil
+	moistureContent := 0.0
+	if dryWtOfSoil > 0 {
+		moistureContent = (wtOfWater / dryWtOfSoil) * 100     // Moisture Content
+		moistureContent = math.Round(moistureContent*10) / 10 // Round to nearest tenth decimal point
+	}
+	return wtOfWater, dryWtOfSoil, moistureContent
+}
+
+// moistureFormulas returns the Wt. of water, Dry wt. of soil and Moisture Content formulas
+// for the Moisture block in column at baseRow. They compute what calculateMoisture does.
+func moistureFormulas(column string, baseRow int) (string, string, string) {
+	offsets := moistureRows()
+	cell := func(offset int) string { return fmt.Sprintf("%s%d", column, baseRow+offset) }
+	wtOfWater := fmt.Sprintf("=%s-%s", cell(offsets.WetWtAndCan), cell(offsets.DryWtAndCan))
+	dryWtOfSoil := fmt.Sprintf("=%s-%s", cell(offsets.DryWtAndCan), cell(offsets.WtOfCan))
+	moistureContent := fmt.Sprintf("=IF(%[2]s>0,ROUND((%[1]s/%[2]s)*100,1),0)", cell(offsets.WtOfWater), cell(offsets.DryWtOfSoil))
+	return wtOfWater, dryWtOfSoil, moistureContent
+}
+
+// setMoistureFormulas writes the moistureFormulas into a Moisture block and has the
+// workbook recalculate when opened, since excelize doesn't store computed results
+func setMoistureFormulas(f *excelize.File, sheetName, column string, baseRow int) error {
+	offsets := moistureRows()
+	wtOfWater, dryWtOfSoil, moistureContent := moistureFormulas(column, baseRow)
+	for _, cell := range []struct {
+		offset  int
+		formula string
+	}{
+		{offsets.WtOfWater, wtOfWater},
+		{offsets.DryWtOfSoil, dryWtOfSoil},
+		{offsets.MoistureContent, moistureContent},
+	} {
+		if err := f.SetCellFormula(sheetName, fmt.Sprintf("%s%d", column, baseRow+cell.offset), cell.formula); err != nil {
+			return err
+		}
+	}
+	fullCalcOnLoad := true
+	return f.SetCalcProps(&excelize.CalcPropsOptions{FullCalcOnLoad: &fullCalcOnLoad})
+}
+
+// dryRunMoistureContent computes the moisture content in training mode. Nothing was
+// written to the Lab file during the pull, so the wet and can weights come from backup.json.
+func dryRunMoistureContent(can OvenCanData, dryWeight string) (float64, error) {
+	sample, err := FindSampleBackup(can.JobNumber, can.BoringNumber, can.Depth)
+	if err != nil {
+		return 0, err
+	}
+	if sample == nil {
+		return 0, fmt.Errorf("no backup entry for %s at %s in job %s", can.BoringNumber, can.Depth, can.JobNumber)
+	}
+
+	var wetWtAndCan, wtOfCan, dryWtAndCan float64
+	fmt.Sscanf(sample.WetWeight, "%f", &wetWtAndCan)
+	fmt.Sscanf(sample.CanWeight, "%f", &wtOfCan)
+	fmt.Sscanf(dryWeight, "%f", &dryWtAndCan)
+
+	if err := checkMoistureWeights(wetWtAndCan, wtOfCan, dryWtAndCan); err != nil {
+		logger.Error.Printf("[dry run] Rejected dry weight for can %s (Job: %s): %v", can.CanNumber, can.JobNumber, err)
+		return 0, err
+	}
+
+	_, _, moistureContent := calculateMoisture(wetWtAndCan, wtOfCan, dryWtAndCan)
+	logger.Info.Printf("[dry run] Would write dry weight %s for can %s (Job: %s) to %s column %s: Moisture Content %.1f%%",
+		dryWeight, can.CanNumber, can.JobNumber, can.MoistureSheet, can.MoistureColumn, moistureContent)
+	return moistureContent, nil
+}
+
+// hasVBAProject reports whether an Excel file contains a VBA macro project. excelize keeps
+// xl/vbaProject.bin when it opens and saves an .xlsm, so the Lab file's macros survive writes.
+func hasVBAProject(path string) bool {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		logger.Error.Printf("Failed to open %s to check for macros: %v", path, err)
+		return false
+	}
+	defer r.Close()
+	for _, file := range r.File {
+		if file.Name == "xl/vbaProject.bin" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,1633 @@
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"lms-tui/models"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+func TestAddCanToOvenConcurrent(t *testing.T) {
+	useTempProjectRoot(t)
+
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			canNumber := fmt.Sprintf("%d", 100+i)
+			if err := AddCanToOven(canNumber, "25490", "B-1", "0 - 1", "Moisture|9", "B", nil); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("AddCanToOven failed: %v", err)
+	}
+
+	cans, err := GetCansInOven()
+	if err != nil {
+		t.Fatalf("GetCansInOven failed: %v", err)
+	}
+	if len(cans) != workers {
+		t.Fatalf("expected %d cans in oven, got %d", workers, len(cans))
+	}
+
+	seen := map[string]bool{}
+	for _, can := range cans {
+		seen[can.CanNumber] = true
+	}
+	for i := 0; i < workers; i++ {
+		if !seen[fmt.Sprintf("%d", 100+i)] {
+			t.Errorf("can %d was lost", 100+i)
+		}
+	}
+}
+
+func TestRemoveCanFromOvenReturnsMatchingCan(t *testing.T) {
+	useTempProjectRoot(t)
+
+	cans := []struct{ can, boring, depth, column string }{
+		{"101", "B-1", "0 - 1", "B"},
+		{"102", "B-2", "2 - 3", "C"},
+		{"103", "B-3", "4 - 5", "D"},
+	}
+	for _, c := range cans {
+		if err := AddCanToOven(c.can, "25490", c.boring, c.depth, "Moisture|9", c.column, nil); err != nil {
+			t.Fatalf("AddCanToOven(%s) failed: %v", c.can, err)
+		}
+	}
+
+	removed, err := RemoveCanFromOven("102")
+	if err != nil {
+		t.Fatalf("RemoveCanFromOven failed: %v", err)
+	}
+	if removed.CanNumber != "102" || removed.BoringNumber != "B-2" || removed.Depth != "2 - 3" || removed.MoistureColumn != "C" {
+		t.Errorf("removed can data does not match can 102: %+v", *removed)
+	}
+
+	remaining, err := GetCansInOven()
+	if err != nil {
+		t.Fatalf("GetCansInOven failed: %v", err)
+	}
+	if len(remaining) != 2 || remaining[0].CanNumber != "101" || remaining[1].CanNumber != "103" {
+		t.Errorf("unexpected cans left in oven: %+v", remaining)
+	}
+
+	inOven, found, err := IsCanInOven("103")
+	if err != nil || !inOven {
+		t.Fatalf("expected can 103 in oven, got inOven=%v err=%v", inOven, err)
+	}
+	if found.BoringNumber != "B-3" {
+		t.Errorf("IsCanInOven returned wrong can: %+v", *found)
+	}
+}
+
+func TestSaveSampleBackupUpdatesExistingSample(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	if err := SaveSampleBackup("25490", "B-1", "0 - 1", "101", "50.0", "200.0", "", "Moisture|9", "B", "", ""); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+	if err := SaveSampleBackup("25490", "B-2", "2 - 3", "102", "50.0", "210.0", "", "Moisture|9", "C", "", ""); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+	if err := SaveSampleBackup("25490", "B-1", "0 - 1", "105", "51.0", "220.0", "", "Moisture|9", "B", "", ""); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+
+	backup, err := LoadBackupData(filepath.Join(root, "ex_project", "25490", "backup.json"))
+	if err != nil {
+		t.Fatalf("LoadBackupData failed: %v", err)
+	}
+	if len(backup.Samples) != 2 || backup.TotalSamples != 2 {
+		t.Fatalf("expected 2 samples, got %d (TotalSamples %d)", len(backup.Samples), backup.TotalSamples)
+	}
+	if backup.Samples[0].BoringNumber != "B-1" || backup.Samples[0].CanNumber != "105" {
+		t.Errorf("expected B-1 updated in place with can 105, got %+v", backup.Samples[0])
+	}
+	if backup.Samples[1].BoringNumber != "B-2" {
+		t.Errorf("expected B-2 second, got %+v", backup.Samples[1])
+	}
+}
+
+func TestPullAndEditShareBackupFile(t *testing.T) {
+	root := useTempProjectRoot(t)
+	// Run from somewhere other than the project root, as when the app is started elsewhere
+	t.Chdir(t.TempDir())
+
+	// Pulling saves through SaveSampleBackup
+	if err := SaveSampleBackup("25490", "B-1", "0 - 1", "101", "50.0", "200.0", "", "Moisture|9", "B", "", ""); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+
+	backupFile := BackupPath("25490")
+	if want := filepath.Join(root, "ex_project", "25490", "backup.json"); backupFile != want {
+		t.Fatalf("BackupPath = %s, want %s", backupFile, want)
+	}
+
+	// Editing loads, changes and saves the whole file
+	backup, err := LoadBackupData(backupFile)
+	if err != nil {
+		t.Fatalf("LoadBackupData failed: %v", err)
+	}
+	if len(backup.Samples) != 1 {
+		t.Fatalf("edit should see the pulled sample, got %d samples", len(backup.Samples))
+	}
+	backup.Samples[0].WetWeight = "205.0"
+	if err := SaveBackupDataToFile(backup, backupFile); err != nil {
+		t.Fatalf("SaveBackupDataToFile failed: %v", err)
+	}
+
+	sample, err := FindSampleBackup("25490", "B-1", "0 - 1")
+	if err != nil {
+		t.Fatalf("FindSampleBackup failed: %v", err)
+	}
+	if sample == nil || sample.WetWeight != "205.0" {
+		t.Errorf("expected the edit to be seen by pulls, got %+v", sample)
+	}
+	if _, err := os.Stat(filepath.Join("ex_project", "25490", "backup.json")); !os.IsNotExist(err) {
+		t.Errorf("nothing should be written relative to the working directory (stat err: %v)", err)
+	}
+}
+
+func TestCleanupBackupFileKeepsMostRecent(t *testing.T) {
+	backupFile := filepath.Join(t.TempDir(), "backup.json")
+
+	backup := &BackupData{
+		JobNumber: "25490",
+		Samples: []SampleBackupData{
+			{JobNumber: "25490", BoringNumber: "B-1", Depth: "0 - 1", CanNumber: "101", CanWeight: "50", WetWeight: "200", Timestamp: "2025-03-01 09:00:00"},
+			{JobNumber: "25490", BoringNumber: "B-2", Depth: "2 - 3", CanNumber: "102", CanWeight: "50", WetWeight: "200", Timestamp: "2025-03-01 09:05:00"},
+			{JobNumber: "25490", BoringNumber: "B-1", Depth: "0 - 1", CanNumber: "103", CanWeight: "50", WetWeight: "210", Timestamp: "2025-03-01 10:00:00"},
+			{JobNumber: "25490", BoringNumber: "B-2", Depth: "2 - 3", CanNumber: "104", CanWeight: "50", WetWeight: "190", Timestamp: "2025-03-01 08:00:00"},
+		},
+	}
+	if err := SaveBackupDataToFile(backup, backupFile); err != nil {
+		t.Fatalf("SaveBackupDataToFile failed: %v", err)
+	}
+
+	removed, err := CleanupBackupFile(backupFile)
+	if err != nil {
+		t.Fatalf("CleanupBackupFile failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("expected 2 entries removed, got %d", removed)
+	}
+
+	data, err := os.ReadFile(backupFile)
+	if err != nil {
+		t.Fatalf("failed to read cleaned backup: %v", err)
+	}
+	var cleaned BackupData
+	if err := json.Unmarshal(data, &cleaned); err != nil {
+		t.Fatalf("cleaned backup is not valid JSON: %v", err)
+	}
+	if cleaned.TotalSamples != 2 || len(cleaned.Samples) != 2 {
+		t.Fatalf("expected 2 samples after cleanup, got %d (TotalSamples %d)", len(cleaned.Samples), cleaned.TotalSamples)
+	}
+	if cleaned.Samples[0].CanNumber != "103" {
+		t.Errorf("expected newest B-1 entry (can 103), got can %s", cleaned.Samples[0].CanNumber)
+	}
+	if cleaned.Samples[1].CanNumber != "102" {
+		t.Errorf("expected newest B-2 entry (can 102), got can %s", cleaned.Samples[1].CanNumber)
+	}
+
+	// A clean file is left alone
+	removed, err = CleanupBackupFile(backupFile)
+	if err != nil || removed != 0 {
+		t.Errorf("expected no changes on second cleanup, got removed=%d err=%v", removed, err)
+	}
+}
+
+func TestLoadBackupDataMigratesUnversionedFile(t *testing.T) {
+	backupFile := filepath.Join(t.TempDir(), "backup.json")
+
+	// Written before schema versioning: no version, a duplicate entry and samples missing the job number
+	old := `{
+  "job_number": "25490",
+  "total_samples": 3,
+  "samples": [
+    {"boring_number": "B-1", "depth": "0 - 1", "can_number": "101", "can_weight": "50", "wet_weight": "200", "timestamp": "2025-03-01 09:00:00"},
+    {"job_number": "25490", "boring_number": "B-2", "depth": "2 - 3", "can_number": "102", "can_weight": "50", "wet_weight": "200", "timestamp": "2025-03-01 09:05:00"},
+    {"boring_number": "B-1", "depth": "0 - 1", "can_number": "103", "can_weight": "50", "wet_weight": "210", "timestamp": "2025-03-01 10:00:00"}
+  ]
+}`
+	if err := os.WriteFile(backupFile, []byte(old), 0644); err != nil {
+		t.Fatalf("failed to write old backup: %v", err)
+	}
+
+	backup, err := LoadBackupData(backupFile)
+	if err != nil {
+		t.Fatalf("LoadBackupData failed: %v", err)
+	}
+	if backup.SchemaVersion != BackupSchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", backup.SchemaVersion, BackupSchemaVersion)
+	}
+	if len(backup.Samples) != 2 || backup.Samples[0].CanNumber != "103" {
+		t.Fatalf("expected duplicates collapsed to the newest B-1 entry, got %+v", backup.Samples)
+	}
+	for _, sample := range backup.Samples {
+		if sample.JobNumber != "25490" {
+			t.Errorf("sample %s|%s job number = %q, want it filled from the file", sample.BoringNumber, sample.Depth, sample.JobNumber)
+		}
+	}
+
+	// Reading never writes; the upgrade reaches the file with the next save
+	data, err := os.ReadFile(backupFile)
+	if err != nil {
+		t.Fatalf("failed to read backup: %v", err)
+	}
+	if string(data) != old {
+		t.Errorf("LoadBackupData rewrote the file: %s", data)
+	}
+	if err := SaveBackupDataToFile(backup, backupFile); err != nil {
+		t.Fatalf("SaveBackupDataToFile failed: %v", err)
+	}
+	data, err = os.ReadFile(backupFile)
+	if err != nil {
+		t.Fatalf("failed to read migrated backup: %v", err)
+	}
+	var rewritten BackupData
+	if err := json.Unmarshal(data, &rewritten); err != nil {
+		t.Fatalf("migrated backup is not valid JSON: %v", err)
+	}
+	if rewritten.SchemaVersion != BackupSchemaVersion || len(rewritten.Samples) != 2 {
+		t.Errorf("expected the file rewritten at version %d with 2 samples, got version %d with %d",
+			BackupSchemaVersion, rewritten.SchemaVersion, len(rewritten.Samples))
+	}
+}
+
+func TestLoadBackupDataLeavesNewerVersionAlone(t *testing.T) {
+	backupFile := filepath.Join(t.TempDir(), "backup.json")
+
+	newer := fmt.Sprintf(`{"schema_version": %d, "job_number": "25490", "samples": []}`, BackupSchemaVersion+1)
+	if err := os.WriteFile(backupFile, []byte(newer), 0644); err != nil {
+		t.Fatalf("failed to write backup: %v", err)
+	}
+
+	backup, err := LoadBackupData(backupFile)
+	if err != nil {
+		t.Fatalf("LoadBackupData failed: %v", err)
+	}
+	if backup.SchemaVersion != BackupSchemaVersion+1 {
+		t.Errorf("SchemaVersion = %d, want the newer version kept", backup.SchemaVersion)
+	}
+	data, err := os.ReadFile(backupFile)
+	if err != nil {
+		t.Fatalf("failed to read backup: %v", err)
+	}
+	if string(data) != newer {
+		t.Errorf("a backup from a newer version should not be rewritten, got %s", data)
+	}
+
+	// Saving it would drop fields this version doesn't know, so every write path refuses
+	if err := SaveBackupDataToFile(backup, backupFile); !errors.Is(err, ErrBackupTooNew) {
+		t.Errorf("SaveBackupDataToFile = %v, want ErrBackupTooNew", err)
+	}
+}
+
+func TestUpsertSampleBackupRefusesNewerVersion(t *testing.T) {
+	useTempProjectRoot(t)
+
+	backupFile := BackupPath("25490")
+	if err := os.MkdirAll(filepath.Dir(backupFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	newer := fmt.Sprintf(`{"schema_version": %d, "job_number": "25490", "samples": [], "future_field": true}`, BackupSchemaVersion+1)
+	if err := os.WriteFile(backupFile, []byte(newer), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := SaveSampleBackup("25490", "B-1", "0 - 1", "101", "50", "150", "", "Moisture", "B", "", "tech")
+	if !errors.Is(err, ErrBackupTooNew) {
+		t.Errorf("SaveSampleBackup = %v, want ErrBackupTooNew", err)
+	}
+	if data, _ := os.ReadFile(backupFile); string(data) != newer {
+		t.Errorf("backup from a newer version was overwritten: %s", data)
+	}
+}
+
+func TestParseExcelDate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"45000", time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"45000.5", time.Date(2023, time.March, 15, 12, 0, 0, 0, time.UTC)},
+		{" 45658 ", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"03/15/2023", time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"3/5/2023", time.Date(2023, time.March, 5, 0, 0, 0, 0, time.UTC)},
+		{"2023-03-15", time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"Mar 15, 2023", time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"March 15, 2023", time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseExcelDate(tt.input)
+		if err != nil {
+			t.Errorf("parseExcelDate(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseExcelDate(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "TBD", "-1"} {
+		if _, err := parseExcelDate(input); err == nil {
+			t.Errorf("parseExcelDate(%q) expected an error", input)
+		}
+	}
+}
+
+func TestSampleBackupRecordsWhoEnteredIt(t *testing.T) {
+	useTempProjectRoot(t)
+
+	if err := SaveSampleBackup("25490", "B-1", "0 - 1", "101", "50", "150", "", "Moisture|9", "B", "", "jsmith"); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+	if err := SaveSampleBackup("25490", "B-1", "1 - 2", "102", "50", "160", "", "Moisture|9", "C", "", "akim"); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+	if err := SaveSampleBackup("25490", "B-1", "2 - 3", "103", "50", "170", "", "Moisture|9", "D", "", "jsmith"); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+	if err := SaveSkippedSample("25490", "B-2", "0 - 1", "Lost sample", "akim"); err != nil {
+		t.Fatalf("SaveSkippedSample failed: %v", err)
+	}
+	if err := UpdateSampleDryWeight("25490", "B-1", "0 - 1", "130", "akim"); err != nil {
+		t.Fatalf("UpdateSampleDryWeight failed: %v", err)
+	}
+
+	sample, err := FindSampleBackup("25490", "B-1", "0 - 1")
+	if err != nil || sample == nil {
+		t.Fatalf("FindSampleBackup = %v, %v", sample, err)
+	}
+	if sample.EnteredBy != "jsmith" || sample.WeighedBy != "akim" {
+		t.Errorf("EnteredBy/WeighedBy = %q/%q, want jsmith/akim", sample.EnteredBy, sample.WeighedBy)
+	}
+	skipped, err := FindSampleBackup("25490", "B-2", "0 - 1")
+	if err != nil || skipped == nil || skipped.EnteredBy != "akim" {
+		t.Errorf("skipped sample = %+v, %v, want it entered by akim", skipped, err)
+	}
+
+	// Skipped samples aren't work done, so the daily report only counts the pulled ones
+	activity, err := CollectDailyActivity(time.Now())
+	if err != nil {
+		t.Fatalf("CollectDailyActivity failed: %v", err)
+	}
+	if got := FormatEnteredBy(activity.EnteredBy); got != "jsmith 2, akim 1" {
+		t.Errorf("FormatEnteredBy = %q, want \"jsmith 2, akim 1\"", got)
+	}
+	if got := FormatEnteredBy(map[string]int{"": 2, "akim": 2}); got != "unrecorded 2, akim 2" {
+		t.Errorf("FormatEnteredBy with unrecorded samples = %q", got)
+	}
+}
+
+func TestProgressRoundTrip(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	// A job that was never pulled starts at the beginning
+	index, err := LoadProgress("25490")
+	if err != nil || index != 0 {
+		t.Fatalf("expected index 0 for a new job, got %d (err %v)", index, err)
+	}
+
+	if err := SaveProgress("25490", 7, 12); err != nil {
+		t.Fatalf("SaveProgress failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "ex_project", "25490", "progress.json")); err != nil {
+		t.Fatalf("progress.json not written under the project root: %v", err)
+	}
+
+	// No Lab file exists in the temp root, so the saved index is returned unvalidated
+	index, err = LoadProgress("25490")
+	if err != nil || index != 7 {
+		t.Fatalf("expected index 7, got %d (err %v)", index, err)
+	}
+
+	if err := MarkJobComplete("25490"); err != nil {
+		t.Fatalf("MarkJobComplete failed: %v", err)
+	}
+	progress, err := LoadProgressData("25490")
+	if err != nil {
+		t.Fatalf("LoadProgressData failed: %v", err)
+	}
+	if !progress.Completed || progress.CompletedAt == "" || progress.CurrentSampleIndex != 7 || progress.TotalSamples != 12 {
+		t.Errorf("unexpected progress after completing: %+v", progress)
+	}
+
+	if err := ReopenJob("25490"); err != nil {
+		t.Fatalf("ReopenJob failed: %v", err)
+	}
+	progress, err = LoadProgressData("25490")
+	if err != nil {
+		t.Fatalf("LoadProgressData failed: %v", err)
+	}
+	if progress.Completed || progress.CompletedAt != "" || progress.CurrentSampleIndex != 7 {
+		t.Errorf("unexpected progress after reopening: %+v", progress)
+	}
+}
+
+func TestExcelToJSON(t *testing.T) {
+	// testdata/Lab_99901.xlsm mirrors the Main Form layout of a real Lab file:
+	// header rows, then sample rows where a blank boring cell continues the boring above
+	fixture, err := filepath.Abs(filepath.Join("testdata", "Lab_99901.xlsm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobData, err := ExcelToJSON(fixture)
+	if err != nil {
+		t.Fatalf("ExcelToJSON failed: %v", err)
+	}
+
+	if jobData.JobNumber != "99901" {
+		t.Errorf("JobNumber = %q, want 99901", jobData.JobNumber)
+	}
+	if jobData.ProjectName != "Fixture Ranch" {
+		t.Errorf("ProjectName = %q, want Fixture Ranch", jobData.ProjectName)
+	}
+	if jobData.Engineer != "ABC" {
+		t.Errorf("Engineer = %q, want ABC", jobData.Engineer)
+	}
+	if jobData.Date != "01-05-26" {
+		t.Errorf("Date = %q, want 01-05-26", jobData.Date)
+	}
+	if jobData.StatedTotal != 6 {
+		t.Errorf("StatedTotal = %d, want 6", jobData.StatedTotal)
+	}
+	if jobData.TotalSamples != 6 {
+		t.Errorf("TotalSamples = %d, want 6", jobData.TotalSamples)
+	}
+
+	tests := []struct {
+		boring string
+		depth  string
+		tests  []string
+	}{
+		{"B-1", "1.5 - 3", []string{"Atterberg Limit", "Moisture Content", "Soil Suction"}},
+		{"B-1", "3 - 4.5", []string{"Moisture Content"}},
+		{"B-1", "4.5 - 6", []string{"Atterberg Limit (w/ lime)", "Moisture Content", "Absorption Pressure Swell", "QU", "Gradation"}},
+		{"B-2", "0 - 1", []string{"Moisture Content"}},
+		{"B-2", "1 - 2", []string{}},
+		{"B-2", "2 - 3", []string{"Moisture Content", "Soil Suction"}},
+	}
+	if len(jobData.Samples) != len(tests) {
+		t.Fatalf("got %d samples, want %d: %+v", len(jobData.Samples), len(tests), jobData.Samples)
+	}
+	for i, tt := range tests {
+		sample := jobData.Samples[i]
+		if sample.BoringNumber != tt.boring || sample.Depth != tt.depth {
+			t.Errorf("sample %d = %s at %s, want %s at %s", i+1, sample.BoringNumber, sample.Depth, tt.boring, tt.depth)
+		}
+		if strings.Join(sample.Tests, ",") != strings.Join(tt.tests, ",") {
+			t.Errorf("sample %d (%s at %s) tests = %v, want %v", i+1, tt.boring, tt.depth, sample.Tests, tt.tests)
+		}
+	}
+}
+
+func TestWriteDryWeightDryRunUsesBackup(t *testing.T) {
+	root := useTempProjectRoot(t)
+	original := Config.DryRun
+	Config.DryRun = true
+	t.Cleanup(func() { Config.DryRun = original })
+
+	if err := SaveSampleBackup("25490", "B-1", "0 - 1", "101", "50", "150", "", "Moisture|9", "B", "", ""); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+
+	can := OvenCanData{CanNumber: "101", JobNumber: "25490", BoringNumber: "B-1", Depth: "0 - 1", MoistureSheet: "Moisture|9", MoistureColumn: "B"}
+	moistureContent, err := WriteDryWeightToMoistureSheet(can, "130")
+	if err != nil {
+		t.Fatalf("WriteDryWeightToMoistureSheet failed: %v", err)
+	}
+	// (150 - 130) / (130 - 50) * 100
+	if moistureContent != 25 {
+		t.Errorf("moisture content = %v, want 25", moistureContent)
+	}
+	if _, err := os.Stat(filepath.Join(root, "ex_project", "25490", "Lab_25490.xlsm")); !os.IsNotExist(err) {
+		t.Errorf("dry run should not create the Lab file (stat err %v)", err)
+	}
+}
+
+func TestRemoveSampleBackup(t *testing.T) {
+	useTempProjectRoot(t)
+
+	for _, depth := range []string{"0 - 1", "1 - 2", "2 - 3"} {
+		if err := SaveSampleBackup("25490", "B-1", depth, "101", "50", "150", "", "Moisture|9", "B", "", ""); err != nil {
+			t.Fatalf("SaveSampleBackup failed: %v", err)
+		}
+	}
+
+	removed, err := RemoveSampleBackup("25490", "B-1", "1 - 2")
+	if err != nil || !removed {
+		t.Fatalf("RemoveSampleBackup = %v, %v; want true, nil", removed, err)
+	}
+	removed, err = RemoveSampleBackup("25490", "B-1", "1 - 2")
+	if err != nil || removed {
+		t.Fatalf("second RemoveSampleBackup = %v, %v; want false, nil", removed, err)
+	}
+
+	backup, err := LoadBackupData(filepath.Join(ProjectRoot, "ex_project", "25490", "backup.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backup.Samples) != 2 || backup.TotalSamples != 2 ||
+		backup.Samples[0].Depth != "0 - 1" || backup.Samples[1].Depth != "2 - 3" {
+		t.Errorf("unexpected backup after removal: %+v", backup)
+	}
+}
+
+func TestSaveSkippedSample(t *testing.T) {
+	useTempProjectRoot(t)
+
+	if err := SaveSampleBackup("25490", "B-1", "0 - 1", "101", "50", "150", "", "Moisture|9", "B", "", ""); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+	if err := SaveSkippedSample("25490", "B-1", "1 - 2", "  ", ""); err == nil {
+		t.Error("expected an error when skipping without a reason")
+	}
+	if err := SaveSkippedSample("25490", "B-1", "1 - 2", "Lost sample", ""); err != nil {
+		t.Fatalf("SaveSkippedSample failed: %v", err)
+	}
+
+	skipped, err := FindSampleBackup("25490", "B-1", "1 - 2")
+	if err != nil || skipped == nil {
+		t.Fatalf("skipped sample not found: %v", err)
+	}
+	if !skipped.IsSkipped() || skipped.SkipReason != "Lost sample" || skipped.CanNumber != "" {
+		t.Errorf("unexpected skipped entry: %+v", skipped)
+	}
+
+	tested, skippedCount, err := CountRecordedSamples("25490")
+	if err != nil || tested != 1 || skippedCount != 1 {
+		t.Errorf("CountRecordedSamples = %d, %d, %v; want 1, 1, nil", tested, skippedCount, err)
+	}
+
+	// Entering weights later replaces the skip
+	if err := SaveSampleBackup("25490", "B-1", "1 - 2", "102", "50", "160", "", "Moisture|9", "C", "", ""); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+	tested, skippedCount, _ = CountRecordedSamples("25490")
+	if tested != 2 || skippedCount != 0 {
+		t.Errorf("after recording weights got %d tested, %d skipped; want 2, 0", tested, skippedCount)
+	}
+}
+
+func TestIsMoistureSheet(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Moisture", true},
+		{"Moisture2", true},
+		{"Moisture10", true},
+		{"Moisture 2", false},
+		{"MoistureSummary", false},
+		{"MoistureNotes", false},
+		{"moisture", false},
+		{"Old Moisture", false},
+	}
+	for _, tt := range tests {
+		if got := isMoistureSheet(tt.name); got != tt.want {
+			t.Errorf("isMoistureSheet(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsSoilSuctionSheet(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Soil Suction", true},
+		{"Soil Suction2", true},
+		{"Soil Suction 2", true},
+		{"Soil Suction Summary", false},
+		{"Soil Suction Notes", false},
+		{"Soil Suction ", false},
+		{"Soil SuctionX", false},
+		{"Old Soil Suction", false},
+	}
+	for _, tt := range tests {
+		if got := isSoilSuctionSheet(tt.name); got != tt.want {
+			t.Errorf("isSoilSuctionSheet(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSoilSuctionSeparateFileRollsOver(t *testing.T) {
+	root := useTempProjectRoot(t)
+	original := Config.SuctionRowsPerSheet
+	Config.SuctionRowsPerSheet = 2
+	t.Cleanup(func() { Config.SuctionRowsPerSheet = original })
+
+	// Minimal Lab file with sample rows on the Soil Suction sheet starting at row 10
+	dirPath := filepath.Join(root, "ex_project", "25490")
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	labPath := filepath.Join(dirPath, "Lab_25490.xlsm")
+	lab := excelize.NewFile()
+	lab.SetSheetName("Sheet1", "Soil Suction")
+	depths := []string{"0 - 1", "1 - 2", "2 - 3"}
+	for i, depth := range depths {
+		lab.SetCellValue("Soil Suction", fmt.Sprintf("B%d", 10+i), "B-1")
+		lab.SetCellValue("Soil Suction", fmt.Sprintf("C%d", 10+i), depth)
+	}
+	if err := lab.SaveAs(labPath); err != nil {
+		t.Fatal(err)
+	}
+	lab.Close()
+
+	shared, err := excelize.OpenFile(labPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shared.Close()
+
+	writer, err := InitSoilSuctionFile("25490", shared)
+	if err != nil {
+		t.Fatalf("InitSoilSuctionFile failed: %v", err)
+	}
+	for i, depth := range depths {
+		if err := writer.WriteSoilSuctionSample("B-1", depth, fmt.Sprintf("S%d", i+1)); err != nil {
+			t.Fatalf("WriteSoilSuctionSample(%s) failed: %v", depth, err)
+		}
+	}
+	writer.Close()
+
+	separate, err := excelize.OpenFile(filepath.Join(dirPath, "SoilSuction_25490.xlsx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer separate.Close()
+
+	// Two samples fill the first sheet, the third starts "Soil Suction 2"
+	sheets := separate.GetSheetList()
+	if len(sheets) != 2 || sheets[0] != "Soil Suction" || sheets[1] != "Soil Suction 2" {
+		t.Fatalf("unexpected sheets %v", sheets)
+	}
+	for _, check := range []struct{ sheet, cell, want string }{
+		{"Soil Suction", "D2", "S1"},
+		{"Soil Suction", "D3", "S2"},
+		{"Soil Suction", "D4", ""},
+		{"Soil Suction 2", "D2", "S3"},
+	} {
+		if got, _ := separate.GetCellValue(check.sheet, check.cell); got != check.want {
+			t.Errorf("%s!%s = %q, want %q", check.sheet, check.cell, got, check.want)
+		}
+	}
+}
+
+func TestCheckMoistureWeights(t *testing.T) {
+	tests := []struct {
+		name            string
+		wet, can, dry   float64
+		wantErrContains string
+	}{
+		{"valid", 150, 50, 130, ""},
+		{"dry equals wet", 150, 50, 150, ""},
+		{"dry above wet", 150, 50, 160, "exceed wet weight"},
+		{"dry below can", 150, 50, 40, "more than the can weight"},
+		{"dry equals can", 150, 50, 50, "more than the can weight"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMoistureWeights(tt.wet, tt.can, tt.dry)
+			if tt.wantErrContains == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrContains) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErrContains)
+			}
+		})
+	}
+}
+
+func TestWriteDryWeightRejectsImpossibleWeights(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	// Minimal Lab file with one sample's wet and can weights in a block at row 9
+	jobDir := filepath.Join(root, "ex_project", "25490")
+	if err := os.MkdirAll(jobDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	labPath := filepath.Join(jobDir, "Lab_25490.xlsm")
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "Moisture")
+	f.SetCellValue("Moisture", "B12", 150)
+	f.SetCellValue("Moisture", "B15", 50)
+	if err := f.SaveAs(labPath); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	can := OvenCanData{CanNumber: "101", JobNumber: "25490", BoringNumber: "B-1", Depth: "0 - 1", MoistureSheet: "Moisture|9", MoistureColumn: "B"}
+	for _, dryWeight := range []string{"160", "40"} {
+		if _, err := WriteDryWeightToMoistureSheet(can, dryWeight); err == nil {
+			t.Errorf("dry weight %s: expected an error", dryWeight)
+		}
+	}
+
+	f, err := excelize.OpenFile(labPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	for _, cell := range []string{"B13", "B14", "B16", "B17"} {
+		if value, _ := f.GetCellValue("Moisture", cell); value != "" {
+			t.Errorf("%s = %q, want it left empty", cell, value)
+		}
+	}
+}
+
+func TestPreviewMoistureContentDoesNotWrite(t *testing.T) {
+	useTempProjectRoot(t)
+	original := Config.DryRun
+	Config.DryRun = true
+	t.Cleanup(func() { Config.DryRun = original })
+
+	if err := SaveSampleBackup("25490", "B-1", "0 - 1", "101", "50", "150", "", "Moisture|9", "B", "", ""); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+
+	can := OvenCanData{CanNumber: "101", JobNumber: "25490", BoringNumber: "B-1", Depth: "0 - 1", MoistureSheet: "Moisture|9", MoistureColumn: "B"}
+	moistureContent, err := PreviewMoistureContent(can, "130")
+	if err != nil || moistureContent != 25 {
+		t.Fatalf("PreviewMoistureContent = %v, %v; want 25, nil", moistureContent, err)
+	}
+	if _, err := PreviewMoistureContent(can, "160"); err == nil {
+		t.Error("expected an error for a dry weight above the wet weight")
+	}
+
+	sample, err := FindSampleBackup("25490", "B-1", "0 - 1")
+	if err != nil || sample == nil {
+		t.Fatalf("FindSampleBackup = %v, %v", sample, err)
+	}
+	if sample.DryWeight != "" {
+		t.Errorf("preview recorded a dry weight: %q", sample.DryWeight)
+	}
+}
+
+func TestFindAllLabFilesAcceptsXlsx(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	write := func(job string, names ...string) {
+		dir := filepath.Join(root, "projects", job)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range names {
+			if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	write("30001", "Lab_30001.xlsx")
+	write("30002", "Lab_30002.xlsm", "Lab_30002.xlsx", "Lab_30002_02.xlsx")
+
+	files, err := FindAllLabFiles("30001")
+	if err != nil || len(files) != 1 || files[0].FileName != "Lab_30001.xlsx" {
+		t.Fatalf("FindAllLabFiles(30001) = %+v, %v; want Lab_30001.xlsx", files, err)
+	}
+
+	files, err = FindAllLabFiles("30002")
+	if err != nil {
+		t.Fatalf("FindAllLabFiles(30002) failed: %v", err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.FileName)
+	}
+	if strings.Join(names, ",") != "Lab_30002.xlsm,Lab_30002_02.xlsx" {
+		t.Errorf("FindAllLabFiles(30002) = %v, want xlsm preferred over xlsx", names)
+	}
+
+	latest, err := FindLatestLabFile("30002")
+	if err != nil || filepath.Base(latest) != "Lab_30002_02.xlsx" {
+		t.Errorf("FindLatestLabFile(30002) = %q, %v; want Lab_30002_02.xlsx", latest, err)
+	}
+}
+
+func TestMacrosSurviveMoistureWrites(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	// Macro-bearing Lab file with one moisture block. The VBA project only needs the OLE header;
+	// the padding makes it compress, since excelize reads raw OLE bytes in a file as encryption.
+	vbaProject := append([]byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}, bytes.Repeat([]byte("lab template macros "), 200)...)
+	srcPath := filepath.Join(root, "projects", "25490", "Lab_25490.xlsm")
+	if err := os.MkdirAll(filepath.Dir(srcPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "Moisture")
+	f.SetCellValue("Moisture", "A9", "Boring No")
+	f.SetCellValue("Moisture", "B9", "B-1")
+	f.SetCellValue("Moisture", "A10", "Depth")
+	f.SetCellValue("Moisture", "B10", "0 - 1")
+	if err := f.AddVBAProject(vbaProject); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SaveAs(srcPath); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if !hasVBAProject(srcPath) {
+		t.Fatal("fixture has no VBA project")
+	}
+
+	writer, err := InitMoistureTestFile("25490", srcPath)
+	if err != nil {
+		t.Fatalf("InitMoistureTestFile failed: %v", err)
+	}
+	if err := writer.WriteMoistureSample("B-1", "0 - 1", "101", "50", "150"); err != nil {
+		t.Fatalf("WriteMoistureSample failed: %v", err)
+	}
+	writer.Close()
+
+	can := OvenCanData{CanNumber: "101", JobNumber: "25490", BoringNumber: "B-1", Depth: "0 - 1", MoistureSheet: "Moisture|9", MoistureColumn: "B"}
+	if _, err := WriteDryWeightToMoistureSheet(can, "130"); err != nil {
+		t.Fatalf("WriteDryWeightToMoistureSheet failed: %v", err)
+	}
+
+	workingPath := WorkingLabFilePath("25490")
+	r, err := zip.OpenReader(workingPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	var savedProject []byte
+	contentTypes := ""
+	for _, file := range r.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		switch file.Name {
+		case "xl/vbaProject.bin":
+			savedProject = data
+		case "[Content_Types].xml":
+			contentTypes = string(data)
+		}
+	}
+	if !bytes.Equal(savedProject, vbaProject) {
+		t.Errorf("VBA project was not preserved (got %d bytes)", len(savedProject))
+	}
+	if !strings.Contains(contentTypes, "macroEnabled") {
+		t.Error("working copy is no longer a macro-enabled workbook")
+	}
+}
+
+func TestCountRemainingSamples(t *testing.T) {
+	useTempProjectRoot(t)
+
+	samples := []SampleData{
+		{BoringNumber: "B-1", Depth: "0 - 1", Tests: []string{"Moisture Content"}},
+		{BoringNumber: "B-1", Depth: "1 - 2", Tests: []string{"Moisture Content", "Soil Suction"}},
+		{BoringNumber: "B-2", Depth: "0 - 1", Tests: []string{"Moisture Content", "Soil Suction"}},
+		{BoringNumber: "B-2", Depth: "1 - 2", Tests: []string{"Moisture Content"}},
+	}
+
+	moisture, suction, err := CountRemainingSamples("25490", samples)
+	if err != nil || moisture != 2 || suction != 2 {
+		t.Fatalf("CountRemainingSamples = %d, %d, %v; want 2, 2, nil", moisture, suction, err)
+	}
+
+	if err := SaveSampleBackup("25490", "B-1", "1 - 2", "101", "50", "150", "201", "Moisture|9", "C", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveSkippedSample("25490", "B-2", "1 - 2", "lost in field", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	moisture, suction, err = CountRemainingSamples("25490", samples)
+	if err != nil || moisture != 1 || suction != 1 {
+		t.Fatalf("after saving, CountRemainingSamples = %d, %d, %v; want 1, 1, nil", moisture, suction, err)
+	}
+}
+
+func TestGetColumnLetter(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{1, "A"},
+		{2, "B"},
+		{26, "Z"},
+		{27, "AA"},
+		{28, "AB"},
+		{52, "AZ"},
+		{53, "BA"},
+		{702, "ZZ"},
+		{703, "AAA"},
+	}
+	for _, tt := range tests {
+		if got := getColumnLetter(tt.index); got != tt.want {
+			t.Errorf("getColumnLetter(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+
+	// Round trip through excelize's own conversion across the 26 and 702 boundaries
+	for index := 1; index <= 1000; index++ {
+		letter := getColumnLetter(index)
+		back, err := excelize.ColumnNameToNumber(letter)
+		if err != nil || back != index {
+			t.Errorf("getColumnLetter(%d) = %q, which excelize reads as %d (%v)", index, letter, back, err)
+		}
+	}
+}
+
+func TestColumnLetterToIndex(t *testing.T) {
+	tests := []struct {
+		letter string
+		want   int
+	}{
+		{"A", 1},
+		{"Z", 26},
+		{"AA", 27},
+		{"AZ", 52},
+		{"BA", 53},
+		{"ZZ", 702},
+		{"AAA", 703},
+		{"XFD", 16384},
+		{"ab", 28},
+		{"", 0},
+		{"A1", 0},
+		{"B-", 0},
+	}
+	for _, tt := range tests {
+		if got := columnLetterToIndex(tt.letter); got != tt.want {
+			t.Errorf("columnLetterToIndex(%q) = %d, want %d", tt.letter, got, tt.want)
+		}
+	}
+
+	for index := 1; index <= 1000; index++ {
+		if back := columnLetterToIndex(getColumnLetter(index)); back != index {
+			t.Errorf("columnLetterToIndex(getColumnLetter(%d)) = %d", index, back)
+		}
+	}
+}
+
+func TestCheckColumnInSheet(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetName("Sheet1", "Moisture")
+	f.SetCellValue("Moisture", "A9", "Boring No")
+	f.SetCellValue("Moisture", "AB9", "B-1")
+
+	for _, column := range []string{"A", "B", "Z", "AB"} {
+		if err := checkColumnInSheet(f, "Moisture", column); err != nil {
+			t.Errorf("checkColumnInSheet(%s) = %v, want nil", column, err)
+		}
+	}
+	for _, column := range []string{"AC", "BA", "", "1"} {
+		if err := checkColumnInSheet(f, "Moisture", column); err == nil {
+			t.Errorf("checkColumnInSheet(%q) = nil, want an error", column)
+		}
+	}
+	if err := checkColumnInSheet(f, "Missing", "A"); err == nil {
+		t.Error("checkColumnInSheet on a missing sheet = nil, want an error")
+	}
+}
+
+func TestExcelToJSONBoringPattern(t *testing.T) {
+	original := Config.BoringPattern
+	t.Cleanup(func() { Config.BoringPattern = original })
+
+	path := filepath.Join(t.TempDir(), "Lab_30010.xlsx")
+	f := excelize.NewFile()
+	f.SetCellValue("Sheet1", "A1", "Job No.")
+	f.SetCellValue("Sheet1", "C1", "30010")
+	rows := [][]string{
+		{"B-1", "0 - 1"},
+		{"", "1 - 2"},
+		{"BH-2", "0 - 1"},
+		{"TP-3", "2 - 3"},
+		{"", "3 - 4"},
+		{"7", "0 - 1"},
+	}
+	for i, row := range rows {
+		f.SetCellValue("Sheet1", fmt.Sprintf("A%d", i+8), row[0])
+		f.SetCellValue("Sheet1", fmt.Sprintf("B%d", i+8), row[1])
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	var skipped []int
+	samplesOf := func() []string {
+		jobData, err := ExcelToJSON(path)
+		if err != nil {
+			t.Fatalf("ExcelToJSON failed: %v", err)
+		}
+		skipped = jobData.UnrecognizedRows
+		var got []string
+		for _, sample := range jobData.Samples {
+			got = append(got, sample.BoringNumber+" "+sample.Depth)
+		}
+		return got
+	}
+
+	// The default pattern only knows "B-" borings, so the others are skipped, along with
+	// TP-3's continuation row rather than it being put under B-1
+	Config.BoringPattern = ""
+	if got, want := strings.Join(samplesOf(), ","), "B-1 0 - 1,B-1 1 - 2"; got != want {
+		t.Errorf("default pattern: samples = %s, want %s", got, want)
+	}
+	if !slices.Equal(skipped, []int{10, 11, 12, 13}) {
+		t.Errorf("default pattern: UnrecognizedRows = %v, want [10 11 12 13]", skipped)
+	}
+
+	Config.BoringPattern = `^(B|BH|TP)-|^\d+$`
+	if got, want := strings.Join(samplesOf(), ","), "B-1 0 - 1,B-1 1 - 2,BH-2 0 - 1,TP-3 2 - 3,TP-3 3 - 4,7 0 - 1"; got != want {
+		t.Errorf("mixed pattern: samples = %s, want %s", got, want)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("mixed pattern: UnrecognizedRows = %v, want none", skipped)
+	}
+}
+
+func TestConfiguredTestTypes(t *testing.T) {
+	original := Config.Tests
+	t.Cleanup(func() { Config.Tests = original })
+
+	path := filepath.Join(t.TempDir(), "Lab_30011.xlsx")
+	f := excelize.NewFile()
+	f.SetCellValue("Sheet1", "A1", "Job No.")
+	f.SetCellValue("Sheet1", "C1", "30011")
+	f.SetCellValue("Sheet1", "A8", "B-1")
+	f.SetCellValue("Sheet1", "B8", "0 - 1")
+	f.SetCellValue("Sheet1", "E8", "x")
+	f.SetCellValue("Sheet1", "K8", "x")
+	if err := f.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// A lab form that added a Soil Suction (filter paper) test in column K
+	Config.Tests = append(slices.Clone(defaultConfig.Tests),
+		TestType{Name: "Filter Paper Suction", Column: 10, Category: TestCategorySuction, Keywords: []string{"Filter Paper"}})
+
+	jobData, err := ExcelToJSON(path)
+	if err != nil {
+		t.Fatalf("ExcelToJSON failed: %v", err)
+	}
+	if len(jobData.Samples) != 1 {
+		t.Fatalf("ExcelToJSON found %d samples, want 1", len(jobData.Samples))
+	}
+	sample := jobData.Samples[0]
+	if got, want := strings.Join(sample.Tests, ","), "Moisture Content,Filter Paper Suction"; got != want {
+		t.Errorf("Tests = %s, want %s", got, want)
+	}
+	if !sample.HasSoilSuction() || sample.HasOtherTests() {
+		t.Errorf("HasSoilSuction = %v, HasOtherTests = %v, want true, false", sample.HasSoilSuction(), sample.HasOtherTests())
+	}
+
+	tests := []struct {
+		test, category string
+	}{
+		{"Moisture Content", TestCategoryMoisture},
+		{"Moisture Content (oven)", TestCategoryMoisture},
+		{"Soil Suction", TestCategorySuction},
+		{"Filter Paper Suction 2", TestCategorySuction},
+		{"Gradation", TestCategoryOther},
+		{"Hydrometer", TestCategoryOther},
+	}
+	for _, tt := range tests {
+		if got := TestCategory(tt.test); got != tt.category {
+			t.Errorf("TestCategory(%q) = %q, want %q", tt.test, got, tt.category)
+		}
+	}
+}
+
+func TestSampleProcessingStatus(t *testing.T) {
+	useTempProjectRoot(t)
+
+	if err := AddCanToOven("101", "25490", "B-1", "0 - 1", "Moisture|9", "B", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddCanToOven("201", "25491", "B-1", "1 - 2", "Moisture|9", "C", nil); err != nil {
+		t.Fatal(err)
+	}
+	inOven, err := GetJobCansInOven("25490")
+	if err != nil {
+		t.Fatalf("GetJobCansInOven failed: %v", err)
+	}
+	if len(inOven) != 1 || inOven["B-1|0 - 1"].CanNumber != "101" {
+		t.Errorf("GetJobCansInOven(25490) = %+v, want only can 101", inOven)
+	}
+
+	tests := []struct {
+		name   string
+		sample SampleBackupData
+		want   string
+	}{
+		{"in oven", SampleBackupData{BoringNumber: "B-1", Depth: "0 - 1", CanNumber: "101"}, SampleStatusInOven},
+		{"dry recorded", SampleBackupData{BoringNumber: "B-1", Depth: "1 - 2", CanNumber: "102", DryWeight: "130"}, SampleStatusDryRecorded},
+		{"out of oven without dry weight", SampleBackupData{BoringNumber: "B-1", Depth: "2 - 3", CanNumber: "103"}, SampleStatusIncomplete},
+		{"skipped", SampleBackupData{BoringNumber: "B-2", Depth: "0 - 1", SkipReason: "No sample"}, SampleStatusSkipped},
+	}
+	for _, tt := range tests {
+		_, canInOven := inOven[tt.sample.BoringNumber+"|"+tt.sample.Depth]
+		if got := tt.sample.ProcessingStatus(canInOven); got != tt.want {
+			t.Errorf("%s: ProcessingStatus = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoverJobsReportsDuplicateFolders(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	for folder, labFile := range map[string]string{
+		"25490":      "Lab_25490.xlsm",
+		"25490 copy": "Lab_25490.xlsm",    // Renamed copy of the same job
+		"25491":      "Lab_25491_02.xlsm", // Version 02 kept in the base folder...
+		"25491_02":   "Lab_25491_02.xlsm", // ...and in a folder of its own
+	} {
+		labPath := filepath.Join(root, "projects", folder, labFile)
+		if err := os.MkdirAll(filepath.Dir(labPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		f := excelize.NewFile()
+		if err := f.SaveAs(labPath); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+
+	jobs, err := DiscoverJobs()
+	if err != nil {
+		t.Fatalf("DiscoverJobs failed: %v", err)
+	}
+	found := map[string]models.Job{}
+	for _, job := range jobs {
+		if _, dup := found[job.ProjectNumber]; dup {
+			t.Errorf("job %s listed twice", job.ProjectNumber)
+		}
+		found[job.ProjectNumber] = job
+	}
+	if len(found) != 2 {
+		t.Fatalf("DiscoverJobs = %v, want jobs 25490 and 25491_02", found)
+	}
+
+	tests := []struct {
+		job, folder, other string
+	}{
+		{"25490", "25490", "25490 copy"},
+		{"25491_02", "25491", "25491_02"},
+	}
+	for _, tt := range tests {
+		job := found[tt.job]
+		if filepath.Base(filepath.Dir(job.LabFilePath)) != tt.folder {
+			t.Errorf("job %s uses %s, want projects/%s", tt.job, job.LabFilePath, tt.folder)
+		}
+		if strings.Join(job.DuplicateFolders, ",") != tt.other {
+			t.Errorf("job %s DuplicateFolders = %v, want [%s]", tt.job, job.DuplicateFolders, tt.other)
+		}
+		if warning := job.DuplicateWarning(); !strings.Contains(warning, tt.folder) || !strings.Contains(warning, tt.other) {
+			t.Errorf("job %s DuplicateWarning = %q", tt.job, warning)
+		}
+	}
+}
+
+func TestDiscoverJobsMissingProjectsDir(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	jobs, err := DiscoverJobs()
+	if !errors.Is(err, ErrProjectsDirMissing) || len(jobs) != 0 {
+		t.Errorf("DiscoverJobs with no projects folder = %v, %v, want no jobs and ErrProjectsDirMissing", jobs, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "projects"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err = DiscoverJobs()
+	if err != nil || len(jobs) != 0 {
+		t.Errorf("DiscoverJobs with an empty projects folder = %v, %v, want no jobs and no error", jobs, err)
+	}
+}
+
+func TestDiscardHeldMoistureSample(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	srcPath := filepath.Join(root, "projects", "25611", "Lab_25611.xlsx")
+	if err := os.MkdirAll(filepath.Dir(srcPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "Moisture")
+	f.SetCellValue("Moisture", "A9", "Boring No")
+	f.SetCellValue("Moisture", "A10", "Depth")
+	f.SetCellValue("Moisture", "B9", "B-1")
+	f.SetCellValue("Moisture", "B10", "0 - 1")
+	if err := f.SaveAs(srcPath); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	writer, err := InitMoistureTestFile("25611", srcPath)
+	if err != nil {
+		t.Fatalf("InitMoistureTestFile failed: %v", err)
+	}
+	defer writer.Close()
+
+	if err := os.RemoveAll(filepath.Dir(WorkingLabFilePath("25611"))); err != nil {
+		t.Fatal(err)
+	}
+	// Retry Save while the share is still down holds the sample once, not once per try
+	for range 2 {
+		if err := writer.WriteMoistureSample("B-1", "0 - 1", "101", "50", "150"); !errors.Is(err, ErrWritePending) {
+			t.Fatalf("WriteMoistureSample with the share gone = %v, want ErrWritePending", err)
+		}
+	}
+	if len(writer.PendingWrites) != 1 {
+		t.Fatalf("PendingWrites after a retry = %v, want the sample held once", writer.PendingWrites)
+	}
+
+	// The tech backs out: the sample leaves the workbook and nothing is held any more
+	if err := writer.DiscardMoistureSample("B-1", "0 - 1"); err != nil {
+		t.Fatalf("DiscardMoistureSample failed: %v", err)
+	}
+	if len(writer.PendingWrites) != 0 {
+		t.Errorf("PendingWrites after discard = %v, want none", writer.PendingWrites)
+	}
+	if canNo, _ := writer.GetFile().GetCellValue("Moisture", "B11"); canNo != "" {
+		t.Errorf("Can No. in memory after discard = %q, want blank", canNo)
+	}
+	if saved, err := ReplayAllPendingWrites(); err != nil || saved != 0 {
+		t.Errorf("ReplayAllPendingWrites after discard = %d, %v, want nothing to save", saved, err)
+	}
+}
+
+func TestSoilSuctionLayoutFromConfig(t *testing.T) {
+	saved := Config.SheetLayouts
+	t.Cleanup(func() { Config.SheetLayouts = saved })
+
+	// A revised template with samples from row 8, Boring in C, Depth in D and the can in F
+	Config.SheetLayouts.SoilSuction = SoilSuctionLayout{FirstRow: 8, BoringColumn: "C", DepthColumn: "D", CanNoColumn: "F"}
+	f := excelize.NewFile()
+	defer f.Close()
+	f.SetSheetName("Sheet1", "Soil Suction")
+	f.SetCellValue("Soil Suction", "C7", "Boring No")
+	f.SetCellValue("Soil Suction", "D7", "Depth")
+	f.SetCellValue("Soil Suction", "C8", "B-1")
+	f.SetCellValue("Soil Suction", "D8", "0 - 1")
+
+	rows, _ := mapSoilSuctionRows(f)
+	if len(rows) != 1 || rows["B-1|0 - 1"] != "Soil Suction|8" {
+		t.Fatalf("rows = %v, want B-1 0 - 1 on row 8", rows)
+	}
+	if err := f.SaveAs(filepath.Join(t.TempDir(), "Lab.xlsx")); err != nil {
+		t.Fatal(err)
+	}
+	writer := &SoilSuctionWriter{file: f, sampleRowMap: rows}
+	if err := writer.WriteSoilSuctionSample("B-1", "0 - 1", "S1"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := f.GetCellValue("Soil Suction", "F8"); got != "S1" {
+		t.Errorf("Soil Suction!F8 = %q, want the can number", got)
+	}
+}
+
+func TestWriteSampleNoteClears(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	srcPath := filepath.Join(root, "projects", "25612", "Lab_25612.xlsx")
+	if err := os.MkdirAll(filepath.Dir(srcPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "Moisture")
+	f.SetCellValue("Moisture", "A9", "Boring No")
+	f.SetCellValue("Moisture", "A10", "Depth")
+	f.SetCellValue("Moisture", "B9", "B-1")
+	f.SetCellValue("Moisture", "B10", "0 - 1")
+	if err := f.SaveAs(srcPath); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	writer, err := InitMoistureTestFile("25612", srcPath)
+	if err != nil {
+		t.Fatalf("InitMoistureTestFile failed: %v", err)
+	}
+	defer writer.Close()
+
+	if err := writer.WriteSampleNote("B-1", "0 - 1", "Skipped: no sample in bag"); err != nil {
+		t.Fatal(err)
+	}
+	// Saving the sample again without a note takes the old one off
+	if err := writer.WriteSampleNote("B-1", "0 - 1", ""); err != nil {
+		t.Fatalf("WriteSampleNote with no note failed: %v", err)
+	}
+	if comments, _ := writer.GetFile().GetComments("Moisture"); len(comments) != 0 {
+		t.Errorf("notes after clearing = %+v, want none", comments)
+	}
+	// A sample with no Moisture column has no note to clear
+	if err := writer.WriteSampleNote("B-9", "0 - 1", ""); err != nil {
+		t.Errorf("clearing the note of an unmapped sample = %v, want nil", err)
+	}
+}
+
+func TestUnmappedSamples(t *testing.T) {
+	writer := &MoistureTestWriter{sampleColMap: map[string]string{"B-1|0 - 1": "Moisture|B|9"}}
+	samples := []SampleData{
+		{BoringNumber: "B-1", Depth: "0 - 1", Tests: []string{"Moisture Content"}},
+		{BoringNumber: "B-1", Depth: "1 - 2", Tests: []string{"Moisture Content"}},
+		{BoringNumber: "B-2", Depth: "0 - 1"}, // No tests, so never pulled
+	}
+	unmapped := writer.UnmappedSamples(samples)
+	if len(unmapped) != 1 || unmapped[0].Depth != "1 - 2" {
+		t.Errorf("UnmappedSamples = %+v, want only B-1 1 - 2", unmapped)
+	}
+}
+
+func TestWriteDryWeightMissingWorkingFile(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	if err := AddCanToOven("401", "25630", "B-1", "0 - 1", "Moisture|9", "B", nil); err != nil {
+		t.Fatal(err)
+	}
+	can := OvenCanData{CanNumber: "401", JobNumber: "25630", BoringNumber: "B-1", Depth: "0 - 1", MoistureSheet: "Moisture|9", MoistureColumn: "B"}
+
+	// With no ex_project folder the share may just not be mounted, so the file isn't called missing
+	if _, err := WriteDryWeightToMoistureSheet(can, "130"); err == nil || errors.Is(err, ErrWorkingFileMissing) {
+		t.Errorf("WriteDryWeightToMoistureSheet with no ex_project folder = %v, want a share error", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "ex_project"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := WriteDryWeightToMoistureSheet(can, "130"); !errors.Is(err, ErrWorkingFileMissing) {
+		t.Errorf("WriteDryWeightToMoistureSheet with no working file = %v, want ErrWorkingFileMissing", err)
+	}
+	if _, err := PreviewMoistureContent(can, "130"); !errors.Is(err, ErrWorkingFileMissing) {
+		t.Errorf("PreviewMoistureContent with no working file = %v, want ErrWorkingFileMissing", err)
+	}
+
+	if _, err := DropOrphanCan("401", "tech"); err != nil {
+		t.Fatalf("DropOrphanCan failed: %v", err)
+	}
+	if inOven, _, _ := IsCanInOven("401"); inOven {
+		t.Error("can 401 is still in the oven after DropOrphanCan")
+	}
+}
+
+func TestSamplesWithNoTests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Lab_30012.xlsx")
+	f := excelize.NewFile()
+	f.SetCellValue("Sheet1", "A1", "Job No.")
+	f.SetCellValue("Sheet1", "C1", "30012")
+	f.SetCellValue("Sheet1", "A8", "B-1")
+	f.SetCellValue("Sheet1", "B8", "0 - 1")
+	f.SetCellValue("Sheet1", "C8", "x")
+	f.SetCellValue("Sheet1", "B9", "1 - 2")  // No test marked
+	f.SetCellValue("Sheet1", "B10", "2 - 3") // No test marked
+	f.SetCellValue("Sheet1", "B11", "3 - 4")
+	f.SetCellValue("Sheet1", "C11", "x")
+	if err := f.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// No-test samples stay in the job so it lists every Main Form row
+	jobData, err := ExcelToJSON(path)
+	if err != nil {
+		t.Fatalf("ExcelToJSON failed: %v", err)
+	}
+	if len(jobData.Samples) != 4 {
+		t.Fatalf("ExcelToJSON found %d samples, want 4", len(jobData.Samples))
+	}
+	if jobData.Samples[1].HasTests() || !jobData.Samples[0].HasTests() {
+		t.Errorf("HasTests = %v, %v, want true, false", jobData.Samples[0].HasTests(), jobData.Samples[1].HasTests())
+	}
+
+	// ...but pulling passes over them
+	tests := []struct {
+		from, want int
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, 3},
+		{2, 3},
+		{3, 3},
+		{4, 4},
+	}
+	for _, tt := range tests {
+		if got := NextSampleWithTests(jobData.Samples, tt.from); got != tt.want {
+			t.Errorf("NextSampleWithTests(from %d) = %d, want %d", tt.from, got, tt.want)
+		}
+	}
+	if got := NextSampleWithTests(jobData.Samples[1:3], 0); got != 2 {
+		t.Errorf("NextSampleWithTests with only no-test samples = %d, want 2", got)
+	}
+}
+
+func TestCansAddedWithin(t *testing.T) {
+	now := time.Date(2025, 3, 2, 7, 0, 0, 0, Location())
+	cans := []OvenCanData{
+		{CanNumber: "101", TimeIn: now.Add(-30 * time.Hour).Format(TimestampFormat)}, // Two mornings ago
+		{CanNumber: "102", TimeIn: now.Add(-15 * time.Hour).Format(TimestampFormat)}, // Yesterday afternoon
+		{CanNumber: "103", TimeIn: "not a time"},
+		{CanNumber: "104", TimeIn: now.Add(-2 * time.Hour).Format(TimestampFormat)},
+	}
+
+	var got []string
+	for _, can := range CansAddedWithin(cans, 16, now) {
+		got = append(got, can.CanNumber)
+	}
+	if want := []string{"102", "103", "104"}; !slices.Equal(got, want) {
+		t.Errorf("CansAddedWithin(16h) = %v, want %v", got, want)
+	}
+	if recent := CansAddedWithin(cans, 1, now); len(recent) != 1 || recent[0].CanNumber != "103" {
+		t.Errorf("CansAddedWithin(1h) = %+v, want only the unreadable can", recent)
+	}
+}
+
+// Formula mode must show the same numbers on the sheet as the values static mode writes
+func TestMoistureFormulasMatchStaticValues(t *testing.T) {
+	original := Config.MoistureFormulas
+	t.Cleanup(func() { Config.MoistureFormulas = original })
+
+	shown := map[bool][]string{}
+	for _, formulas := range []bool{false, true} {
+		root := useTempProjectRoot(t)
+		Config.MoistureFormulas = formulas
+
+		srcPath := filepath.Join(root, "projects", "25490", "Lab_25490.xlsx")
+		if err := os.MkdirAll(filepath.Dir(srcPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		f := excelize.NewFile()
+		f.SetSheetName("Sheet1", "Moisture")
+		f.SetCellValue("Moisture", "A9", "Boring No")
+		f.SetCellValue("Moisture", "B9", "B-1")
+		f.SetCellValue("Moisture", "A10", "Depth")
+		f.SetCellValue("Moisture", "B10", "0 - 1")
+		if err := f.SaveAs(srcPath); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+
+		writer, err := InitMoistureTestFile("25490", srcPath)
+		if err != nil {
+			t.Fatalf("InitMoistureTestFile failed: %v", err)
+		}
+		if err := writer.WriteMoistureSample("B-1", "0 - 1", "101", "50.2", "150.3"); err != nil {
+			t.Fatalf("WriteMoistureSample failed: %v", err)
+		}
+		writer.Close()
+
+		can := OvenCanData{CanNumber: "101", JobNumber: "25490", BoringNumber: "B-1", Depth: "0 - 1", MoistureSheet: "Moisture|9", MoistureColumn: "B"}
+		moistureContent, err := WriteDryWeightToMoistureSheet(can, "130.1")
+		if err != nil {
+			t.Fatalf("WriteDryWeightToMoistureSheet (formulas %v) failed: %v", formulas, err)
+		}
+		if moistureContent != 25.3 {
+			t.Errorf("moisture content (formulas %v) = %v, want 25.3", formulas, moistureContent)
+		}
+
+		saved, err := excelize.OpenFile(WorkingLabFilePath("25490"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range []int{14, 16, 17} { // Wt. of water, Dry wt. of soil, Moisture Content
+			cell := fmt.Sprintf("B%d", row)
+			formula, _ := saved.GetCellFormula("Moisture", cell)
+			if (formula != "") != formulas {
+				t.Errorf("%s formula = %q with formulas %v", cell, formula, formulas)
+			}
+			value, err := saved.CalcCellValue("Moisture", cell)
+			if !formulas {
+				value, err = saved.GetCellValue("Moisture", cell)
+			}
+			if err != nil {
+				t.Fatalf("reading %s (formulas %v) failed: %v", cell, formulas, err)
+			}
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				t.Fatalf("%s (formulas %v) = %q, not a number", cell, formulas, value)
+			}
+			shown[formulas] = append(shown[formulas], strconv.FormatFloat(number, 'f', 1, 64))
+		}
+		saved.Close()
+	}
+
+	if got, want := strings.Join(shown[true], ","), strings.Join(shown[false], ","); got != want {
+		t.Errorf("formula mode shows %s, static mode shows %s", got, want)
+	}
+}
+
+func TestSampleNeedsRecheck(t *testing.T) {
+	useTempProjectRoot(t)
+
+	for _, depth := range []string{"0 - 1", "1 - 2"} {
+		if err := SaveSampleBackup("25490", "B-1", depth, "101", "50", "150", "", "Moisture|9", "B", "", "jsmith"); err != nil {
+			t.Fatalf("SaveSampleBackup failed: %v", err)
+		}
+	}
+	if err := SetSampleNeedsRecheck("25490", "B-1", "0 - 1", true); err != nil {
+		t.Fatalf("SetSampleNeedsRecheck failed: %v", err)
+	}
+	if err := SetSampleNeedsRecheck("25490", "B-9", "0 - 1", true); err == nil {
+		t.Error("SetSampleNeedsRecheck on a missing sample should fail")
+	}
+
+	// Editing the weights keeps the flag until someone clears it
+	if err := SaveSampleBackup("25490", "B-1", "0 - 1", "101", "50", "151", "", "Moisture|9", "B", "", "jsmith"); err != nil {
+		t.Fatalf("SaveSampleBackup failed: %v", err)
+	}
+	sample, err := FindSampleBackup("25490", "B-1", "0 - 1")
+	if err != nil || sample == nil || !sample.NeedsRecheck {
+		t.Fatalf("edited sample = %+v, %v; want still flagged for recheck", sample, err)
+	}
+
+	activity, err := CollectDailyActivity(time.Now())
+	if err != nil {
+		t.Fatalf("CollectDailyActivity failed: %v", err)
+	}
+	if activity.Rechecks != 1 || len(activity.Jobs) != 1 || strings.Join(activity.Jobs[0].Rechecks, ",") != "B-1|0 - 1" {
+		t.Errorf("daily activity rechecks = %d, jobs %+v; want B-1|0 - 1", activity.Rechecks, activity.Jobs)
+	}
+
+	if err := SetSampleNeedsRecheck("25490", "B-1", "0 - 1", false); err != nil {
+		t.Fatalf("clearing recheck failed: %v", err)
+	}
+	if sample, _ := FindSampleBackup("25490", "B-1", "0 - 1"); sample == nil || sample.NeedsRecheck {
+		t.Errorf("sample after clearing = %+v, want no recheck flag", sample)
+	}
+}
+
+func TestExcelToJSONLeavesOutRowsBeforeFirstBoring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Lab_30012.xlsx")
+	f := excelize.NewFile()
+	f.SetCellValue("Sheet1", "A1", "Job No.")
+	f.SetCellValue("Sheet1", "C1", "30012")
+	// The first sample row lost its boring label, so it has no boring to continue
+	rows := [][]string{
+		{"", "0 - 1"},
+		{"", "1 - 2"},
+		{"B-1", "2 - 3"},
+		{"", "3 - 4"},
+		{"B-2", "0 - 1"},
+	}
+	for i, row := range rows {
+		f.SetCellValue("Sheet1", fmt.Sprintf("A%d", i+8), row[0])
+		f.SetCellValue("Sheet1", fmt.Sprintf("B%d", i+8), row[1])
+		f.SetCellValue("Sheet1", fmt.Sprintf("E%d", i+8), "x")
+	}
+	if err := f.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	jobData, err := ExcelToJSON(path)
+	if err != nil {
+		t.Fatalf("ExcelToJSON failed: %v", err)
+	}
+	var got []string
+	for _, sample := range jobData.Samples {
+		if sample.BoringNumber == "" {
+			t.Errorf("sample at %s has no boring number", sample.Depth)
+		}
+		got = append(got, sample.BoringNumber+" "+sample.Depth)
+	}
+	if want := "B-1 2 - 3,B-1 3 - 4,B-2 0 - 1"; strings.Join(got, ",") != want {
+		t.Errorf("samples = %s, want %s", strings.Join(got, ","), want)
+	}
+	if jobData.TotalSamples != 3 {
+		t.Errorf("TotalSamples = %d, want 3", jobData.TotalSamples)
+	}
+	if !slices.Equal(jobData.UnlabeledRows, []int{8, 9}) {
+		t.Errorf("UnlabeledRows = %v, want [8 9]", jobData.UnlabeledRows)
+	}
+	if warnings := jobData.SkippedRowWarnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "8, 9") {
+		t.Errorf("SkippedRowWarnings = %q, want one naming rows 8, 9", warnings)
+	}
+}
@@ -0,0 +1,304 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	excelize "github.com/xuri/excelize/v2"
+	"lms-tui/logger"
+)
+
+// ReopenResult describes what reopening a job for added samples found
+type ReopenResult struct {
+	NewSamples  []SampleData // On the Main Form but not yet in backup.json, in form order
+	NewColumns  int          // Moisture columns the updated Lab file has that the working copy lacked
+	BackupPath  string       // Where the previous working copy was kept, if it was rebuilt
+	FirstSample int          // Index pulling resumes at
+}
+
+// FindNewSamples returns the indexes of samples that have no entry (pulled or skipped) in
+// the job's backup.json, e.g. borings the client added after the job was finished.
+// Samples with no tests marked are never pulled, so they are not new.
+func FindNewSamples(jobNumber string, samples []SampleData) ([]int, error) {
+	backupFile := BackupPath(jobNumber)
+
+	backup, err := LoadBackupData(backupFile)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := make(map[string]bool)
+	for _, sample := range backup.Samples {
+		recorded[sample.BoringNumber+"|"+sample.Depth] = true
+	}
+
+	var newSamples []int
+	for i, sample := range samples {
+		if sample.HasTests() && !recorded[sample.BoringNumber+"|"+sample.Depth] {
+			newSamples = append(newSamples, i)
+		}
+	}
+	return newSamples, nil
+}
+
+// ReopenJobForNewSamples reopens a job after its Lab file gained samples: it re-reads the
+// Main Form, finds the samples not yet in backup.json, rebuilds the working copy when the
+// Lab file has Moisture columns it lacks, and sets progress so pulling visits only the new samples.
+func ReopenJobForNewSamples(jobNumber, sourceLabFilePath string) (*ReopenResult, error) {
+	jobData, err := ExcelToJSON(sourceLabFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Lab file: %v", err)
+	}
+
+	newIndexes, err := FindNewSamples(jobNumber, jobData.Samples)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %v", err)
+	}
+
+	result := &ReopenResult{FirstSample: len(jobData.Samples)}
+	for _, i := range newIndexes {
+		result.NewSamples = append(result.NewSamples, jobData.Samples[i])
+	}
+	if len(newIndexes) > 0 {
+		result.FirstSample = newIndexes[0]
+	}
+
+	result.NewColumns, result.BackupPath, err = refreshWorkingLabFile(jobNumber, sourceLabFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update the working Lab file: %v", err)
+	}
+
+	progress, err := LoadProgressData(jobNumber)
+	if err != nil {
+		return nil, err
+	}
+	progress.Completed = false
+	progress.CompletedAt = ""
+	progress.NewSamplesOnly = true
+	progress.CurrentSampleIndex = result.FirstSample
+	progress.TotalSamples = len(jobData.Samples)
+	progress.LastSaved = Now()
+	progress.SavedBy = os.Getenv("USER")
+	if err := writeProgressData(progress); err != nil {
+		return nil, err
+	}
+
+	logger.Info.Printf("Reopened job %s for %d new samples (%d new Moisture columns)",
+		jobNumber, len(result.NewSamples), result.NewColumns)
+	return result, nil
+}
+
+// refreshWorkingLabFile rebuilds a job's working Lab file from the updated source when the
+// source maps samples the working copy doesn't, carrying every value already entered across
+// to wherever its sample now sits. The previous working copy is kept beside it. Returns the
+// number of new Moisture columns and the kept copy's path; nothing changes when there are none.
+func refreshWorkingLabFile(jobNumber, srcPath string) (int, string, error) {
+	workingPath := WorkingLabFilePath(jobNumber)
+	if _, err := os.Stat(workingPath); os.IsNotExist(err) {
+		return 0, "", nil // The first pull copies the updated Lab file anyway
+	}
+
+	srcData, err := os.ReadFile(srcPath)
+	if err != nil {
+		return 0, "", err
+	}
+	tmpPath := workingPath + ".reopen-tmp" + filepath.Ext(workingPath)
+	if err := os.WriteFile(tmpPath, srcData, 0644); err != nil {
+		return 0, "", err
+	}
+	defer os.Remove(tmpPath)
+
+	updated, err := excelize.OpenFile(tmpPath)
+	if err != nil {
+		return 0, "", err
+	}
+	defer updated.Close()
+
+	working, err := excelize.OpenFile(workingPath)
+	if err != nil {
+		return 0, "", err
+	}
+	defer working.Close()
+
+	oldColumns := mapMoistureColumns(working)
+	newColumns := mapMoistureColumns(updated)
+	added := 0
+	for key := range newColumns {
+		if _, ok := oldColumns[key]; !ok {
+			added++
+		}
+	}
+	if added == 0 {
+		return 0, "", nil
+	}
+	if Config.DryRun {
+		logger.Info.Printf("[dry run] Would rebuild %s with %d new Moisture columns", workingPath, added)
+		return added, "", nil
+	}
+
+	// Moisture blocks: every row the app writes, plus the tech's note on Can No.
+	offsets := moistureRows()
+	blockRows := []int{offsets.CanNo, offsets.WetWtAndCan, offsets.DryWtAndCan, offsets.WtOfWater,
+		offsets.WtOfCan, offsets.DryWtOfSoil, offsets.MoistureContent}
+	moved := map[string]MoistureLocation{}
+	for key, oldMapping := range oldColumns {
+		newMapping, ok := newColumns[key]
+		if !ok {
+			logger.Error.Printf("WARNING: Sample %s is no longer in the updated Lab file; its values stay in backup.json and %s", key, workingPath)
+			continue
+		}
+		from, to := parseMoistureMapping(oldMapping), parseMoistureMapping(newMapping)
+		for _, offset := range blockRows {
+			copyCellValue(working, updated, from.Sheet, fmt.Sprintf("%s%d", from.Column, from.BaseRow+offset),
+				to.Sheet, fmt.Sprintf("%s%d", to.Column, to.BaseRow+offset))
+		}
+		// Formulas written with moisture_formulas point at the old column, so write them afresh
+		if formula, _ := working.GetCellFormula(from.Sheet, fmt.Sprintf("%s%d", from.Column, from.BaseRow+offsets.MoistureContent)); formula != "" {
+			if err := setMoistureFormulas(updated, to.Sheet, to.Column, to.BaseRow); err != nil {
+				logger.Error.Printf("WARNING: Could not rewrite moisture formulas for sample %s: %v", key, err)
+			}
+		}
+		if from != to {
+			moved[key] = to
+		}
+	}
+	copySampleNotes(working, updated, oldColumns, newColumns)
+
+	// Soil suction can numbers
+	canNoColumn := soilSuctionLayout().CanNoColumn
+	oldRows, _ := mapSoilSuctionRows(working)
+	newRows, _ := mapSoilSuctionRows(updated)
+	for key, oldMapping := range oldRows {
+		newMapping, ok := newRows[key]
+		if !ok {
+			continue
+		}
+		fromParts, toParts := strings.Split(oldMapping, "|"), strings.Split(newMapping, "|")
+		copyCellValue(working, updated, fromParts[0], canNoColumn+fromParts[1], toParts[0], canNoColumn+toParts[1])
+	}
+
+	if err := updated.Save(); err != nil {
+		return 0, "", err
+	}
+
+	backupPath := fmt.Sprintf("%s.before-reopen-%s", workingPath, time.Now().Format("20060102-150405"))
+	if err := os.Rename(workingPath, backupPath); err != nil {
+		return 0, "", err
+	}
+	if err := os.Rename(tmpPath, workingPath); err != nil {
+		os.Rename(backupPath, workingPath)
+		return 0, "", err
+	}
+	logger.Info.Printf("Rebuilt %s from %s with %d new Moisture columns; previous copy kept as %s",
+		workingPath, srcPath, added, backupPath)
+
+	if len(moved) > 0 {
+		updateMovedMoistureLocations(jobNumber, moved)
+	}
+	return added, backupPath, nil
+}
+
+// parseMoistureMapping splits a "SheetName|ColumnLetter|BaseRow" column mapping
+func parseMoistureMapping(mapping string) MoistureLocation {
+	var location MoistureLocation
+	parts := strings.Split(mapping, "|")
+	if len(parts) != 3 {
+		return location
+	}
+	location.Sheet, location.Column = parts[0], parts[1]
+	fmt.Sscanf(parts[2], "%d", &location.BaseRow)
+	return location
+}
+
+// copyCellValue copies one cell's raw value between workbooks, keeping numbers numeric
+func copyCellValue(from, to *excelize.File, fromSheet, fromCell, toSheet, toCell string) {
+	value, err := from.GetCellValue(fromSheet, fromCell, excelize.Options{RawCellValue: true})
+	if err != nil || value == "" {
+		return
+	}
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		to.SetCellValue(toSheet, toCell, number)
+		return
+	}
+	to.SetCellValue(toSheet, toCell, value)
+}
+
+// copySampleNotes carries the notes left on Can No. cells across to the samples' new cells
+func copySampleNotes(from, to *excelize.File, oldColumns, newColumns map[string]string) {
+	canNoRow := moistureRows().CanNo
+	notes := map[string]excelize.Comment{} // "Sheet!Cell" -> comment
+	sheets := map[string]bool{}
+	for _, mapping := range oldColumns {
+		sheets[parseMoistureMapping(mapping).Sheet] = true
+	}
+	for sheet := range sheets {
+		comments, err := from.GetComments(sheet)
+		if err != nil {
+			continue
+		}
+		for _, comment := range comments {
+			notes[sheet+"!"+comment.Cell] = comment
+		}
+	}
+
+	for key, oldMapping := range oldColumns {
+		newMapping, ok := newColumns[key]
+		if !ok {
+			continue
+		}
+		fromLoc, toLoc := parseMoistureMapping(oldMapping), parseMoistureMapping(newMapping)
+		note, ok := notes[fmt.Sprintf("%s!%s%d", fromLoc.Sheet, fromLoc.Column, fromLoc.BaseRow+canNoRow)]
+		if !ok {
+			continue
+		}
+		cell := fmt.Sprintf("%s%d", toLoc.Column, toLoc.BaseRow+canNoRow)
+		to.DeleteComment(toLoc.Sheet, cell)
+		if err := to.AddComment(toLoc.Sheet, excelize.Comment{Cell: cell, Author: note.Author, Text: note.Text}); err != nil {
+			logger.Error.Printf("Failed to carry note for %s to %s!%s: %v", key, toLoc.Sheet, cell, err)
+		}
+	}
+}
+
+// updateMovedMoistureLocations points backup.json and oven tracking at samples' new Moisture
+// columns, since dry weights are written to the location recorded there
+func updateMovedMoistureLocations(jobNumber string, moved map[string]MoistureLocation) {
+	backupFile := BackupPath(jobNumber)
+	if backup, err := LoadBackupData(backupFile); err != nil {
+		logger.Error.Printf("Failed to load backup to update moved samples: %v", err)
+	} else {
+		for i, sample := range backup.Samples {
+			if to, ok := moved[sample.BoringNumber+"|"+sample.Depth]; ok && sample.MoistureColumn != "" {
+				backup.Samples[i].MoistureSheet = fmt.Sprintf("%s|%d", to.Sheet, to.BaseRow)
+				backup.Samples[i].MoistureColumn = to.Column
+			}
+		}
+		if err := SaveBackupDataToFile(backup, backupFile); err != nil {
+			logger.Error.Printf("Failed to save backup with moved samples: %v", err)
+		}
+	}
+
+	err := withFileLock(GetOvenTrackingFilePath(), func() error {
+		tracking, err := LoadOvenTracking()
+		if err != nil {
+			return err
+		}
+		for i, can := range tracking.Cans {
+			if can.JobNumber != jobNumber {
+				continue
+			}
+			if to, ok := moved[can.BoringNumber+"|"+can.Depth]; ok {
+				tracking.Cans[i].MoistureSheet = fmt.Sprintf("%s|%d", to.Sheet, to.BaseRow)
+				tracking.Cans[i].MoistureColumn = to.Column
+			}
+		}
+		return SaveOvenTracking(tracking)
+	})
+	if err != nil {
+		logger.Error.Printf("Failed to update oven tracking for moved samples: %v", err)
+	}
+	logger.Info.Printf("Updated %d moved sample locations for job %s", len(moved), jobNumber)
+}
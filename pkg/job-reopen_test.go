@@ -0,0 +1,131 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+func TestReopenJobForNewSamples(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	srcPath := filepath.Join(root, "projects", "25600", "Lab_25600.xlsx")
+	if err := os.MkdirAll(filepath.Dir(srcPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// writeLab saves a Lab file whose Moisture block holds the given samples left to right
+	writeLab := func(samples [][2]string) {
+		f := excelize.NewFile()
+		f.SetSheetName("Sheet1", "Main Form")
+		f.SetCellValue("Main Form", "A1", "Job No.")
+		f.SetCellValue("Main Form", "C1", "25600")
+		f.NewSheet("Moisture")
+		f.SetCellValue("Moisture", "A9", "Boring No")
+		f.SetCellValue("Moisture", "A10", "Depth")
+		formRow := 8
+		for _, boring := range []string{"B-1", "B-2"} {
+			for _, sample := range samples {
+				if sample[0] == boring {
+					f.SetCellValue("Main Form", fmt.Sprintf("A%d", formRow), sample[0])
+					f.SetCellValue("Main Form", fmt.Sprintf("B%d", formRow), sample[1])
+					f.SetCellValue("Main Form", fmt.Sprintf("C%d", formRow), "x") // Moisture Content
+					formRow++
+				}
+			}
+		}
+		for i, sample := range samples {
+			column := getColumnLetter(i + 2)
+			f.SetCellValue("Moisture", column+"9", sample[0])
+			f.SetCellValue("Moisture", column+"10", sample[1])
+		}
+		if err := f.SaveAs(srcPath); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+
+	writeLab([][2]string{{"B-1", "0 - 1"}, {"B-1", "1 - 2"}})
+	writer, err := InitMoistureTestFile("25600", srcPath)
+	if err != nil {
+		t.Fatalf("InitMoistureTestFile failed: %v", err)
+	}
+	for i, depth := range []string{"0 - 1", "1 - 2"} {
+		canNo := fmt.Sprintf("10%d", i+1)
+		if err := writer.WriteMoistureSample("B-1", depth, canNo, "50", "150"); err != nil {
+			t.Fatal(err)
+		}
+		sheet, column, _ := writer.GetSampleMapping("B-1", depth)
+		if err := SaveSampleBackup("25600", "B-1", depth, canNo, "50", "150", "", sheet, column, "", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.WriteSampleNote("B-1", "0 - 1", "cracked can"); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+	if err := MarkJobComplete("25600"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The client adds B-2, and the revised Moisture sheet puts it first
+	writeLab([][2]string{{"B-2", "0 - 1"}, {"B-1", "0 - 1"}, {"B-1", "1 - 2"}})
+	result, err := ReopenJobForNewSamples("25600", srcPath)
+	if err != nil {
+		t.Fatalf("ReopenJobForNewSamples failed: %v", err)
+	}
+	if len(result.NewSamples) != 1 || result.NewSamples[0].BoringNumber != "B-2" || result.FirstSample != 2 {
+		t.Errorf("new samples = %+v starting at %d, want only B-2 at index 2", result.NewSamples, result.FirstSample)
+	}
+	if result.NewColumns != 1 || result.BackupPath == "" {
+		t.Errorf("NewColumns = %d, BackupPath = %q; want 1 and a kept copy", result.NewColumns, result.BackupPath)
+	}
+	if _, err := os.Stat(result.BackupPath); err != nil {
+		t.Errorf("previous working copy not kept: %v", err)
+	}
+
+	// Entered values followed their samples to the new columns
+	f, err := excelize.OpenFile(WorkingLabFilePath("25600"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	for cell, want := range map[string]string{"B11": "", "C11": "101", "D11": "102", "C15": "50"} {
+		if got, _ := f.GetCellValue("Moisture", cell); got != want {
+			t.Errorf("Moisture!%s = %q, want %q", cell, got, want)
+		}
+	}
+	comments, _ := f.GetComments("Moisture")
+	if len(comments) != 1 || comments[0].Cell != "C11" {
+		t.Errorf("notes = %+v, want one on C11", comments)
+	}
+	backup, err := LoadBackupData(filepath.Join(root, "ex_project", "25600", "backup.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backup.Samples[0].MoistureColumn != "C" || backup.Samples[1].MoistureColumn != "D" {
+		t.Errorf("backup columns = %s, %s; want C, D", backup.Samples[0].MoistureColumn, backup.Samples[1].MoistureColumn)
+	}
+
+	progress, err := LoadProgressData("25600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.Completed || !progress.NewSamplesOnly || progress.CurrentSampleIndex != 2 {
+		t.Errorf("progress after reopening = %+v", progress)
+	}
+	if err := SaveProgress("25600", 3, 3); err != nil {
+		t.Fatal(err)
+	}
+	if progress, _ := LoadProgressData("25600"); !progress.NewSamplesOnly {
+		t.Error("SaveProgress dropped NewSamplesOnly")
+	}
+	if err := MarkJobComplete("25600"); err != nil {
+		t.Fatal(err)
+	}
+	if progress, _ := LoadProgressData("25600"); progress.NewSamplesOnly {
+		t.Error("MarkJobComplete kept NewSamplesOnly")
+	}
+}
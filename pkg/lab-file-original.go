@@ -0,0 +1,114 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	excelize "github.com/xuri/excelize/v2"
+	"lms-tui/logger"
+)
+
+// LabCellChange is one cell that differs between the working Lab file and its original snapshot
+type LabCellChange struct {
+	Sheet    string
+	Cell     string
+	Original string
+	Current  string
+}
+
+// OriginalLabFilePath returns the untouched snapshot kept next to a job's working Lab file,
+// e.g. ex_project/25490/Lab_25490.orig.xlsm
+func OriginalLabFilePath(jobNumber string) string {
+	workingPath := WorkingLabFilePath(jobNumber)
+	ext := filepath.Ext(workingPath)
+	return strings.TrimSuffix(workingPath, ext) + ".orig" + ext
+}
+
+// snapshotOriginalLabFile writes the source Lab file's bytes as the job's original snapshot.
+// It is only ever written once, so it stays a rollback point even after the working copy changes.
+func snapshotOriginalLabFile(workingPath string, srcData []byte) {
+	ext := filepath.Ext(workingPath)
+	origPath := strings.TrimSuffix(workingPath, ext) + ".orig" + ext
+	if _, err := os.Stat(origPath); err == nil {
+		return
+	}
+	if err := os.WriteFile(origPath, srcData, 0444); err != nil {
+		logger.Error.Printf("Failed to write original Lab file snapshot %s: %v", origPath, err)
+		return
+	}
+	logger.Info.Printf("Saved original Lab file snapshot: %s", origPath)
+}
+
+// CompareLabFileToOriginal lists every cell whose value differs between a job's working Lab
+// file and the snapshot taken when it was first copied, sheet by sheet in workbook order
+func CompareLabFileToOriginal(jobNumber string) ([]LabCellChange, error) {
+	origPath := OriginalLabFilePath(jobNumber)
+	if _, err := os.Stat(origPath); err != nil {
+		return nil, fmt.Errorf("no original snapshot for job %s: %v", jobNumber, err)
+	}
+
+	original, err := excelize.OpenFile(origPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open original Lab file: %v", err)
+	}
+	defer original.Close()
+
+	current, err := excelize.OpenFile(WorkingLabFilePath(jobNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open working Lab file: %v", err)
+	}
+	defer current.Close()
+
+	// Sheets only in one file still count, so a dropped or added sheet shows up
+	sheets := current.GetSheetList()
+	for _, sheet := range original.GetSheetList() {
+		if idx, _ := current.GetSheetIndex(sheet); idx == -1 {
+			sheets = append(sheets, sheet)
+		}
+	}
+
+	changes := []LabCellChange{}
+	for _, sheet := range sheets {
+		// A missing sheet reads as empty
+		originalRows, _ := original.GetRows(sheet, excelize.Options{RawCellValue: true})
+		currentRows, _ := current.GetRows(sheet, excelize.Options{RawCellValue: true})
+
+		rowCount := len(originalRows)
+		if len(currentRows) > rowCount {
+			rowCount = len(currentRows)
+		}
+		for rowIdx := 0; rowIdx < rowCount; rowIdx++ {
+			var originalRow, currentRow []string
+			if rowIdx < len(originalRows) {
+				originalRow = originalRows[rowIdx]
+			}
+			if rowIdx < len(currentRows) {
+				currentRow = currentRows[rowIdx]
+			}
+
+			colCount := len(originalRow)
+			if len(currentRow) > colCount {
+				colCount = len(currentRow)
+			}
+			for colIdx := 0; colIdx < colCount; colIdx++ {
+				var before, after string
+				if colIdx < len(originalRow) {
+					before = originalRow[colIdx]
+				}
+				if colIdx < len(currentRow) {
+					after = currentRow[colIdx]
+				}
+				if before == after {
+					continue
+				}
+				cell, _ := excelize.CoordinatesToCellName(colIdx+1, rowIdx+1)
+				changes = append(changes, LabCellChange{Sheet: sheet, Cell: cell, Original: before, Current: after})
+			}
+		}
+	}
+
+	logger.Info.Printf("Compared Lab file for job %s to its original: %d cells changed", jobNumber, len(changes))
+	return changes, nil
+}
@@ -0,0 +1,82 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+func TestOriginalLabFileSnapshotAndCompare(t *testing.T) {
+	root := useTempProjectRoot(t)
+	original := Config.KeepOriginalLabFile
+	Config.KeepOriginalLabFile = true
+	t.Cleanup(func() { Config.KeepOriginalLabFile = original })
+
+	srcPath := filepath.Join(root, "projects", "25490", "Lab_25490.xlsm")
+	if err := os.MkdirAll(filepath.Dir(srcPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "Moisture")
+	f.SetCellValue("Moisture", "A9", "Boring No")
+	f.SetCellValue("Moisture", "B9", "B-1")
+	f.SetCellValue("Moisture", "A10", "Depth")
+	f.SetCellValue("Moisture", "B10", "0 - 1")
+	if err := f.SaveAs(srcPath); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	writer, err := InitMoistureTestFile("25490", srcPath)
+	if err != nil {
+		t.Fatalf("InitMoistureTestFile failed: %v", err)
+	}
+	if err := writer.WriteMoistureSample("B-1", "0 - 1", "101", "50", "150"); err != nil {
+		t.Fatalf("WriteMoistureSample failed: %v", err)
+	}
+	writer.Close()
+
+	origPath := OriginalLabFilePath("25490")
+	if origPath != filepath.Join(root, "ex_project", "25490", "Lab_25490.orig.xlsm") {
+		t.Errorf("OriginalLabFilePath = %s", origPath)
+	}
+
+	changes, err := CompareLabFileToOriginal("25490")
+	if err != nil {
+		t.Fatalf("CompareLabFileToOriginal failed: %v", err)
+	}
+	changed := map[string]LabCellChange{}
+	for _, change := range changes {
+		changed[change.Sheet+"!"+change.Cell] = change
+	}
+	for cell, want := range map[string]string{"Moisture!B11": "101", "Moisture!B12": "150", "Moisture!B15": "50"} {
+		change, ok := changed[cell]
+		if !ok {
+			t.Errorf("%s not reported as changed", cell)
+			continue
+		}
+		if change.Original != "" || change.Current != want {
+			t.Errorf("%s = %q -> %q, want \"\" -> %q", cell, change.Original, change.Current, want)
+		}
+	}
+	if _, ok := changed["Moisture!B9"]; ok {
+		t.Error("unchanged cell B9 reported as changed")
+	}
+
+	// A second init must not overwrite the snapshot with the modified working copy
+	writer, err = InitMoistureTestFile("25490", srcPath)
+	if err != nil {
+		t.Fatalf("second InitMoistureTestFile failed: %v", err)
+	}
+	writer.Close()
+	snapshot, err := excelize.OpenFile(origPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer snapshot.Close()
+	if value, _ := snapshot.GetCellValue("Moisture", "B11"); value != "" {
+		t.Errorf("snapshot B11 = %q, want it untouched", value)
+	}
+}
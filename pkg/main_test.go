@@ -0,0 +1,25 @@
+package pkg
+
+import (
+	"io"
+	"log"
+	"os"
+	"testing"
+
+	"lms-tui/logger"
+)
+
+func TestMain(m *testing.M) {
+	// Discard log output so tests don't need a logs directory
+	logger.Info = log.New(io.Discard, "", 0)
+	logger.Error = log.New(io.Discard, "", 0)
+	logger.Debug = log.New(io.Discard, "", 0)
+	os.Exit(m.Run())
+}
+
+// useTempProjectRoot points ProjectRoot at a fresh temp directory for the test
+func useTempProjectRoot(t *testing.T) string {
+	t.Helper()
+	t.Cleanup(SetProjectRoot(t.TempDir()))
+	return ProjectRoot
+}
@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"lms-tui/logger"
+	"lms-tui/models"
+)
+
+// MetaOverrideFileName is the optional file in projects/<jobNumber>/ the office edits to
+// correct job details read wrongly from the Lab file's Main Form
+const MetaOverrideFileName = "meta_override.json"
+
+// MetaOverride replaces job details parsed from the Main Form. It only changes what the
+// app shows; the Lab file is never touched. Empty fields keep the parsed value.
+type MetaOverride struct {
+	ProjectName      string `json:"project_name,omitempty"`
+	EngineerInitials string `json:"engineer,omitempty"`
+	DateAssigned     string `json:"date_assigned,omitempty"` // e.g. "03/14/2025", any format the Main Form accepts
+	DueDate          string `json:"due_date,omitempty"`
+}
+
+// LoadMetaOverride reads meta_override.json from a job folder.
+// Returns nil (and no error) when the folder has no override file.
+func LoadMetaOverride(projectDir string) (*MetaOverride, error) {
+	overridePath := filepath.Join(projectDir, MetaOverrideFileName)
+	data, err := os.ReadFile(overridePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		logger.Error.Printf("Failed to read %s: %v", overridePath, err)
+		return nil, err
+	}
+
+	var override MetaOverride
+	if err := json.Unmarshal(data, &override); err != nil {
+		logger.Error.Printf("Failed to parse %s: %v", overridePath, err)
+		return nil, fmt.Errorf("invalid %s: %v", overridePath, err)
+	}
+	return &override, nil
+}
+
+// Apply merges the override over the job's parsed details. A date that can't be read is
+// reported and left as parsed; the other fields are still applied.
+func (o *MetaOverride) Apply(job *models.Job) error {
+	if name := strings.TrimSpace(o.ProjectName); name != "" {
+		job.ProjectName = name
+	}
+	if engineer := strings.TrimSpace(o.EngineerInitials); engineer != "" {
+		job.EngineerInitials = engineer
+	}
+	dateAssigned, dueDate, err := o.dates()
+	if !dateAssigned.IsZero() {
+		job.DateAssigned = dateAssigned
+	}
+	if !dueDate.IsZero() {
+		job.DueDate = dueDate
+	}
+	return err
+}
+
+// ApplyToJobData merges the override over the details read straight from the Main Form for
+// the job detail header, so it shows what the job lists show. Dates are written MM/DD/YYYY.
+func (o *MetaOverride) ApplyToJobData(jobData *JobData) error {
+	if name := strings.TrimSpace(o.ProjectName); name != "" {
+		jobData.ProjectName = name
+	}
+	if engineer := strings.TrimSpace(o.EngineerInitials); engineer != "" {
+		jobData.Engineer = engineer
+	}
+	dateAssigned, dueDate, err := o.dates()
+	if !dateAssigned.IsZero() {
+		jobData.Date = dateAssigned.Format("01/02/2006")
+	}
+	if !dueDate.IsZero() {
+		jobData.DueDate = dueDate.Format("01/02/2006")
+	}
+	return err
+}
+
+// dates parses the override's dates. A date that is unset or can't be read is zero, and
+// the read failures are returned together.
+func (o *MetaOverride) dates() (dateAssigned, dueDate time.Time, err error) {
+	var errs []error
+	if o.DateAssigned != "" {
+		if dateAssigned, err = parseExcelDate(o.DateAssigned); err != nil {
+			errs = append(errs, fmt.Errorf("date_assigned: %v", err))
+		}
+	}
+	if o.DueDate != "" {
+		if dueDate, err = parseExcelDate(o.DueDate); err != nil {
+			errs = append(errs, fmt.Errorf("due_date: %v", err))
+		}
+	}
+	return dateAssigned, dueDate, errors.Join(errs...)
+}
@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lms-tui/models"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+func TestDiscoverJobsAppliesMetaOverride(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	for _, job := range []string{"25490", "25491"} {
+		labPath := filepath.Join(root, "projects", job, "Lab_"+job+".xlsm")
+		if err := os.MkdirAll(filepath.Dir(labPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		f := excelize.NewFile()
+		if err := f.SaveAs(labPath); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+	override := `{"project_name": "Riverside Lift Station", "engineer": "KLM", "due_date": "03/14/2025", "date_assigned": "not a date"}`
+	if err := os.WriteFile(filepath.Join(root, "projects", "25490", MetaOverrideFileName), []byte(override), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := DiscoverJobs()
+	if err != nil {
+		t.Fatalf("DiscoverJobs failed: %v", err)
+	}
+	found := map[string]models.Job{}
+	for _, job := range jobs {
+		found[job.ProjectNumber] = job
+	}
+	corrected, plain := found["25490"], found["25491"]
+	if corrected.ProjectName != "Riverside Lift Station" || corrected.EngineerInitials != "KLM" || corrected.FormatDueDate() != "03/14/2025" {
+		t.Errorf("corrected job = %q, %q, due %s; want the override's values",
+			corrected.ProjectName, corrected.EngineerInitials, corrected.FormatDueDate())
+	}
+	// The unreadable date keeps what was parsed, like the job without an override
+	if corrected.FormatDateAssigned() != plain.FormatDateAssigned() {
+		t.Errorf("date assigned = %s, want the parsed %s", corrected.FormatDateAssigned(), plain.FormatDateAssigned())
+	}
+	if plain.ProjectName == "Riverside Lift Station" || plain.EngineerInitials == "KLM" {
+		t.Errorf("override for 25490 leaked into 25491: %+v", plain)
+	}
+}
+
+func TestMetaOverrideAppliesToJobData(t *testing.T) {
+	jobData := &JobData{ProjectName: "Riversde Lift", Engineer: "KL", Date: "45000", DueDate: "45010"}
+	override := MetaOverride{ProjectName: "Riverside Lift Station", DueDate: "03/14/2025", DateAssigned: "not a date"}
+	if err := override.ApplyToJobData(jobData); err == nil {
+		t.Error("expected the unreadable date_assigned to be reported")
+	}
+	want := JobData{ProjectName: "Riverside Lift Station", Engineer: "KL", Date: "45000", DueDate: "03/14/2025"}
+	if jobData.ProjectName != want.ProjectName || jobData.Engineer != want.Engineer || jobData.Date != want.Date || jobData.DueDate != want.DueDate {
+		t.Errorf("job data = %q, %q, %q, %q; want %q, %q, %q, %q", jobData.ProjectName, jobData.Engineer, jobData.Date, jobData.DueDate,
+			want.ProjectName, want.Engineer, want.Date, want.DueDate)
+	}
+}
@@ -0,0 +1,98 @@
+package pkg
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"lms-tui/logger"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+// moistureExportColumns mirrors the rows of the printed moisture content form
+var moistureExportColumns = []ExportColumn{
+	{"Boring", 10},
+	{"Depth", 12},
+	{"Can No.", 10},
+	{"Wet wt. and can (g)", 14},
+	{"Dry wt. of soil and can (g)", 14},
+	{"Wt. of water (g)", 12},
+	{"Wt. of can (g)", 12},
+	{"Dry wt. of soil (g)", 12},
+	{"Moisture Content (%)", 12},
+	{"Notes", 30},
+}
+
+// ExportMoistureSheet writes the job's moisture results from backup.json to
+// ex_project/<job>/MoistureExport_<job>.xlsx for printing and returns the file path.
+// Samples still in the oven have no dry weight, so their computed columns stay blank.
+func ExportMoistureSheet(jobNumber string) (string, error) {
+	dirPath := filepath.Join(ProjectRoot, "ex_project", jobNumber)
+	backup, err := LoadBackupData(BackupPath(jobNumber))
+	if err != nil {
+		return "", err
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := "Moisture Content"
+	f.SetSheetName("Sheet1", sheetName)
+	writeExportHeader(f, sheetName, moistureExportColumns)
+
+	numberStyle, err := twoDecimalStyle(f)
+	if err != nil {
+		logger.Error.Printf("Failed to create number style for moisture export: %v", err)
+		return "", err
+	}
+
+	for i, sample := range backup.Samples {
+		row := i + 2
+		cell := func(col int) string {
+			return fmt.Sprintf("%s%d", getColumnLetter(col), row)
+		}
+
+		f.SetCellValue(sheetName, cell(1), sample.BoringNumber)
+		f.SetCellValue(sheetName, cell(2), sample.Depth)
+		if sample.IsSkipped() {
+			f.SetCellValue(sheetName, cell(3), "Skipped")
+			f.SetCellValue(sheetName, cell(10), sample.SkipReason)
+			continue
+		}
+		f.SetCellValue(sheetName, cell(3), sample.CanNumber)
+		f.SetCellValue(sheetName, cell(10), sample.Notes)
+
+		wetWtAndCan, wetErr := strconv.ParseFloat(sample.WetWeight, 64)
+		wtOfCan, canErr := strconv.ParseFloat(sample.CanWeight, 64)
+		if wetErr == nil {
+			f.SetCellValue(sheetName, cell(4), wetWtAndCan)
+		}
+		if canErr == nil {
+			f.SetCellValue(sheetName, cell(7), wtOfCan)
+		}
+
+		dryWtAndCan, dryErr := strconv.ParseFloat(sample.DryWeight, 64)
+		if dryErr == nil && wetErr == nil && canErr == nil {
+			wtOfWater, dryWtOfSoil, moistureContent := calculateMoisture(wetWtAndCan, wtOfCan, dryWtAndCan)
+			f.SetCellValue(sheetName, cell(5), dryWtAndCan)
+			f.SetCellValue(sheetName, cell(6), wtOfWater)
+			f.SetCellValue(sheetName, cell(8), dryWtOfSoil)
+			f.SetCellValue(sheetName, cell(9), moistureContent)
+		}
+		f.SetCellStyle(sheetName, cell(4), cell(9), numberStyle)
+	}
+
+	exportPath := filepath.Join(dirPath, fmt.Sprintf("MoistureExport_%s.xlsx", jobNumber))
+	if Config.DryRun {
+		logger.Info.Printf("[dry run] Would export %d moisture samples to %s", len(backup.Samples), exportPath)
+		return exportPath, nil
+	}
+	if err := f.SaveAs(exportPath); err != nil {
+		logger.Error.Printf("Failed to save moisture export: %v", err)
+		return "", err
+	}
+
+	logger.Info.Printf("Exported %d moisture samples for job %s to %s", len(backup.Samples), jobNumber, exportPath)
+	return exportPath, nil
+}
@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"testing"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+func TestExportMoistureSheet(t *testing.T) {
+	useTempProjectRoot(t)
+
+	if err := SaveSampleBackup("25490", "B-1", "0 - 1", "101", "50", "150", "", "Moisture|9", "B", "cracked can", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateSampleDryWeight("25490", "B-1", "0 - 1", "130", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveSampleBackup("25490", "B-1", "1 - 2", "102", "50", "160", "", "Moisture|9", "C", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveSkippedSample("25490", "B-1", "2 - 3", "Lost sample", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	exportPath, err := ExportMoistureSheet("25490")
+	if err != nil {
+		t.Fatalf("ExportMoistureSheet failed: %v", err)
+	}
+	f, err := excelize.OpenFile(exportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, check := range []struct{ cell, want string }{
+		{"A1", "Boring"},
+		{"I1", "Moisture Content (%)"},
+		{"C2", "101"},
+		{"D2", "150.00"},
+		{"E2", "130.00"},
+		{"F2", "20.00"},
+		{"H2", "80.00"},
+		{"I2", "25.00"}, // (150 - 130) / (130 - 50) * 100
+		{"J2", "cracked can"},
+		{"D3", "160.00"},
+		{"E3", ""}, // still in the oven
+		{"I3", ""},
+		{"C4", "Skipped"},
+		{"J4", "Lost sample"},
+	} {
+		if got, _ := f.GetCellValue("Moisture Content", check.cell); got != check.want {
+			t.Errorf("%s = %q, want %q", check.cell, got, check.want)
+		}
+	}
+}
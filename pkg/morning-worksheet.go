@@ -0,0 +1,89 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"lms-tui/logger"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+// morningWorksheetColumns is the paper list of cans to weigh, with a blank column for the
+// dry weight written at the balance
+var morningWorksheetColumns = []ExportColumn{
+	{"Can No.", 10},
+	{"Job", 12},
+	{"Boring", 10},
+	{"Depth", 12},
+	{"Time In", 18},
+	{"Dry Weight (g)", 16},
+}
+
+// ExportMorningWorksheet writes the cans currently in the oven to
+// ex_project/morning_<date>.xlsx for printing and returns the file path
+func ExportMorningWorksheet() (string, error) {
+	cans, err := GetCansInOven()
+	if err != nil {
+		return "", err
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := "Morning Count"
+	f.SetSheetName("Sheet1", sheetName)
+	writeExportHeader(f, sheetName, morningWorksheetColumns)
+
+	// Borders on every cell and taller rows leave room to write by hand
+	bodyStyle, err := f.NewStyle(&excelize.Style{
+		Border: []excelize.Border{
+			{Type: "left", Color: "#000000", Style: 1},
+			{Type: "right", Color: "#000000", Style: 1},
+			{Type: "top", Color: "#000000", Style: 1},
+			{Type: "bottom", Color: "#000000", Style: 1},
+		},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	if err != nil {
+		logger.Error.Printf("Failed to create body style for morning worksheet: %v", err)
+		return "", err
+	}
+
+	for i, can := range cans {
+		row := i + 2
+		timeIn := can.TimeIn
+		if t, err := can.ParseTimeIn(); err == nil {
+			timeIn = t.In(Location()).Format("01/02 15:04")
+		}
+		values := []string{can.CanNumber, can.JobNumber, can.BoringNumber, can.Depth, timeIn, ""}
+		for col, value := range values {
+			f.SetCellValue(sheetName, fmt.Sprintf("%s%d", getColumnLetter(col+1), row), value)
+		}
+		f.SetRowHeight(sheetName, row, 24)
+	}
+	if len(cans) > 0 {
+		lastCell := fmt.Sprintf("%s%d", getColumnLetter(len(morningWorksheetColumns)), len(cans)+1)
+		f.SetCellStyle(sheetName, "A2", lastCell, bodyStyle)
+	}
+
+	date := time.Now().In(Location()).Format("2006-01-02")
+	exportPath := filepath.Join(ProjectRoot, "ex_project", fmt.Sprintf("morning_%s.xlsx", date))
+	if Config.DryRun {
+		logger.Info.Printf("[dry run] Would export %d cans to morning worksheet %s", len(cans), exportPath)
+		return exportPath, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(exportPath), 0755); err != nil {
+		logger.Error.Printf("Failed to create ex_project directory: %v", err)
+		return "", err
+	}
+	if err := f.SaveAs(exportPath); err != nil {
+		logger.Error.Printf("Failed to save morning worksheet: %v", err)
+		return "", err
+	}
+
+	logger.Info.Printf("Exported %d cans to morning worksheet %s", len(cans), exportPath)
+	return exportPath, nil
+}
@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"testing"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+func TestExportMorningWorksheet(t *testing.T) {
+	useTempProjectRoot(t)
+
+	for _, can := range []string{"101", "102"} {
+		if err := AddCanToOven(can, "25490", "B-1", "0 - 1", "Moisture|9", "B", nil); err != nil {
+			t.Fatalf("AddCanToOven(%s) failed: %v", can, err)
+		}
+	}
+
+	exportPath, err := ExportMorningWorksheet()
+	if err != nil {
+		t.Fatalf("ExportMorningWorksheet failed: %v", err)
+	}
+
+	f, err := excelize.OpenFile(exportPath)
+	if err != nil {
+		t.Fatalf("failed to open worksheet: %v", err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("Morning Count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 cans, got %d rows", len(rows))
+	}
+	if rows[1][0] != "101" || rows[2][0] != "102" || rows[1][1] != "25490" {
+		t.Errorf("unexpected worksheet rows: %q", rows[1:])
+	}
+	if value, _ := f.GetCellValue("Morning Count", "F2"); value != "" {
+		t.Errorf("dry weight column should be blank, got %q", value)
+	}
+}
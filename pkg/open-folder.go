@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"lms-tui/logger"
+	"lms-tui/models"
+)
+
+// JobFolder returns the folder to inspect for a job: ex_project/<job> once the job
+// has been pulled, otherwise the job's projects folder
+func JobFolder(job models.Job) string {
+	exProjectDir := filepath.Join(ProjectRoot, "ex_project", job.ProjectNumber)
+	if info, err := os.Stat(exProjectDir); err == nil && info.IsDir() {
+		return exProjectDir
+	}
+	folderName := job.FolderName
+	if folderName == "" {
+		folderName = job.ProjectNumber
+	}
+	return filepath.Join(ProjectsDir(), folderName)
+}
+
+// OpenJobFolder opens the job's folder with Config.OpenFolderCommand (default xdg-open).
+// The file manager is started in the background so the TUI keeps running.
+func OpenJobFolder(job models.Job) (string, error) {
+	folder := JobFolder(job)
+
+	command := strings.Fields(Config.OpenFolderCommand)
+	if len(command) == 0 {
+		command = []string{"xdg-open"}
+	}
+	if _, err := exec.LookPath(command[0]); err != nil {
+		logger.Error.Printf("Open folder command '%s' is not available: %v", command[0], err)
+		return folder, fmt.Errorf("'%s' is not available on this machine.\n\nFolder: %s", command[0], folder)
+	}
+
+	cmd := exec.Command(command[0], append(command[1:], folder)...)
+	if err := cmd.Start(); err != nil {
+		logger.Error.Printf("Failed to open folder %s: %v", folder, err)
+		return folder, fmt.Errorf("failed to open %s: %v", folder, err)
+	}
+	// Reap the process when the file manager launcher exits
+	go cmd.Wait()
+
+	logger.Info.Printf("Opened folder for job %s: %s", job.ProjectNumber, folder)
+	return folder, nil
+}
@@ -0,0 +1,89 @@
+package pkg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// OvenBatch tags the next cans put in the oven from a pull screen as one drying cohort,
+// e.g. a set loaded together late in the day. It is metadata only: the batch is stored on
+// each can's oven entry and nothing else depends on it.
+type OvenBatch struct {
+	ID        string
+	StartedAt string // When the batch was started, in TimeIn's format
+	Remaining int    // Cans still to be tagged
+}
+
+// NewOvenBatch starts a batch that tags the next count cans with id
+func NewOvenBatch(id string, count int) (*OvenBatch, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("a batch name is required")
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("the number of cans must be above 0")
+	}
+	return &OvenBatch{ID: id, StartedAt: Now(), Remaining: count}, nil
+}
+
+// Active reports whether the batch still has cans to tag. A nil batch is not active.
+func (b *OvenBatch) Active() bool {
+	return b != nil && b.Remaining > 0
+}
+
+// Take counts a can into the batch and returns the ID and start to store on it, or empty
+// strings once the batch is used up
+func (b *OvenBatch) Take() (string, string) {
+	if !b.Active() {
+		return "", ""
+	}
+	b.Remaining--
+	return b.ID, b.StartedAt
+}
+
+// OvenBatchIDs returns the batches the cans belong to, in the order they first appear
+func OvenBatchIDs(cans []OvenCanData) []string {
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, can := range cans {
+		if can.BatchID != "" && !seen[can.BatchID] {
+			seen[can.BatchID] = true
+			ids = append(ids, can.BatchID)
+		}
+	}
+	return ids
+}
+
+// CansInBatch returns the cans tagged with the batch id, in order
+func CansInBatch(cans []OvenCanData, id string) []OvenCanData {
+	inBatch := []OvenCanData{}
+	for _, can := range cans {
+		if can.BatchID == id {
+			inBatch = append(inBatch, can)
+		}
+	}
+	return inBatch
+}
+
+// GroupCansByBatch orders cans so each batch's cans are together, batches in the order
+// they first appear and cans with no batch last. Order within a group is kept.
+func GroupCansByBatch(cans []OvenCanData) []OvenCanData {
+	rank := map[string]int{}
+	for i, id := range OvenBatchIDs(cans) {
+		rank[id] = i
+	}
+	groupOf := func(can OvenCanData) int {
+		if can.BatchID == "" {
+			return len(rank)
+		}
+		return rank[can.BatchID]
+	}
+
+	grouped := make([]OvenCanData, len(cans))
+	copy(grouped, cans)
+	sort.SliceStable(grouped, func(i, j int) bool {
+		return groupOf(grouped[i]) < groupOf(grouped[j])
+	})
+	return grouped
+}
@@ -0,0 +1,62 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+)
+
+// Cans added during a batch are tagged until it is used up, and grouped for Morning Count
+func TestOvenBatchTagsNextCans(t *testing.T) {
+	useTempProjectRoot(t)
+
+	if _, err := NewOvenBatch("  ", 2); err == nil {
+		t.Error("NewOvenBatch accepted a blank name")
+	}
+	if _, err := NewOvenBatch("late", 0); err == nil {
+		t.Error("NewOvenBatch accepted 0 cans")
+	}
+
+	if err := AddCanToOven("100", "25490", "B-1", "0 - 1", "Moisture|9", "B", nil); err != nil {
+		t.Fatalf("AddCanToOven(100) failed: %v", err)
+	}
+	batch, err := NewOvenBatch("late", 2)
+	if err != nil {
+		t.Fatalf("NewOvenBatch failed: %v", err)
+	}
+	for _, can := range []string{"101", "102", "103"} {
+		if err := AddCanToOven(can, "25490", "B-"+can, "0 - 1", "Moisture|9", "B", batch); err != nil {
+			t.Fatalf("AddCanToOven(%s) failed: %v", can, err)
+		}
+	}
+	if batch.Active() {
+		t.Errorf("batch still active with %d remaining after 3 cans", batch.Remaining)
+	}
+
+	cans, err := GetCansInOven()
+	if err != nil {
+		t.Fatalf("GetCansInOven failed: %v", err)
+	}
+	want := map[string]string{"100": "", "101": "late", "102": "late", "103": ""}
+	for _, can := range cans {
+		if can.BatchID != want[can.CanNumber] {
+			t.Errorf("can %s batch = %q, want %q", can.CanNumber, can.BatchID, want[can.CanNumber])
+		}
+		if (can.BatchID != "") != (can.BatchStartedAt != "") {
+			t.Errorf("can %s batch started at %q with batch %q", can.CanNumber, can.BatchStartedAt, can.BatchID)
+		}
+	}
+
+	if ids := OvenBatchIDs(cans); len(ids) != 1 || ids[0] != "late" {
+		t.Errorf("OvenBatchIDs = %v, want [late]", ids)
+	}
+	if inBatch := CansInBatch(cans, "late"); len(inBatch) != 2 {
+		t.Errorf("CansInBatch(late) returned %d cans, want 2", len(inBatch))
+	}
+	order := []string{}
+	for _, can := range GroupCansByBatch(cans) {
+		order = append(order, can.CanNumber)
+	}
+	if got := strings.Join(order, ","); got != "101,102,100,103" {
+		t.Errorf("GroupCansByBatch order = %s, want 101,102,100,103", got)
+	}
+}
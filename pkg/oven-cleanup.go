@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+
+	"lms-tui/logger"
+)
+
+// RemoveCansWithoutDryWeight takes cans out of oven tracking without writing a dry weight,
+// for cans that were physically removed and never weighed. Each removal is logged with who
+// did it so the missing dry weights can be traced. It returns the cans removed and an error
+// naming any that couldn't be.
+func RemoveCansWithoutDryWeight(canNumbers []string, removedBy string) ([]OvenCanData, error) {
+	var removed []OvenCanData
+	var errs []error
+	for _, canNumber := range canNumbers {
+		can, err := RemoveCanFromOven(canNumber)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can %s: %v", canNumber, err))
+			continue
+		}
+		logger.Info.Printf("Manual oven removal by %s: can %s (Job: %s, Boring: %s, Depth: %s, in since %s) removed without recording a dry weight",
+			removedBy, can.CanNumber, can.JobNumber, can.BoringNumber, can.Depth, can.TimeIn)
+		removed = append(removed, *can)
+	}
+	return removed, errors.Join(errs...)
+}
+
+// DropOrphanCan takes a can out of oven tracking when its job's working Lab file is gone,
+// so there is nowhere left to record its dry weight. droppedBy is logged for the audit trail.
+func DropOrphanCan(canNumber string, droppedBy string) (*OvenCanData, error) {
+	can, err := RemoveCanFromOven(canNumber)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info.Printf("Dropped orphan oven entry by %s: can %s (Job: %s, Boring: %s, Depth: %s, in since %s) has no working Lab file to record a dry weight in",
+		droppedBy, can.CanNumber, can.JobNumber, can.BoringNumber, can.Depth, can.TimeIn)
+	return can, nil
+}
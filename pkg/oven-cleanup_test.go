@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRemoveCansWithoutDryWeight(t *testing.T) {
+	useTempProjectRoot(t)
+
+	for _, can := range []string{"301", "302", "303"} {
+		if err := AddCanToOven(can, "25620", "B-1", "0 - "+can, "Moisture|9", "B", nil); err != nil {
+			t.Fatalf("AddCanToOven(%s) failed: %v", can, err)
+		}
+	}
+
+	removed, err := RemoveCansWithoutDryWeight([]string{"301", "303", "999"}, "mgr")
+	if err == nil || !strings.Contains(err.Error(), "999") {
+		t.Errorf("RemoveCansWithoutDryWeight error = %v, want one naming can 999", err)
+	}
+	if len(removed) != 2 || removed[0].CanNumber != "301" || removed[1].CanNumber != "303" {
+		t.Errorf("removed = %+v, want cans 301 and 303", removed)
+	}
+
+	cans, err := GetCansInOven()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cans) != 1 || cans[0].CanNumber != "302" {
+		t.Errorf("cans left in oven = %+v, want only 302", cans)
+	}
+}
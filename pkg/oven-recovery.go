@@ -0,0 +1,106 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"lms-tui/logger"
+)
+
+// OvenTrackingRecovery describes a corrupt oven_tracking.json that was reset
+type OvenTrackingRecovery struct {
+	BackupPath    string // Where the corrupt file was moved
+	RecoveredCans int    // Intact can entries salvaged from the corrupt file
+	Err           error  // The original parse error
+}
+
+var (
+	ovenRecoveryMu      sync.Mutex
+	pendingOvenRecovery *OvenTrackingRecovery
+)
+
+// TakeOvenTrackingRecovery returns the most recent recovery (if any) and clears it,
+// so a screen can tell the tech once that tracking was reset
+func TakeOvenTrackingRecovery() *OvenTrackingRecovery {
+	ovenRecoveryMu.Lock()
+	defer ovenRecoveryMu.Unlock()
+
+	recovery := pendingOvenRecovery
+	pendingOvenRecovery = nil
+	return recovery
+}
+
+// recoverOvenTracking handles an oven_tracking.json that failed to parse: it salvages
+// whatever complete can entries it can, moves the corrupt file aside with a timestamp,
+// and writes the salvaged entries back as the new tracking file
+func recoverOvenTracking(filePath string, data []byte, parseErr error) (*OvenTrackingData, error) {
+	logger.Error.Printf("!!! OVEN TRACKING FILE IS CORRUPT: %s: %v", filePath, parseErr)
+
+	tracking := &OvenTrackingData{Cans: partialParseOvenCans(data)}
+
+	backupPath := fmt.Sprintf("%s.corrupt-%s", filePath, time.Now().Format("20060102-150405"))
+	if err := os.Rename(filePath, backupPath); err != nil {
+		logger.Error.Printf("Failed to back up corrupt oven tracking file: %v", err)
+		return nil, fmt.Errorf("oven tracking file is corrupt and could not be backed up: %v", err)
+	}
+	logger.Error.Printf("!!! Corrupt oven tracking file moved to %s; recovered %d cans", backupPath, len(tracking.Cans))
+
+	if err := SaveOvenTracking(tracking); err != nil {
+		return nil, err
+	}
+
+	ovenRecoveryMu.Lock()
+	pendingOvenRecovery = &OvenTrackingRecovery{
+		BackupPath:    backupPath,
+		RecoveredCans: len(tracking.Cans),
+		Err:           parseErr,
+	}
+	ovenRecoveryMu.Unlock()
+
+	return tracking, nil
+}
+
+// partialParseOvenCans decodes can entries from the "cans" array one at a time,
+// stopping at the first damaged entry (e.g. a file truncated mid-write)
+func partialParseOvenCans(data []byte) []OvenCanData {
+	cans := []OvenCanData{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	// Walk tokens until the "cans" key
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return cans
+	}
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return cans
+		}
+		if key, ok := tok.(string); ok && key == "cans" {
+			break
+		}
+		// Skip this key's value
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return cans
+		}
+	}
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return cans
+	}
+	for dec.More() {
+		var can OvenCanData
+		if err := dec.Decode(&can); err != nil {
+			break
+		}
+		if can.CanNumber == "" {
+			continue
+		}
+		cans = append(cans, can)
+	}
+	return cans
+}
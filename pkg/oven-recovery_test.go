@@ -0,0 +1,63 @@
+package pkg
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadOvenTrackingRecoversTruncatedFile(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	for _, can := range []string{"101", "102", "103"} {
+		if err := AddCanToOven(can, "25490", "B-"+can, "0 - 1", "Moisture|9", "B", nil); err != nil {
+			t.Fatalf("AddCanToOven(%s) failed: %v", can, err)
+		}
+	}
+
+	// Truncate in the middle of the last can entry, as a crash mid-write would
+	trackingFile := GetOvenTrackingFilePath()
+	data, err := os.ReadFile(trackingFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cut := bytes.LastIndex(data, []byte(`"103"`))
+	if cut < 0 {
+		t.Fatalf("can 103 not found in tracking file")
+	}
+	if err := os.WriteFile(trackingFile, data[:cut+3], 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tracking, err := LoadOvenTracking()
+	if err != nil {
+		t.Fatalf("LoadOvenTracking failed: %v", err)
+	}
+	if len(tracking.Cans) != 2 || tracking.Cans[0].CanNumber != "101" || tracking.Cans[1].CanNumber != "102" {
+		t.Fatalf("expected cans 101 and 102 recovered, got %+v", tracking.Cans)
+	}
+
+	recovery := TakeOvenTrackingRecovery()
+	if recovery == nil {
+		t.Fatal("expected a recovery notice")
+	}
+	if recovery.RecoveredCans != 2 {
+		t.Errorf("expected 2 recovered cans, got %d", recovery.RecoveredCans)
+	}
+	if _, err := os.Stat(recovery.BackupPath); err != nil {
+		t.Errorf("corrupt file was not backed up: %v", err)
+	}
+	if filepath.Dir(recovery.BackupPath) != root {
+		t.Errorf("backup written outside project root: %s", recovery.BackupPath)
+	}
+	if TakeOvenTrackingRecovery() != nil {
+		t.Error("recovery notice should only be returned once")
+	}
+
+	// The rewritten file is valid again
+	cans, err := GetCansInOven()
+	if err != nil || len(cans) != 2 {
+		t.Errorf("expected 2 cans after recovery, got %d (err %v)", len(cans), err)
+	}
+}
@@ -0,0 +1,112 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"lms-tui/logger"
+)
+
+// ErrWritePending means a change reached the writer's Lab file in memory but saving it to the
+// share failed. The writer holds it in PendingWrites until a save succeeds.
+var ErrWritePending = errors.New("the Lab file could not be saved to the share; the change is held until it is back")
+
+// PendingWrite is a change a MoistureTestWriter made but couldn't save
+type PendingWrite struct {
+	Description string // What was written, e.g. "moisture sample B-1|0 - 1"
+	HeldAt      time.Time
+	Err         string // Why the save failed
+}
+
+// pendingWriters are the open writers holding unsaved changes, so they can all be replayed
+// when the share comes back
+var (
+	pendingWritersMu sync.Mutex
+	pendingWriters   = map[*MoistureTestWriter]bool{}
+)
+
+// saveOrHold saves the Lab file after a write. When the save fails the change stays in the
+// workbook in memory and is recorded in PendingWrites instead of being lost; the returned
+// error wraps ErrWritePending. A successful save also saves everything held before it.
+func (w *MoistureTestWriter) saveOrHold(description string) error {
+	if err := w.file.Save(); err != nil {
+		// Retrying the same change while the share is still down just updates the reason
+		held := slices.IndexFunc(w.PendingWrites, func(p PendingWrite) bool { return p.Description == description })
+		if held >= 0 {
+			w.PendingWrites[held].Err = err.Error()
+		} else {
+			w.PendingWrites = append(w.PendingWrites, PendingWrite{Description: description, HeldAt: time.Now(), Err: err.Error()})
+		}
+		pendingWritersMu.Lock()
+		pendingWriters[w] = true
+		pendingWritersMu.Unlock()
+		logger.Error.Printf("WARNING: Holding %s for job %s until the share is back (%d held): %v",
+			description, w.JobNumber, len(w.PendingWrites), err)
+		return fmt.Errorf("%w: %v", ErrWritePending, err)
+	}
+	w.clearPendingWrites()
+	return nil
+}
+
+// clearPendingWrites forgets held changes once a save has written them
+func (w *MoistureTestWriter) clearPendingWrites() {
+	if len(w.PendingWrites) == 0 {
+		return
+	}
+	logger.Info.Printf("Saved %d held writes for job %s", len(w.PendingWrites), w.JobNumber)
+	w.PendingWrites = nil
+	pendingWritersMu.Lock()
+	delete(pendingWriters, w)
+	pendingWritersMu.Unlock()
+}
+
+// dropPendingWrite forgets a held change that was taken back before it could be saved
+func (w *MoistureTestWriter) dropPendingWrite(description string) {
+	w.PendingWrites = slices.DeleteFunc(w.PendingWrites, func(p PendingWrite) bool { return p.Description == description })
+	if len(w.PendingWrites) == 0 {
+		pendingWritersMu.Lock()
+		delete(pendingWriters, w)
+		pendingWritersMu.Unlock()
+	}
+}
+
+// ReplayPendingWrites tries again to save the changes the writer is holding
+func (w *MoistureTestWriter) ReplayPendingWrites() error {
+	if len(w.PendingWrites) == 0 {
+		return nil
+	}
+	if err := w.file.Save(); err != nil {
+		logger.Error.Printf("Still can't save %d held writes for job %s: %v", len(w.PendingWrites), w.JobNumber, err)
+		return err
+	}
+	w.clearPendingWrites()
+	return nil
+}
+
+// ReplayAllPendingWrites replays the held changes of every open writer, e.g. when the share
+// comes back. It returns how many changes were saved and the first error.
+func ReplayAllPendingWrites() (int, error) {
+	pendingWritersMu.Lock()
+	writers := make([]*MoistureTestWriter, 0, len(pendingWriters))
+	for w := range pendingWriters {
+		writers = append(writers, w)
+	}
+	pendingWritersMu.Unlock()
+
+	saved := 0
+	var firstErr error
+	for _, w := range writers {
+		held := len(w.PendingWrites)
+		if err := w.ReplayPendingWrites(); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("job %s: %v", w.JobNumber, err)
+			}
+			continue
+		}
+		saved += held
+	}
+	return saved, firstErr
+}
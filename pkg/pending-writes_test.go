@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+func TestMoistureWriterHoldsWritesWhenShareDrops(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	srcPath := filepath.Join(root, "projects", "25610", "Lab_25610.xlsx")
+	if err := os.MkdirAll(filepath.Dir(srcPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "Moisture")
+	f.SetCellValue("Moisture", "A9", "Boring No")
+	f.SetCellValue("Moisture", "A10", "Depth")
+	f.SetCellValue("Moisture", "B9", "B-1")
+	f.SetCellValue("Moisture", "B10", "0 - 1")
+	if err := f.SaveAs(srcPath); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	writer, err := InitMoistureTestFile("25610", srcPath)
+	if err != nil {
+		t.Fatalf("InitMoistureTestFile failed: %v", err)
+	}
+	defer writer.Close()
+
+	// The share drops: the job's working folder disappears
+	jobDir := filepath.Dir(WorkingLabFilePath("25610"))
+	if err := os.RemoveAll(jobDir); err != nil {
+		t.Fatal(err)
+	}
+	err = writer.WriteMoistureSample("B-1", "0 - 1", "101", "50", "150")
+	if !errors.Is(err, ErrWritePending) {
+		t.Fatalf("WriteMoistureSample with the share gone = %v, want ErrWritePending", err)
+	}
+	if len(writer.PendingWrites) != 1 {
+		t.Fatalf("PendingWrites = %v, want the moisture sample held", writer.PendingWrites)
+	}
+	if _, err := ReplayAllPendingWrites(); err == nil {
+		t.Error("ReplayAllPendingWrites succeeded with the share still gone")
+	}
+
+	// The share comes back and the held sample is saved
+	if err := os.MkdirAll(jobDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if saved, err := ReplayAllPendingWrites(); err != nil || saved != 1 {
+		t.Fatalf("ReplayAllPendingWrites = %d, %v, want 1 saved", saved, err)
+	}
+	if len(writer.PendingWrites) != 0 {
+		t.Errorf("PendingWrites after replay = %v, want none", writer.PendingWrites)
+	}
+
+	saved, err := excelize.OpenFile(WorkingLabFilePath("25610"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer saved.Close()
+	if canNo, _ := saved.GetCellValue("Moisture", "B11"); canNo != "101" {
+		t.Errorf("Can No. after replay = %q, want 101", canNo)
+	}
+}
@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"lms-tui/logger"
+)
+
+// PrintFile sends a file to the printer with Config.PrintCommand (default lp).
+// lp can't print a spreadsheet, so .xlsx files are first converted to PDF with
+// Config.PrintConverter and the PDF is printed instead.
+func PrintFile(path string) error {
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".xlsx" || ext == ".xlsm" {
+		pdfPath, err := convertToPDF(path)
+		if err != nil {
+			return err
+		}
+		path = pdfPath
+	}
+
+	command := strings.Fields(Config.PrintCommand)
+	if len(command) == 0 {
+		command = []string{"lp"}
+	}
+	if _, err := exec.LookPath(command[0]); err != nil {
+		logger.Error.Printf("Print command '%s' is not available: %v", command[0], err)
+		return fmt.Errorf("'%s' is not available on this machine", command[0])
+	}
+
+	output, err := exec.Command(command[0], append(command[1:], path)...).CombinedOutput()
+	if err != nil {
+		logger.Error.Printf("Failed to print %s: %v (%s)", path, err, strings.TrimSpace(string(output)))
+		return fmt.Errorf("failed to print %s: %v", path, err)
+	}
+
+	logger.Info.Printf("Sent %s to the printer: %s", path, strings.TrimSpace(string(output)))
+	return nil
+}
+
+// convertToPDF runs Config.PrintConverter with the output folder and the spreadsheet
+// appended, the way "soffice --headless --convert-to pdf --outdir" takes them, and
+// returns the PDF written next to the spreadsheet
+func convertToPDF(path string) (string, error) {
+	command := strings.Fields(Config.PrintConverter)
+	if len(command) == 0 {
+		return "", fmt.Errorf("print_converter is not set, so %s can't be turned into something the printer accepts", filepath.Base(path))
+	}
+	if _, err := exec.LookPath(command[0]); err != nil {
+		logger.Error.Printf("Print converter '%s' is not available: %v", command[0], err)
+		return "", fmt.Errorf("'%s' is not available on this machine, so %s can't be printed", command[0], filepath.Base(path))
+	}
+
+	output, err := exec.Command(command[0], append(command[1:], filepath.Dir(path), path)...).CombinedOutput()
+	if err != nil {
+		logger.Error.Printf("Failed to convert %s to PDF: %v (%s)", path, err, strings.TrimSpace(string(output)))
+		return "", fmt.Errorf("failed to convert %s to PDF: %v", filepath.Base(path), err)
+	}
+
+	pdfPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".pdf"
+	logger.Info.Printf("Converted %s to %s", path, pdfPath)
+	return pdfPath, nil
+}
@@ -0,0 +1,51 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrintFileConvertsSpreadsheets(t *testing.T) {
+	root := useTempProjectRoot(t)
+	originalPrint, originalConverter := Config.PrintCommand, Config.PrintConverter
+	t.Cleanup(func() { Config.PrintCommand, Config.PrintConverter = originalPrint, originalConverter })
+
+	// Scripts stand in for soffice (given the output folder and file) and lp (given the file)
+	writeScript := func(name, body string) string {
+		path := filepath.Join(root, name)
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	printed := filepath.Join(root, "printed.txt")
+	Config.PrintConverter = writeScript("convert.sh", `echo pdf > "$1/$(basename "$2" .xlsx).pdf"`)
+	Config.PrintCommand = writeScript("print.sh", `echo "$1" > `+printed)
+
+	sheet := filepath.Join(root, "MoistureExport_25490.xlsx")
+	if err := os.WriteFile(sheet, []byte("xlsx"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := PrintFile(sheet); err != nil {
+		t.Fatalf("PrintFile failed: %v", err)
+	}
+	data, err := os.ReadFile(printed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.TrimSpace(string(data)), filepath.Join(root, "MoistureExport_25490.pdf"); got != want {
+		t.Errorf("printed %q, want the converted %q", got, want)
+	}
+
+	// Without a converter the raw spreadsheet is never sent to the printer
+	os.Remove(printed)
+	Config.PrintConverter = ""
+	if err := PrintFile(sheet); err == nil {
+		t.Error("PrintFile printed a spreadsheet with no print_converter set")
+	}
+	if _, err := os.Stat(printed); err == nil {
+		t.Error("the spreadsheet reached the print command")
+	}
+}
@@ -0,0 +1,180 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	excelize "github.com/xuri/excelize/v2"
+	"lms-tui/logger"
+	"lms-tui/models"
+)
+
+// ProjectMetaFileName is the optional per-job override file in projects/<jobNumber>/
+const ProjectMetaFileName = ".lmsmeta.json"
+
+// ProjectMeta holds per-job overrides for legacy projects that don't follow
+// the Lab_<jobNumber>.xlsm / "Main Form" conventions
+type ProjectMeta struct {
+	LabFile       string `json:"lab_file"`          // Lab filename in the job folder, e.g. "Lab 25313 rev2.xlsm"
+	MainFormSheet string `json:"main_form_sheet"`   // Sheet holding the job header and sample list
+	OnHold        bool   `json:"on_hold,omitempty"` // Paused, e.g. awaiting client info; hidden from the pull list
+	HoldReason    string `json:"hold_reason,omitempty"`
+	HeldAt        string `json:"held_at,omitempty"`
+}
+
+// LoadProjectMeta reads .lmsmeta.json from a job folder.
+// Returns nil (and no error) when the folder has no override file.
+func LoadProjectMeta(projectDir string) (*ProjectMeta, error) {
+	metaPath := filepath.Join(projectDir, ProjectMetaFileName)
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		logger.Error.Printf("Failed to read %s: %v", metaPath, err)
+		return nil, err
+	}
+
+	var meta ProjectMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		logger.Error.Printf("Failed to parse %s: %v", metaPath, err)
+		return nil, fmt.Errorf("invalid %s: %v", metaPath, err)
+	}
+
+	logger.Info.Printf("Loaded project overrides from %s: LabFile=%q, MainFormSheet=%q", metaPath, meta.LabFile, meta.MainFormSheet)
+	return &meta, nil
+}
+
+// SetJobHold puts a job on hold, or releases it when onHold is false, by updating the
+// .lmsmeta.json in its folder under projects/ (job.FolderName). Other overrides in the file are kept.
+func SetJobHold(folderName string, onHold bool, reason string) error {
+	projectDir := filepath.Join(ProjectsDir(), folderName)
+	meta, err := LoadProjectMeta(projectDir)
+	if err != nil {
+		return err
+	}
+	if meta == nil {
+		meta = &ProjectMeta{}
+	}
+
+	meta.OnHold = onHold
+	meta.HoldReason = ""
+	meta.HeldAt = ""
+	if onHold {
+		meta.HoldReason = reason
+		meta.HeldAt = Now()
+	}
+
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %v", ProjectMetaFileName, err)
+	}
+	metaPath := filepath.Join(projectDir, ProjectMetaFileName)
+	if err := writeFileAtomic(metaPath, data, 0644); err != nil {
+		logger.Error.Printf("Failed to write %s: %v", metaPath, err)
+		return err
+	}
+
+	if onHold {
+		logger.Info.Printf("Job %s put on hold: %s", folderName, reason)
+	} else {
+		logger.Info.Printf("Job %s released from hold", folderName)
+	}
+	return nil
+}
+
+// SplitJobNumber splits a versioned job number such as "25490_03" into its base job number
+// and version suffix ("25490", "03"). A number without a numeric suffix is returned whole.
+func SplitJobNumber(jobNumber string) (string, string) {
+	idx := strings.LastIndex(jobNumber, "_")
+	if idx <= 0 || idx == len(jobNumber)-1 {
+		return jobNumber, ""
+	}
+	suffix := jobNumber[idx+1:]
+	if _, err := strconv.Atoi(suffix); err != nil {
+		return jobNumber, ""
+	}
+	return jobNumber[:idx], suffix
+}
+
+// GroupJobVersions keeps every version of a job together, base file first and then in
+// version order, wherever the folders put them. Jobs otherwise keep their order.
+func GroupJobVersions(jobs []models.Job) {
+	firstSeen := map[string]int{}
+	for i, job := range jobs {
+		if _, ok := firstSeen[job.BaseJobNumber]; !ok {
+			firstSeen[job.BaseJobNumber] = i
+		}
+	}
+	version := func(job models.Job) int {
+		_, suffix := SplitJobNumber(job.ProjectNumber)
+		n, _ := strconv.Atoi(suffix) // The base file has no suffix and sorts as 0
+		return n
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if fi, fj := firstSeen[jobs[i].BaseJobNumber], firstSeen[jobs[j].BaseJobNumber]; fi != fj {
+			return fi < fj
+		}
+		return version(jobs[i]) < version(jobs[j])
+	})
+}
+
+// SortJobsHeldLast moves jobs on hold to the bottom of the list, keeping the order otherwise
+func SortJobsHeldLast(jobs []models.Job) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return !jobs[i].OnHold && jobs[j].OnHold
+	})
+}
+
+// labFileOverride returns the full path of the Lab file named in the job folder's
+// .lmsmeta.json, or "" when there is no override
+func labFileOverride(projectDir string) (string, error) {
+	meta, err := LoadProjectMeta(projectDir)
+	if err != nil || meta == nil || meta.LabFile == "" {
+		return "", err
+	}
+
+	labPath := filepath.Join(projectDir, meta.LabFile)
+	if _, err := os.Stat(labPath); err != nil {
+		logger.Error.Printf("Lab file override %s not found: %v", labPath, err)
+		return "", fmt.Errorf("lab file '%s' from %s not found", meta.LabFile, ProjectMetaFileName)
+	}
+	return labPath, nil
+}
+
+// mainFormSheetOverride returns the Main Form sheet named in the job folder's .lmsmeta.json
+// if the workbook has it, or "" when there is no usable override
+func mainFormSheetOverride(f *excelize.File, labFilePath string) string {
+	meta, err := LoadProjectMeta(filepath.Dir(labFilePath))
+	if err != nil || meta == nil || meta.MainFormSheet == "" {
+		return ""
+	}
+
+	for _, name := range f.GetSheetList() {
+		if name == meta.MainFormSheet {
+			return name
+		}
+	}
+	logger.Error.Printf("Main Form override sheet '%s' not found in %s", meta.MainFormSheet, labFilePath)
+	return ""
+}
+
+// findMainFormSheet returns the Main Form sheet of a Lab workbook: the .lmsmeta.json
+// override if present, otherwise "Main Form" or "!Main Form". Returns "" if none is found.
+func findMainFormSheet(f *excelize.File, labFilePath string) string {
+	if sheetName := mainFormSheetOverride(f, labFilePath); sheetName != "" {
+		return sheetName
+	}
+
+	for _, name := range f.GetSheetList() {
+		if name == "Main Form" || name == "!Main Form" {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lms-tui/models"
+
+	excelize "github.com/xuri/excelize/v2"
+)
+
+func TestSetJobHold(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	for _, jobNumber := range []string{"25001", "25002"} {
+		labPath := filepath.Join(root, "projects", jobNumber, "Lab_"+jobNumber+".xlsm")
+		if err := os.MkdirAll(filepath.Dir(labPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		f := excelize.NewFile()
+		if err := f.SaveAs(labPath); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+
+	if err := SetJobHold("25001", true, "awaiting client info"); err != nil {
+		t.Fatalf("SetJobHold failed: %v", err)
+	}
+
+	jobs, err := DiscoverJobs()
+	if err != nil || len(jobs) != 2 {
+		t.Fatalf("DiscoverJobs = %d jobs, %v; want 2", len(jobs), err)
+	}
+	if jobs[0].ProjectNumber != "25002" || jobs[0].OnHold {
+		t.Errorf("first job = %s (on hold %v), want 25002 not on hold", jobs[0].ProjectNumber, jobs[0].OnHold)
+	}
+	if jobs[1].ProjectNumber != "25001" || !jobs[1].OnHold || jobs[1].HoldReason != "awaiting client info" {
+		t.Errorf("last job = %+v, want 25001 on hold with its reason", jobs[1])
+	}
+
+	if err := SetJobHold("25001", false, ""); err != nil {
+		t.Fatalf("releasing hold failed: %v", err)
+	}
+	meta, err := LoadProjectMeta(filepath.Join(root, "projects", "25001"))
+	if err != nil || meta == nil {
+		t.Fatalf("LoadProjectMeta = %v, %v", meta, err)
+	}
+	if meta.OnHold || meta.HoldReason != "" || meta.HeldAt != "" {
+		t.Errorf("meta after release = %+v, want the hold cleared", meta)
+	}
+}
+
+func TestSplitJobNumber(t *testing.T) {
+	tests := []struct {
+		jobNumber, base, suffix string
+	}{
+		{"25490", "25490", ""},
+		{"25490_02", "25490", "02"},
+		{"25490_A", "25490_A", ""},
+		{"25490_", "25490_", ""},
+		{"2025_25490_03", "2025_25490", "03"},
+	}
+	for _, tt := range tests {
+		base, suffix := SplitJobNumber(tt.jobNumber)
+		if base != tt.base || suffix != tt.suffix {
+			t.Errorf("SplitJobNumber(%q) = %q, %q, want %q, %q", tt.jobNumber, base, suffix, tt.base, tt.suffix)
+		}
+	}
+}
+
+func TestGroupJobVersions(t *testing.T) {
+	var jobs []models.Job
+	for _, number := range []string{"25490_03", "25488", "25490", "25491", "25490_02"} {
+		base, _ := SplitJobNumber(number)
+		jobs = append(jobs, models.Job{ProjectNumber: number, BaseJobNumber: base})
+	}
+
+	GroupJobVersions(jobs)
+
+	var got []string
+	for _, job := range jobs {
+		got = append(got, job.ProjectNumber)
+	}
+	want := "25490,25490_02,25490_03,25488,25491"
+	if strings.Join(got, ",") != want {
+		t.Errorf("GroupJobVersions order = %v, want %s", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"lms-tui/logger"
+)
+
+// pullSessions tracks jobs currently open for pulling in this process, so two
+// MoistureTestWriters are never open over the same Lab file
+var (
+	pullSessionsMu      sync.Mutex
+	pullSessions        = map[string]time.Time{}
+	pullSessionRecovery = map[string]func(){}
+	pullSessionUnsaved  = map[string]func() bool{}
+	pullSessionLocks    = map[string]func(){}
+)
+
+// pullSessionLockPath is the file an open pull session holds locked, so a session on another
+// workstation or a batch rebuild can tell the job's working Lab file is open
+func pullSessionLockPath(jobNumber string) string {
+	return filepath.Join(ProjectRoot, "ex_project", jobNumber, "pull-session")
+}
+
+// ClaimPullSession registers a job as open for pulling.
+// Returns an error if the job already has an open pull session.
+func ClaimPullSession(jobNumber string) error {
+	pullSessionsMu.Lock()
+	defer pullSessionsMu.Unlock()
+
+	if openedAt, exists := pullSessions[jobNumber]; exists {
+		logger.Error.Printf("Refused second pull session for job %s (open since %s)", jobNumber, openedAt.Format("3:04 PM"))
+		return fmt.Errorf("job %s is already open for pulling (since %s)", jobNumber, openedAt.Format("3:04 PM"))
+	}
+
+	release, err := tryFileLock(pullSessionLockPath(jobNumber))
+	if errors.Is(err, ErrFileLocked) {
+		logger.Error.Printf("Refused pull session for job %s: it is open on another workstation", jobNumber)
+		return fmt.Errorf("job %s is already open for pulling on another workstation", jobNumber)
+	}
+	if err != nil {
+		// The session still works; only other workstations and rebuilds can't see it
+		logger.Error.Printf("WARNING: Could not lock job %s for its pull session: %v", jobNumber, err)
+	} else {
+		pullSessionLocks[jobNumber] = release
+	}
+
+	pullSessions[jobNumber] = time.Now()
+	logger.Info.Printf("Opened pull session for job %s", jobNumber)
+	return nil
+}
+
+// ReleasePullSession removes a job's pull session registration
+func ReleasePullSession(jobNumber string) {
+	pullSessionsMu.Lock()
+	defer pullSessionsMu.Unlock()
+
+	delete(pullSessionRecovery, jobNumber)
+	delete(pullSessionUnsaved, jobNumber)
+	if release, ok := pullSessionLocks[jobNumber]; ok {
+		release()
+		delete(pullSessionLocks, jobNumber)
+	}
+	if _, exists := pullSessions[jobNumber]; exists {
+		delete(pullSessions, jobNumber)
+		logger.Info.Printf("Released pull session for job %s", jobNumber)
+	}
+}
+
+// SetPullSessionRecovery registers what to run for an open pull session if the app crashes,
+// e.g. saving progress and closing the Lab file. It is dropped when the session is released.
+func SetPullSessionRecovery(jobNumber string, recover func()) {
+	pullSessionsMu.Lock()
+	defer pullSessionsMu.Unlock()
+
+	pullSessionRecovery[jobNumber] = recover
+}
+
+// SetPullSessionUnsavedCheck registers how to tell whether an open pull session has typed
+// input that hasn't been saved yet, so quitting can warn before it is lost
+func SetPullSessionUnsavedCheck(jobNumber string, unsaved func() bool) {
+	pullSessionsMu.Lock()
+	defer pullSessionsMu.Unlock()
+
+	pullSessionUnsaved[jobNumber] = unsaved
+}
+
+// UnsavedPullSessions returns the open pull sessions that have unsaved typed input
+func UnsavedPullSessions() []string {
+	pullSessionsMu.Lock()
+	defer pullSessionsMu.Unlock()
+
+	var jobs []string
+	for jobNumber := range pullSessions {
+		if unsaved, ok := pullSessionUnsaved[jobNumber]; ok && unsaved() {
+			jobs = append(jobs, jobNumber)
+		}
+	}
+	sort.Strings(jobs)
+	return jobs
+}
+
+// RecoverPullSessions runs the crash recovery of every open pull session and returns the
+// jobs that were open. A recovery that panics too is logged and the rest still run.
+func RecoverPullSessions() []string {
+	return closePullSessions("crash recovery")
+}
+
+// ShutdownPullSessions saves and closes every open pull session when the app quits normally,
+// using the same steps as crash recovery, and returns the jobs that were open
+func ShutdownPullSessions() []string {
+	return closePullSessions("shutdown")
+}
+
+// closePullSessions runs each open session's recovery, logging what it was run for
+func closePullSessions(reason string) []string {
+	pullSessionsMu.Lock()
+	defer pullSessionsMu.Unlock()
+
+	var jobs []string
+	for jobNumber := range pullSessions {
+		jobs = append(jobs, jobNumber)
+		recoverFn, ok := pullSessionRecovery[jobNumber]
+		if !ok {
+			continue
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Error.Printf("Failed to run %s for job %s: %v", reason, jobNumber, r)
+				}
+			}()
+			recoverFn()
+			logger.Info.Printf("Ran %s for job %s", reason, jobNumber)
+		}()
+	}
+	sort.Strings(jobs)
+	return jobs
+}
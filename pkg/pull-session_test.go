@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecoverPullSessions(t *testing.T) {
+	useTempProjectRoot(t)
+
+	for _, jobNumber := range []string{"25001", "25002"} {
+		if err := ClaimPullSession(jobNumber); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { ReleasePullSession(jobNumber) })
+	}
+
+	saved := false
+	SetPullSessionRecovery("25001", func() { panic("writer already closed") })
+	SetPullSessionRecovery("25002", func() { saved = true })
+
+	jobs := RecoverPullSessions()
+	if strings.Join(jobs, ",") != "25001,25002" {
+		t.Errorf("RecoverPullSessions = %v, want both open jobs", jobs)
+	}
+	if !saved {
+		t.Error("recovery for 25002 did not run after 25001's recovery panicked")
+	}
+
+	// Released sessions are not recovered
+	ReleasePullSession("25002")
+	saved = false
+	RecoverPullSessions()
+	if saved {
+		t.Error("recovery ran for a released session")
+	}
+}
+
+func TestUnsavedPullSessions(t *testing.T) {
+	useTempProjectRoot(t)
+
+	for _, jobNumber := range []string{"25011", "25012", "25013"} {
+		if err := ClaimPullSession(jobNumber); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { ReleasePullSession(jobNumber) })
+	}
+
+	typing := true
+	SetPullSessionUnsavedCheck("25011", func() bool { return typing })
+	SetPullSessionUnsavedCheck("25012", func() bool { return false })
+
+	if jobs := UnsavedPullSessions(); strings.Join(jobs, ",") != "25011" {
+		t.Errorf("UnsavedPullSessions = %v, want [25011]", jobs)
+	}
+	typing = false
+	if jobs := UnsavedPullSessions(); len(jobs) != 0 {
+		t.Errorf("UnsavedPullSessions after saving = %v, want none", jobs)
+	}
+
+	closed := false
+	SetPullSessionRecovery("25012", func() { closed = true })
+	if jobs := ShutdownPullSessions(); strings.Join(jobs, ",") != "25011,25012,25013" {
+		t.Errorf("ShutdownPullSessions = %v, want all open jobs", jobs)
+	}
+	if !closed {
+		t.Error("shutdown did not run the session's recovery")
+	}
+}
@@ -0,0 +1,73 @@
+package pkg
+
+import (
+	"sync"
+	"time"
+
+	"lms-tui/logger"
+)
+
+// ShareStatus is the result of checking that ProjectRoot (the network share) is reachable
+type ShareStatus struct {
+	Reachable bool
+	Detail    string // ProjectRoot when reachable, otherwise the error
+	CheckedAt time.Time
+}
+
+// CheckShare runs the diagnostics check that ProjectRoot exists and can be written to
+func CheckShare() ShareStatus {
+	result := checkProjectRoot()
+	return ShareStatus{Reachable: result.Passed, Detail: result.Detail, CheckedAt: time.Now()}
+}
+
+// shareStopWait is how long WatchShare's stop waits for a check in progress. A check on a
+// dropped hard-mounted share can hang, and quitting shouldn't hang with it.
+const shareStopWait = time.Second
+
+// WatchShare checks the share every interval in the background, calling onChange with the
+// first result and again whenever the share drops or comes back. The returned stop func
+// waits up to shareStopWait for a check in progress; onChange isn't called after stop.
+func WatchShare(interval time.Duration, onChange func(ShareStatus)) (stop func()) {
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last *ShareStatus
+		for {
+			status := CheckShare()
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if last == nil || status.Reachable != last.Reachable {
+				if status.Reachable {
+					logger.Info.Printf("Share reachable: %s", status.Detail)
+				} else {
+					logger.Error.Printf("WARNING: Share unreachable: %s", status.Detail)
+				}
+				onChange(status)
+			}
+			last = &status
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		select {
+		case <-finished:
+		case <-time.After(shareStopWait):
+			logger.Error.Printf("WARNING: Share check still running after %v, not waiting for it", shareStopWait)
+		}
+	}
+}
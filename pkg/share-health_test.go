@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWatchShare(t *testing.T) {
+	root := useTempProjectRoot(t)
+
+	if status := CheckShare(); !status.Reachable {
+		t.Fatalf("CheckShare on a writable root = %+v, want reachable", status)
+	}
+
+	changes := make(chan ShareStatus, 10)
+	stop := WatchShare(10*time.Millisecond, func(status ShareStatus) { changes <- status })
+	defer stop()
+
+	next := func() ShareStatus {
+		select {
+		case status := <-changes:
+			return status
+		case <-time.After(2 * time.Second):
+			t.Fatal("WatchShare reported no change")
+			return ShareStatus{}
+		}
+	}
+	if status := next(); !status.Reachable {
+		t.Errorf("first status = %+v, want reachable", status)
+	}
+
+	// The share drops: ProjectRoot disappears from under the app
+	if err := os.RemoveAll(root); err != nil {
+		t.Fatal(err)
+	}
+	if status := next(); status.Reachable {
+		t.Errorf("status after the root was removed = %+v, want unreachable", status)
+	}
+
+	if err := os.MkdirAll(root, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if status := next(); !status.Reachable {
+		t.Errorf("status after the root came back = %+v, want reachable", status)
+	}
+}
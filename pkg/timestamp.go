@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"time"
+
+	"lms-tui/logger"
+)
+
+// TimestampFormat is used for every audit timestamp; the offset makes entries
+// from different lab locations unambiguous
+const TimestampFormat = "2006-01-02 15:04:05 -07:00"
+
+// legacyTimestampFormat is what files written before the zone was added contain
+const legacyTimestampFormat = "2006-01-02 15:04:05"
+
+// Location returns the timezone from Config.Timezone, or local time if unset or invalid
+func Location() *time.Location {
+	if Config.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(Config.Timezone)
+	if err != nil {
+		logger.Error.Printf("Invalid timezone '%s' in config, using local time: %v", Config.Timezone, err)
+		return time.Local
+	}
+	return loc
+}
+
+// Now returns the current time formatted as an audit timestamp in the configured timezone
+func Now() string {
+	return time.Now().In(Location()).Format(TimestampFormat)
+}
+
+// ParseTimestamp parses a timestamp written by Now. Older timestamps without a zone
+// are read in the configured timezone.
+func ParseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse(TimestampFormat, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation(legacyTimestampFormat, value, Location())
+}
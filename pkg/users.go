@@ -0,0 +1,173 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"lms-tui/logger"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Roles a user can have
+const (
+	RoleTech    = "tech"
+	RoleManager = "manager"
+)
+
+// User is a lab user who can log in. PINs are stored only as bcrypt hashes.
+type User struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Role    string `json:"role"`
+	PINHash string `json:"pin_hash"`
+}
+
+// IsManager reports whether the user is a lab manager. A nil user has no roles.
+func (u *User) IsManager() bool {
+	return u != nil && u.Role == RoleManager
+}
+
+// AuditID returns the ID to record as having entered data, "" when nobody is logged in
+func (u *User) AuditID() string {
+	if u == nil {
+		return ""
+	}
+	return u.ID
+}
+
+// usersFile is the list of users stored in users.json
+type usersFile struct {
+	Users []User `json:"users"`
+}
+
+// defaultUserID and defaultPIN are the original built-in login. They seed users.json the
+// first time it is needed so nobody is locked out; the PIN should be changed right away.
+const (
+	defaultUserID = "1234"
+	defaultPIN    = "0000"
+)
+
+// GetUsersFilePath returns the path to users.json, shared by every workstation
+func GetUsersFilePath() string {
+	return filepath.Join(ProjectRoot, "users.json")
+}
+
+// loadUsers reads users.json, seeding it with the default manager if it does not exist
+func loadUsers() (*usersFile, error) {
+	filePath := GetUsersFilePath()
+
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		hash, err := bcrypt.GenerateFromPassword([]byte(defaultPIN), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		users := &usersFile{Users: []User{{ID: defaultUserID, Name: "Lab Manager", Role: RoleManager, PINHash: string(hash)}}}
+		if err := saveUsers(users); err != nil {
+			return nil, err
+		}
+		logger.Info.Printf("Created %s with the default user %s", filePath, defaultUserID)
+		return users, nil
+	}
+	if err != nil {
+		logger.Error.Printf("Failed to read users file: %v", err)
+		return nil, err
+	}
+
+	var users usersFile
+	if err := json.Unmarshal(data, &users); err != nil {
+		logger.Error.Printf("Failed to parse users file: %v", err)
+		return nil, fmt.Errorf("users file corrupted or invalid JSON format: %v", err)
+	}
+	return &users, nil
+}
+
+// saveUsers writes users.json atomically
+func saveUsers(users *usersFile) error {
+	jsonData, err := json.MarshalIndent(users, "", "  ")
+	if err != nil {
+		logger.Error.Printf("Failed to marshal users: %v", err)
+		return err
+	}
+	if err := writeFileAtomic(GetUsersFilePath(), jsonData, 0600); err != nil {
+		logger.Error.Printf("Failed to write users file: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Authenticate checks a user's PIN and returns the user on success
+func Authenticate(userID, pin string) (*User, error) {
+	users, err := loadUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users.Users {
+		if user.ID != userID {
+			continue
+		}
+		if bcrypt.CompareHashAndPassword([]byte(user.PINHash), []byte(pin)) != nil {
+			break
+		}
+		found := user
+		return &found, nil
+	}
+	// Same error for unknown users and wrong PINs
+	return nil, fmt.Errorf("invalid user ID or PIN")
+}
+
+// MinPINLength returns the configured minimum PIN length
+func MinPINLength() int {
+	if Config.MinPINLength <= 0 {
+		return defaultConfig.MinPINLength
+	}
+	return Config.MinPINLength
+}
+
+// ChangePIN replaces a user's PIN after verifying the current one
+func ChangePIN(userID, oldPIN, newPIN string) error {
+	minLength := MinPINLength()
+	if len(newPIN) < minLength {
+		return fmt.Errorf("new PIN must be at least %d digits", minLength)
+	}
+	if strings.Trim(newPIN, "0123456789") != "" {
+		return fmt.Errorf("new PIN must contain only digits")
+	}
+	if newPIN == oldPIN {
+		return fmt.Errorf("new PIN must be different from the current PIN")
+	}
+
+	return withFileLock(GetUsersFilePath(), func() error {
+		users, err := loadUsers()
+		if err != nil {
+			return err
+		}
+
+		for i := range users.Users {
+			if users.Users[i].ID != userID {
+				continue
+			}
+			if bcrypt.CompareHashAndPassword([]byte(users.Users[i].PINHash), []byte(oldPIN)) != nil {
+				logger.Info.Printf("PIN change for user %s rejected: current PIN did not match", userID)
+				return fmt.Errorf("current PIN is incorrect")
+			}
+
+			hash, err := bcrypt.GenerateFromPassword([]byte(newPIN), bcrypt.DefaultCost)
+			if err != nil {
+				return err
+			}
+			users.Users[i].PINHash = string(hash)
+			if err := saveUsers(users); err != nil {
+				return err
+			}
+			logger.Info.Printf("PIN changed for user %s", userID)
+			return nil
+		}
+		return fmt.Errorf("user %s not found", userID)
+	})
+}
@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestChangePIN(t *testing.T) {
+	useTempProjectRoot(t)
+
+	// First use seeds users.json with the default login
+	if _, err := Authenticate(defaultUserID, defaultPIN); err != nil {
+		t.Fatalf("default login failed: %v", err)
+	}
+
+	if err := ChangePIN(defaultUserID, "9999", "4321"); err == nil {
+		t.Error("expected an error for a wrong current PIN")
+	}
+	if err := ChangePIN(defaultUserID, defaultPIN, "12"); err == nil {
+		t.Error("expected an error for a short PIN")
+	}
+	if err := ChangePIN(defaultUserID, defaultPIN, defaultPIN); err == nil {
+		t.Error("expected an error for an unchanged PIN")
+	}
+	if err := ChangePIN(defaultUserID, defaultPIN, "4321"); err != nil {
+		t.Fatalf("ChangePIN: %v", err)
+	}
+
+	if _, err := Authenticate(defaultUserID, defaultPIN); err == nil {
+		t.Error("old PIN still works after change")
+	}
+	if _, err := Authenticate(defaultUserID, "4321"); err != nil {
+		t.Errorf("new PIN rejected: %v", err)
+	}
+
+	data, err := os.ReadFile(GetUsersFilePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "4321") {
+		t.Error("users.json contains the plain-text PIN")
+	}
+}
@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ValidateCanNumber checks a numeric can number against the configured allowed range.
+// The check only applies when both CanNumberMin and CanNumberMax are set and the
+// can number parses as an integer, so alphanumeric can labels are always accepted.
+func ValidateCanNumber(canNumber string) error {
+	if Config.CanNumberMin <= 0 || Config.CanNumberMax <= 0 {
+		return nil
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(canNumber))
+	if err != nil {
+		return nil
+	}
+
+	if value < Config.CanNumberMin || value > Config.CanNumberMax {
+		return fmt.Errorf("can # %s is outside the allowed range %d-%d", canNumber, Config.CanNumberMin, Config.CanNumberMax)
+	}
+	return nil
+}
+
+// NormalizeWeight rewrites a typed weight so strconv.ParseFloat accepts it. Spaces and a
+// trailing unit ("g", "gr", "grams") are dropped ("123 g" -> "123"). Some numpads send the
+// locale's decimal comma, so with AcceptDecimalComma on a lone comma becomes the decimal
+// point ("123,45" -> "123.45"). When the weight also has a dot, or several commas, the commas
+// are grouping and are dropped ("1,234.5" -> "1234.5"). Anything else non-numeric is left
+// for ParseFloat to reject.
+func NormalizeWeight(weight string) string {
+	weight = strings.Join(strings.Fields(weight), "")
+	weight = trimGramsSuffix(weight)
+	if !Config.AcceptDecimalComma || !strings.Contains(weight, ",") {
+		return weight
+	}
+	if strings.Contains(weight, ".") || strings.Count(weight, ",") > 1 {
+		return strings.ReplaceAll(weight, ",", "")
+	}
+	return strings.Replace(weight, ",", ".", 1)
+}
+
+// trimGramsSuffix removes a trailing "g", "gr", "gram" or "grams" in any case. Partial words
+// are accepted too so the unit can be typed a letter at a time into a float-only field.
+func trimGramsSuffix(weight string) string {
+	letters := len(weight) - len(strings.TrimRightFunc(weight, unicode.IsLetter))
+	if letters == 0 || letters == len(weight) {
+		return weight
+	}
+	if strings.HasPrefix("grams", strings.ToLower(weight[len(weight)-letters:])) {
+		return weight[:len(weight)-letters]
+	}
+	return weight
+}
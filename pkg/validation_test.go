@@ -0,0 +1,48 @@
+package pkg
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestNormalizeWeight(t *testing.T) {
+	original := Config.AcceptDecimalComma
+	t.Cleanup(func() { Config.AcceptDecimalComma = original })
+
+	Config.AcceptDecimalComma = true
+	tests := map[string]string{
+		"123,45":    "123.45",
+		"1,234.5":   "1234.5",
+		"123.45":    "123.45",
+		" 123,45 ":  "123.45",
+		"1,234,567": "1234567",
+		"123 g":     "123",
+		"123g":      "123",
+		"123.5G":    "123.5",
+		"123 grams": "123",
+		"1 23 g":    "123",
+		"12,5 g":    "12.5",
+	}
+	for input, want := range tests {
+		got := NormalizeWeight(input)
+		if got != want {
+			t.Errorf("NormalizeWeight(%q) = %q, want %q", input, got, want)
+		}
+		if _, err := strconv.ParseFloat(got, 64); err != nil {
+			t.Errorf("NormalizeWeight(%q) = %q does not parse: %v", input, got, err)
+		}
+	}
+
+	// Genuinely non-numeric input still fails to parse
+	for _, input := range []string{"abc", "12kg", "g", "12 x"} {
+		if _, err := strconv.ParseFloat(NormalizeWeight(input), 64); err == nil {
+			t.Errorf("NormalizeWeight(%q) = %q parsed, want it rejected", input, NormalizeWeight(input))
+		}
+	}
+
+	// With the flag off commas are left alone
+	Config.AcceptDecimalComma = false
+	if got := NormalizeWeight("123,45"); got != "123,45" {
+		t.Errorf("NormalizeWeight with flag off = %q, want it unchanged", got)
+	}
+}
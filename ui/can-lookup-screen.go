@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// NewCanLookupScreen finds which job and sample a can number belongs to across all jobs
+func NewCanLookupScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.InputField) {
+	SetScreenShortcuts("Find Can", []Shortcut{
+		{"Enter", "Search for the can number"},
+		{"Up/Down", "Navigate results"},
+		{"/", "Search for another can"},
+		{"+", "Back to LMS"},
+	})
+
+	logger.Info.Println("Opening Find Can screen")
+
+	canField := tview.NewInputField().
+		SetLabel("Can #: ").
+		SetFieldWidth(20).
+		SetFieldBackgroundColor(tcell.ColorBlack).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetLabelColor(tcell.ColorWhite)
+
+	table := tview.NewTable().
+		SetBorders(true).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	summaryText := tview.NewTextView().
+		SetText("Type a can number and press Enter").
+		SetTextAlign(tview.AlignCenter).
+		SetDynamicColors(true)
+
+	search := func() {
+		canNumber := strings.TrimSpace(canField.GetText())
+		if canNumber == "" {
+			return
+		}
+		locations := pkg.FindCan(canNumber)
+
+		table.Clear()
+		headers := []string{"Job", "Boring", "Depth", "Status", "Saved"}
+		for col, header := range headers {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorWhite).
+				SetAlign(tview.AlignCenter).
+				SetSelectable(false).
+				SetAttributes(tcell.AttrBold))
+		}
+		for row, location := range locations {
+			statusColor := tcell.ColorWhite
+			if location.InOven {
+				statusColor = tcell.ColorYellow
+			} else if location.DryWeight != "" {
+				statusColor = tcell.ColorGreen
+			}
+			table.SetCell(row+1, 0, tview.NewTableCell(location.JobNumber).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(tcell.ColorWhite))
+			table.SetCell(row+1, 1, tview.NewTableCell(location.BoringNumber).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(tcell.ColorWhite))
+			table.SetCell(row+1, 2, tview.NewTableCell(location.Depth).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(tcell.ColorWhite))
+			table.SetCell(row+1, 3, tview.NewTableCell(location.Status()).
+				SetTextColor(statusColor).
+				SetExpansion(1))
+			table.SetCell(row+1, 4, tview.NewTableCell(location.Timestamp).
+				SetTextColor(tcell.ColorGray))
+		}
+
+		switch len(locations) {
+		case 0:
+			summaryText.SetText(fmt.Sprintf("[red]Can #%s was not found in any job or the oven[-]", tview.Escape(canNumber)))
+		case 1:
+			summaryText.SetText(fmt.Sprintf("[green]Can #%s belongs to job %s[-]", tview.Escape(canNumber), locations[0].JobNumber))
+		default:
+			summaryText.SetText(fmt.Sprintf("[yellow]Can #%s turns up %d times (newest first)[-]", tview.Escape(canNumber), len(locations)))
+		}
+
+		if len(locations) > 0 {
+			table.Select(1, 0)
+			app.SetFocus(table)
+		}
+	}
+
+	canField.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			search()
+		}
+	})
+
+	// Instructions text
+	instructions := tview.NewTextView().
+		SetText("Enter: Search  |  Up/Down: Navigate  |  /: New Search  |  +: Back to LMS").
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorWhite)
+
+	// Container
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(canField, 1, 0, true).
+		AddItem(summaryText, 1, 0, false).
+		AddItem(table, 0, 1, false).
+		AddItem(instructions, 1, 0, false)
+
+	container.SetBorder(true).
+		SetTitle(userTitle("Find Can", user)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite)
+
+	// Center it
+	vertical := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(container, 0, 4, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(vertical, 0, 3, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '/' && app.GetFocus() == table {
+			canField.SetText("")
+			app.SetFocus(canField)
+			return nil
+		}
+		if event.Rune() == '+' {
+			onBack()
+			return nil
+		}
+		return event
+	})
+
+	return horizontal, canField
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/pkg"
+)
+
+// NewChangePINScreen lets the logged-in user replace their own PIN
+func NewChangePINScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.Form) {
+	SetScreenShortcuts("Change PIN", []Shortcut{
+		{"Enter", "Next field / save"},
+		{"Esc", "Back to Home"},
+	})
+
+	digitsOnly := func(textToCheck string, lastChar rune) bool {
+		return lastChar >= '0' && lastChar <= '9'
+	}
+
+	form := tview.NewForm().
+		AddPasswordField("Current PIN", "", 20, '*', nil).
+		AddPasswordField("New PIN", "", 20, '*', nil).
+		AddPasswordField("Confirm PIN", "", 20, '*', nil)
+	for i := 0; i < form.GetFormItemCount(); i++ {
+		if field, ok := form.GetFormItem(i).(*tview.InputField); ok {
+			field.SetAcceptanceFunc(digitsOnly)
+		}
+	}
+
+	var horizontal *tview.Flex
+
+	getPIN := func(label string) string {
+		pin, _ := formText(form, label)
+		return pin
+	}
+	clearPINs := func() {
+		for i := 0; i < form.GetFormItemCount(); i++ {
+			if field, ok := form.GetFormItem(i).(*tview.InputField); ok {
+				field.SetText("")
+			}
+		}
+		form.SetFocus(0)
+	}
+
+	form.AddButton("Save", func() {
+		newPIN := getPIN("New PIN")
+		if newPIN != getPIN("Confirm PIN") {
+			clearPINs()
+			ShowError(app, fmt.Errorf("new PIN and confirmation do not match"), horizontal, form)
+			return
+		}
+		if err := pkg.ChangePIN(user.ID, getPIN("Current PIN"), newPIN); err != nil {
+			clearPINs()
+			ShowError(app, err, horizontal, form)
+			return
+		}
+
+		app.SetRoot(newAlertModal("PIN changed.\n\nUse the new PIN next time you log in.", onBack), true)
+	})
+	form.AddButton("Cancel", onBack)
+
+	form.SetFieldBackgroundColor(tcell.ColorBlack).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetButtonBackgroundColor(tcell.ColorWhite).
+		SetButtonTextColor(tcell.ColorBlack).
+		SetLabelColor(tcell.ColorWhite).
+		SetBackgroundColor(tcell.ColorBlack)
+
+	form.SetBorder(true).
+		SetTitle(userTitle(fmt.Sprintf("Change PIN - %s", user.Name), user)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite)
+
+	form.SetCancelFunc(onBack)
+
+	instructions := tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("PIN must be at least %d digits", pkg.MinPINLength())).
+		SetTextColor(tcell.ColorWhite)
+
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(instructions, 1, 0, false)
+
+	vertical := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(container, 12, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(vertical, 50, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	return horizontal, form
+}
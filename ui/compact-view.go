@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// compactViewWidth is the terminal width below which the sample tables open in compact view.
+// The full edit tables wrap badly on the small panel PCs at some lab stations.
+const compactViewWidth = 100
+
+// lastScreenWidth is the terminal width at the last draw, 0 before the first
+var lastScreenWidth int
+
+// TrackScreenWidth records the terminal width so screens can pick a layout when they open.
+// It is called from the application's after-draw function.
+func TrackScreenWidth(screen tcell.Screen) {
+	lastScreenWidth, _ = screen.Size()
+}
+
+// startCompact reports whether a sample table should open in compact view
+func startCompact() bool {
+	return lastScreenWidth > 0 && lastScreenWidth < compactViewWidth
+}
+
+// newCompactDetail returns the pane that shows the selected row beside a compact table
+func newCompactDetail() *tview.TextView {
+	detail := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	detail.SetBorder(true).
+		SetTitle(" Sample ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetBackgroundColor(tcell.ColorBlack)
+	return detail
+}
+
+// compactDetailText lays out a full table row as "Header: value" lines for the detail pane
+func compactDetailText(headers []string, cells []*tview.TableCell) string {
+	var b strings.Builder
+	for i, header := range headers {
+		if i >= len(cells) {
+			break
+		}
+		fmt.Fprintf(&b, "[yellow]%s:[-] %s\n", header, tview.Escape(cells[i].Text))
+	}
+	return b.String()
+}
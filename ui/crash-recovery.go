@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// RecoverFromCrash is deferred in main so a panic anywhere in the UI - including
+// QueueUpdateDraw callbacks, which run on the same event loop as app.Run - is logged
+// and open pull sessions are saved, instead of a raw stack trace over the terminal.
+// tview has already restored the terminal by the time the panic reaches here.
+func RecoverFromCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	logger.Error.Printf("PANIC on screen %q: %v\n%s", helpScreenName, r, debug.Stack())
+
+	jobs := pkg.RecoverPullSessions()
+	if len(jobs) > 0 {
+		logger.Error.Printf("Crash recovery saved progress for jobs: %s", strings.Join(jobs, ", "))
+	}
+
+	fmt.Fprintln(os.Stderr, "\nLMS hit an unexpected error and had to close.")
+	if len(jobs) > 0 {
+		fmt.Fprintf(os.Stderr, "Progress for job %s was saved - pick it up from Pull Job.\n", strings.Join(jobs, ", "))
+	}
+	logPath := logger.FilePath
+	if logPath == "" {
+		logPath = "the log file"
+	}
+	fmt.Fprintf(os.Stderr, "The details were written to %s. Please restart the app and let the lab manager know.\n", logPath)
+	os.Exit(1)
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// NewDailyActivityScreen shows the end-of-day summary of samples entered today across all jobs
+func NewDailyActivityScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.Table) {
+	SetScreenShortcuts("Today's Work", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"+", "Back to LMS"},
+	})
+
+	logger.Info.Println("Opening Today's Work report")
+
+	table := tview.NewTable().
+		SetBorders(true).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	// Set headers
+	headers := []string{"Job #", "Samples", "First Entry", "Last Entry", "Entered By", "Recheck"}
+	for col, header := range headers {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorWhite).
+			SetAlign(tview.AlignCenter).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	activity, err := pkg.CollectDailyActivity(time.Now())
+	if err != nil {
+		logger.Error.Printf("Failed to collect daily activity: %v", err)
+		activity = &pkg.DailyActivity{Date: time.Now()}
+	}
+
+	// Populate table
+	if len(activity.Jobs) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No samples entered today").
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignCenter))
+	} else {
+		for row, job := range activity.Jobs {
+			table.SetCell(row+1, 0, tview.NewTableCell(job.JobNumber).
+				SetAlign(tview.AlignCenter).
+				SetExpansion(1))
+			table.SetCell(row+1, 1, tview.NewTableCell(fmt.Sprintf("%d", job.SampleCount)).
+				SetAlign(tview.AlignCenter))
+			table.SetCell(row+1, 2, tview.NewTableCell(job.FirstEntry.Format("3:04 PM")).
+				SetAlign(tview.AlignCenter))
+			table.SetCell(row+1, 3, tview.NewTableCell(job.LastEntry.Format("3:04 PM")).
+				SetAlign(tview.AlignCenter))
+			table.SetCell(row+1, 4, tview.NewTableCell(pkg.FormatEnteredBy(job.EnteredBy)).
+				SetAlign(tview.AlignCenter))
+			// Samples the techs flagged, so a supervisor can see what to look at
+			recheck := tview.NewTableCell("-").SetAlign(tview.AlignCenter)
+			if len(job.Rechecks) > 0 {
+				recheck.SetText(strings.Join(job.Rechecks, ", ")).SetTextColor(tcell.ColorFuchsia)
+			}
+			table.SetCell(row+1, 5, recheck)
+		}
+	}
+
+	// Summary line with totals
+	summary := fmt.Sprintf("%s  |  Total samples: %d  |  Jobs: %d",
+		activity.Date.Format("Monday 01/02/2006"), activity.TotalSamples, len(activity.Jobs))
+	if activity.TotalSamples > 0 {
+		summary += "  |  By: " + pkg.FormatEnteredBy(activity.EnteredBy)
+	}
+	if activity.Rechecks > 0 {
+		summary += fmt.Sprintf("  |  To recheck: %d", activity.Rechecks)
+	}
+	summaryText := tview.NewTextView().
+		SetText(summary).
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorWhite)
+
+	// Instructions text
+	instructions := tview.NewTextView().
+		SetText("Up/Down: Navigate  |  +: Back to LMS").
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorWhite)
+
+	// Container
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summaryText, 1, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(instructions, 1, 0, false)
+
+	container.SetBorder(true).
+		SetTitle(userTitle("Today's Work - All Jobs", user)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite)
+
+	// Center it
+	vertical := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(container, 0, 4, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(vertical, 0, 3, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '+' {
+			onBack()
+			return nil
+		}
+		return event
+	})
+
+	if err != nil {
+		QueueError(app, fmt.Errorf("failed to collect today's work: %v", err), horizontal, table)
+	}
+
+	return horizontal, table
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// NewDiagnosticsScreen runs the environment self-test and shows each check as pass/fail
+func NewDiagnosticsScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.Table) {
+	if denied := managerOnly(app, user, "Diagnostics", onBack); denied != nil {
+		return denied, nil
+	}
+
+	SetScreenShortcuts("Diagnostics", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"/", "Run checks again"},
+		{"+", "Back to LMS"},
+	})
+
+	logger.Info.Println("Opening Diagnostics screen")
+
+	table := tview.NewTable().
+		SetBorders(true).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	summaryText := tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetDynamicColors(true)
+
+	runChecks := func() {
+		table.Clear()
+
+		// Set headers
+		headers := []string{"Check", "Result", "Details"}
+		for col, header := range headers {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorWhite).
+				SetAlign(tview.AlignCenter).
+				SetSelectable(false).
+				SetAttributes(tcell.AttrBold))
+		}
+
+		results := pkg.RunDiagnostics()
+		failed := 0
+		for row, result := range results {
+			status := "PASS"
+			statusColor := tcell.ColorGreen
+			if !result.Passed {
+				status = "FAIL"
+				statusColor = tcell.ColorRed
+				failed++
+			}
+			table.SetCell(row+1, 0, tview.NewTableCell(result.Name).
+				SetTextColor(tcell.ColorWhite))
+			table.SetCell(row+1, 1, tview.NewTableCell(status).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(statusColor).
+				SetAttributes(tcell.AttrBold))
+			table.SetCell(row+1, 2, tview.NewTableCell(result.Detail).
+				SetTextColor(statusColor).
+				SetExpansion(1))
+		}
+
+		if failed == 0 {
+			summaryText.SetText(fmt.Sprintf("[green]All %d checks passed[-]", len(results)))
+		} else {
+			summaryText.SetText(fmt.Sprintf("[red]%d of %d checks failed[-]", failed, len(results)))
+		}
+	}
+	runChecks()
+
+	// Instructions text
+	instructions := tview.NewTextView().
+		SetText("Up/Down: Navigate  |  /: Run Again  |  +: Back to LMS").
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorWhite)
+
+	// Container
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summaryText, 1, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(instructions, 1, 0, false)
+
+	container.SetBorder(true).
+		SetTitle(userTitle("Diagnostics", user)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite)
+
+	// Center it
+	vertical := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(container, 0, 4, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(vertical, 0, 3, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '/' {
+			runChecks()
+			return nil
+		}
+		if event.Rune() == '+' {
+			onBack()
+			return nil
+		}
+		return event
+	})
+
+	return horizontal, table
+}
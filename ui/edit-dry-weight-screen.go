@@ -0,0 +1,303 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/models"
+	"lms-tui/pkg"
+)
+
+// NewEditDryWeightScreen lists moisture samples that have left the oven and lets the tech
+// re-enter a mistyped dry weight
+func NewEditDryWeightScreen(app *tview.Application, user *pkg.User, job models.Job, onBack func()) tview.Primitive {
+	if denied := managerOnly(app, user, "Edit Dry Weights", onBack); denied != nil {
+		return denied
+	}
+
+	SetScreenShortcuts("Edit Dry Weights", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"Enter", "Re-enter dry weight"},
+		{"v", "Switch between full and compact view"},
+		{"+", "Back to Edit Samples"},
+	})
+
+	logger.Info.Printf("Opening edit dry weight screen for Job: %s", job.ProjectNumber)
+
+	backupFile := pkg.BackupPath(job.ProjectNumber)
+	backupData, err := pkg.LoadBackupData(backupFile)
+	if err != nil {
+		logger.Error.Printf("Failed to load backup data: %v", err)
+		return newAlertModal(fmt.Sprintf("Failed to load backup data:\n%v\n\nPress Enter to go back", err), onBack)
+	}
+
+	// Cans still in the oven have no dry weight yet, so leave them out
+	cansInOven, err := pkg.GetCansInOven()
+	if err != nil {
+		logger.Error.Printf("Failed to load oven tracking: %v", err)
+	}
+	inOven := map[string]bool{}
+	for _, can := range cansInOven {
+		if can.JobNumber == job.ProjectNumber {
+			inOven[can.BoringNumber+"|"+can.Depth] = true
+		}
+	}
+
+	completedIndexes := []int{}
+	for i, sample := range backupData.Samples {
+		// Skipped samples never went in the oven and have no dry weight
+		if !inOven[sample.BoringNumber+"|"+sample.Depth] && !sample.IsSkipped() {
+			completedIndexes = append(completedIndexes, i)
+		}
+	}
+
+	if len(completedIndexes) == 0 {
+		return newAlertModal("No samples have been weighed out of the oven yet.\n\nPress Enter to go back", onBack)
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	headers := []string{"#", "Boring", "Depth", "Can #", "Can Wt", "Wet Wt", "Dry Wt", "Weighed By"}
+	const compactColumns = 3 // #, Boring and Depth are enough to pick a sample
+	compact := startCompact()
+	detail := newCompactDetail()
+
+	// rowCells returns every column for the completed sample on table row row+1
+	rowCells := func(row int) []*tview.TableCell {
+		sampleIndex := completedIndexes[row]
+		sample := backupData.Samples[sampleIndex]
+		dryWeight := sample.DryWeight
+		if dryWeight == "" {
+			dryWeight = "-"
+		}
+		return []*tview.TableCell{
+			tview.NewTableCell(fmt.Sprintf("%d", sampleIndex+1)).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.BoringNumber).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.Depth).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.CanNumber).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.CanWeight).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.WetWeight).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(dryWeight).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.WeighedBy).SetAlign(tview.AlignCenter),
+		}
+	}
+
+	setRow := func(row int) {
+		cells := rowCells(row)
+		if compact {
+			cells = cells[:compactColumns]
+		}
+		for col, cell := range cells {
+			table.SetCell(row+1, col, cell)
+		}
+	}
+
+	populateTable := func() {
+		table.Clear()
+		shown := headers
+		if compact {
+			shown = headers[:compactColumns]
+		}
+		for col, header := range shown {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorYellow).
+				SetAlign(tview.AlignCenter).
+				SetSelectable(false))
+		}
+		for row := range completedIndexes {
+			setRow(row)
+		}
+	}
+
+	// In compact view the selected sample is shown in full beside the table
+	showDetail := func(row int) {
+		if row < 1 || row > len(completedIndexes) {
+			detail.SetText("")
+			return
+		}
+		detail.SetText(compactDetailText(headers, rowCells(row-1)))
+	}
+
+	populateTable()
+	table.Select(1, 0)
+	showDetail(1)
+	table.SetSelectionChangedFunc(func(row, column int) {
+		showDetail(row)
+	})
+
+	table.SetBorder(true).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetBackgroundColor(tcell.ColorBlack)
+
+	// The table alone, or in compact view the table beside the selected sample
+	body := tview.NewFlex()
+	arrangeBody := func() {
+		body.Clear()
+		body.AddItem(table, 0, 1, true)
+		if compact {
+			table.SetTitle(" Select Sample ")
+			body.AddItem(detail, 0, 1, false)
+		} else {
+			table.SetTitle(" Select Sample to Re-enter Dry Weight ")
+		}
+	}
+	arrangeBody()
+
+	infoText := tview.NewTextView().
+		SetText(fmt.Sprintf("Job %s - %d samples weighed out of the oven\n\nUse ↑/↓ to select, Enter to edit, v to switch view, + to go back",
+			job.ProjectNumber, len(completedIndexes))).
+		SetTextAlign(tview.AlignCenter).
+		SetDynamicColors(true).
+		SetBackgroundColor(tcell.ColorBlack)
+
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow)
+	AddTrainingBanner(container)
+	container.AddItem(infoText, 3, 0, false).
+		AddItem(body, 0, 1, true)
+
+	table.SetSelectedFunc(func(row, col int) {
+		if row == 0 || row > len(completedIndexes) {
+			return
+		}
+		sampleIndex := completedIndexes[row-1]
+		showEditDryWeightModal(app, user, job, sampleIndex, backupData, backupFile, table, container, func() {
+			setRow(row - 1)
+			showDetail(row)
+		})
+	})
+
+	container.SetBorder(true).
+		SetTitle(userTitle(fmt.Sprintf("Edit Dry Weights - Job %s", job.ProjectNumber), user)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetBackgroundColor(tcell.ColorBlack)
+
+	container.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '+' {
+			onBack()
+			return nil
+		}
+		if event.Rune() == 'v' {
+			// Switch between the full table and the compact view
+			compact = !compact
+			row, _ := table.GetSelection()
+			populateTable()
+			arrangeBody()
+			table.Select(row, 0)
+			showDetail(row)
+			app.SetFocus(table)
+			return nil
+		}
+		return event
+	})
+
+	QueueOvenRecoveryNotice(app, container, table)
+
+	return container
+}
+
+func showEditDryWeightModal(app *tview.Application, user *pkg.User, job models.Job, sampleIndex int, backupData *pkg.BackupData,
+	backupFile string, table *tview.Table, container tview.Primitive, onSaved func()) {
+
+	sample := backupData.Samples[sampleIndex]
+
+	form := tview.NewForm()
+	form.AddInputField("Dry Weight (g)", sample.DryWeight, 25, tview.InputFieldFloat, nil)
+
+	form.AddButton("Save Changes", func() {
+		newDryWeight, ok := formText(form, "Dry Weight (g)")
+		if !ok {
+			Alert(app, missingFieldError("Dry Weight (g)").Error(), container, table)
+			return
+		}
+		if newDryWeight == "" {
+			Alert(app, "Dry Weight is required", container, table)
+			return
+		}
+
+		// Use the location saved with the sample; older backups need a live remap
+		moistureSheet, moistureColumn := sample.MoistureSheet, sample.MoistureColumn
+		if moistureSheet == "" || moistureColumn == "" {
+			var err error
+			moistureSheet, moistureColumn, err = pkg.LookupMoistureLocation(job.ProjectNumber, job.LabFilePath, sample.BoringNumber, sample.Depth)
+			if err != nil {
+				logger.Error.Printf("Failed to find moisture location: %v", err)
+				Alert(app, fmt.Sprintf("Failed to find sample on Moisture sheet:\n%v", err), container, table)
+				return
+			}
+			backupData.Samples[sampleIndex].MoistureSheet = moistureSheet
+			backupData.Samples[sampleIndex].MoistureColumn = moistureColumn
+		}
+
+		can := pkg.OvenCanData{
+			CanNumber:      sample.CanNumber,
+			JobNumber:      job.ProjectNumber,
+			BoringNumber:   sample.BoringNumber,
+			Depth:          sample.Depth,
+			MoistureSheet:  moistureSheet,
+			MoistureColumn: moistureColumn,
+		}
+
+		// Recompute water, dry soil, and moisture content rows
+		moistureContent, err := pkg.WriteDryWeightToMoistureSheet(can, newDryWeight)
+		if err != nil {
+			logger.Error.Printf("Failed to write dry weight: %v", err)
+			Alert(app, fmt.Sprintf("Failed to update dry weight:\n%v", err), container, table)
+			return
+		}
+
+		logger.Info.Printf("Updated dry weight for %s|%s: %s -> %s g", sample.BoringNumber, sample.Depth, sample.DryWeight, newDryWeight)
+
+		backupData.Samples[sampleIndex].DryWeight = newDryWeight
+		backupData.Samples[sampleIndex].WeighedBy = user.AuditID()
+		if err := pkg.SaveBackupDataToFile(backupData, backupFile); err != nil {
+			logger.Error.Printf("Failed to save backup: %v", err)
+			Alert(app, fmt.Sprintf("Failed to save backup:\n%v", err), container, table)
+			return
+		}
+
+		onSaved()
+
+		Alert(app, fmt.Sprintf("Dry weight updated!\n\nMoisture Content: %.1f%%", moistureContent), container, table)
+	})
+
+	cancelEdit := func() {
+		app.SetRoot(container, true)
+		app.SetFocus(table)
+	}
+	form.AddButton("Cancel", cancelEdit)
+	form.SetCancelFunc(cancelEdit)
+
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Edit Dry Weight - %s | %s (Can #%s) ", sample.BoringNumber, sample.Depth, sample.CanNumber)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite).
+		SetBackgroundColor(tcell.ColorBlack)
+
+	form.SetFieldBackgroundColor(tcell.ColorBlack).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetButtonBackgroundColor(tcell.ColorWhite).
+		SetButtonTextColor(tcell.ColorBlack).
+		SetLabelColor(tcell.ColorWhite).
+		SetBackgroundColor(tcell.ColorBlack)
+
+	// Center the form
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 9, 0, true).
+			AddItem(nil, 0, 1, false), 60, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	modal.SetBackgroundColor(tcell.ColorBlack)
+	app.SetRoot(modal, true)
+	app.SetFocus(form)
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -11,11 +12,22 @@ import (
 	"lms-tui/pkg"
 )
 
-func NewEditJobSelectionScreen(app *tview.Application, onBack func()) (tview.Primitive, *tview.Table) {
+func NewEditJobSelectionScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.Table) {
+	if denied := managerOnly(app, user, "Edit Past Samples", onBack); denied != nil {
+		return denied, nil
+	}
+
+	SetScreenShortcuts("Edit Past Samples", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"Enter", "Select job"},
+		{"0-9", "Type a job number to jump to it"},
+		{"+", "Back to LMS"},
+	})
+
 	// Discover jobs that have been pulled (have backup data)
-	jobs, err := pkg.DiscoverJobs()
-	if err != nil {
-		logger.Error.Printf("Failed to discover jobs: %v", err)
+	jobs, discoverErr := pkg.DiscoverJobs()
+	if discoverErr != nil {
+		logger.Error.Printf("Failed to discover jobs: %v", discoverErr)
 	}
 
 	// Filter jobs that have backup data
@@ -25,7 +37,7 @@ func NewEditJobSelectionScreen(app *tview.Application, onBack func()) (tview.Pri
 	}{}
 
 	for _, job := range jobs {
-		backupFile := fmt.Sprintf("ex_project/%s/backup.json", job.ProjectNumber)
+		backupFile := pkg.BackupPath(job.ProjectNumber)
 		if _, err := os.Stat(backupFile); err == nil {
 			// Backup file exists, load it to get sample count
 			backupData, err := pkg.LoadBackupData(backupFile)
@@ -59,8 +71,9 @@ func NewEditJobSelectionScreen(app *tview.Application, onBack func()) (tview.Pri
 
 	// Populate table
 	if len(jobsWithSamples) == 0 {
-		table.SetCell(1, 0, tview.NewTableCell("No jobs with samples found").
-			SetTextColor(tcell.ColorYellow).
+		message, color := noJobsMessage(discoverErr, "No jobs with samples found")
+		table.SetCell(1, 0, tview.NewTableCell(message).
+			SetTextColor(color).
 			SetAlign(tview.AlignCenter))
 	} else {
 		for row, jobInfo := range jobsWithSamples {
@@ -85,15 +98,23 @@ func NewEditJobSelectionScreen(app *tview.Application, onBack func()) (tview.Pri
 		logger.Info.Printf("Selected job %s for editing samples", selectedJobInfo.Job.ProjectNumber)
 
 		// Navigate to edit samples screen
-		editSamplesScreen := NewEditSamplesScreen(app, selectedJobInfo.Job, func() {
+		editSamplesScreen := NewEditSamplesScreen(app, user, selectedJobInfo.Job, func() {
 			// Go back to job selection
-			editJobScreen, editJobTable := NewEditJobSelectionScreen(app, onBack)
+			editJobScreen, editJobTable := NewEditJobSelectionScreen(app, user, onBack)
 			app.SetRoot(editJobScreen, true)
-			app.SetFocus(editJobTable)
+			if editJobTable != nil { // nil when the user was turned away
+				app.SetFocus(editJobTable)
+			}
 		})
 		app.SetRoot(editSamplesScreen, true)
 	})
 
+	// Status line, where a typed job number shows
+	statusText := tview.NewTextView().
+		SetText("Jobs with Samples").
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorWhite)
+
 	// Instructions
 	instructions := tview.NewTextView().
 		SetText("Up/Down: Navigate  |  Enter: Select Job  |  +: Back to LMS").
@@ -103,11 +124,12 @@ func NewEditJobSelectionScreen(app *tview.Application, onBack func()) (tview.Pri
 	// Container
 	container := tview.NewFlex().
 		SetDirection(tview.FlexRow).
+		AddItem(statusText, 1, 0, false).
 		AddItem(table, 0, 1, true).
 		AddItem(instructions, 1, 0, false)
 
 	container.SetBorder(true).
-		SetTitle(" Select Job to Edit Samples ").
+		SetTitle(userTitle("Select Job to Edit Samples", user)).
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorWhite)
 
@@ -123,8 +145,19 @@ func NewEditJobSelectionScreen(app *tview.Application, onBack func()) (tview.Pri
 		AddItem(vertical, 0, 3, true).
 		AddItem(nil, 0, 1, false)
 
+	jumpToJob := newJobJump(app, table, statusText, func() []string {
+		numbers := make([]string, len(jobsWithSamples))
+		for i, jobInfo := range jobsWithSamples {
+			numbers[i] = jobInfo.Job.ProjectNumber
+		}
+		return numbers
+	}, func() string { return "Jobs with Samples" })
+
 	// Input capture
 	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if jumpToJob(event) {
+			return nil
+		}
 		if event.Rune() == '+' {
 			onBack()
 			return nil
@@ -132,5 +165,10 @@ func NewEditJobSelectionScreen(app *tview.Application, onBack func()) (tview.Pri
 		return event
 	})
 
+	// A missing projects folder is explained in the list itself
+	if discoverErr != nil && !errors.Is(discoverErr, pkg.ErrProjectsDirMissing) {
+		QueueError(app, fmt.Errorf("failed to discover jobs: %v", discoverErr), horizontal, table)
+	}
+
 	return horizontal, table
 }
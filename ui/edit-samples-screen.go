@@ -3,8 +3,6 @@ package ui
 import (
 	"fmt"
 
-	"strings"
-
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"lms-tui/logger"
@@ -12,35 +10,34 @@ import (
 	"lms-tui/pkg"
 )
 
-func NewEditSamplesScreen(app *tview.Application, job models.Job, onBack func()) tview.Primitive {
+func NewEditSamplesScreen(app *tview.Application, user *pkg.User, job models.Job, onBack func()) tview.Primitive {
+	if denied := managerOnly(app, user, "Edit Samples", onBack); denied != nil {
+		return denied
+	}
+
+	SetScreenShortcuts("Edit Samples", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"Enter", "Edit selected sample"},
+		{"/", "Re-enter dry weights"},
+		{"r", "Flag / clear the selected sample for recheck"},
+		{"v", "Switch between full and compact view"},
+		{"+", "Back to job selection"},
+	})
+
 	logger.Info.Printf("Opening edit samples screen for Job: %s", job.ProjectNumber)
 
 	// Load backup data
-	backupFile := fmt.Sprintf("ex_project/%s/backup.json", job.ProjectNumber)
+	backupFile := pkg.BackupPath(job.ProjectNumber)
 	backupData, err := pkg.LoadBackupData(backupFile)
 	if err != nil {
 		logger.Error.Printf("Failed to load backup data: %v", err)
 		// Show error modal and go back
-		modal := tview.NewModal().
-			SetText(fmt.Sprintf("Failed to load backup data:\n%v\n\nPress Enter to go back", err)).
-			AddButtons([]string{"OK"}).
-			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				onBack()
-			})
-		modal.SetBackgroundColor(tcell.ColorBlack)
-		return modal
+		return newAlertModal(fmt.Sprintf("Failed to load backup data:\n%v\n\nPress Enter to go back", err), onBack)
 	}
 
 	if len(backupData.Samples) == 0 {
 		// No samples to edit
-		modal := tview.NewModal().
-			SetText("No samples found to edit.\n\nPress Enter to go back").
-			AddButtons([]string{"OK"}).
-			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				onBack()
-			})
-		modal.SetBackgroundColor(tcell.ColorBlack)
-		return modal
+		return newAlertModal("No samples found to edit.\n\nPress Enter to go back", onBack)
 	}
 
 	// Create table to show all samples
@@ -49,36 +46,121 @@ func NewEditSamplesScreen(app *tview.Application, job models.Job, onBack func())
 		SetSelectable(true, false).
 		SetFixed(1, 0)
 
-	// Set headers
-	headers := []string{"#", "Boring", "Depth", "Can #", "Can Wt", "Wet Wt", "Suction Can"}
-	for col, header := range headers {
-		table.SetCell(0, col, tview.NewTableCell(header).
-			SetTextColor(tcell.ColorYellow).
-			SetAlign(tview.AlignCenter).
-			SetSelectable(false))
+	headers := []string{"#", "Boring", "Depth", "Can #", "Can Wt", "Wet Wt", "Suction Can", "Status", "Entered By", "Notes"}
+	const compactColumns = 3 // #, Boring and Depth are enough to pick a sample
+	compact := startCompact()
+	detail := newCompactDetail()
+
+	// Cans still drying, so the status column can tell them from cans that went missing
+	var inOven map[string]pkg.OvenCanData
+	loadInOven := func() {
+		var err error
+		inOven, err = pkg.GetJobCansInOven(job.ProjectNumber)
+		if err != nil {
+			logger.Error.Printf("Failed to load oven tracking for sample status: %v", err)
+		}
 	}
 
-	// Populate table with samples
-	for i, sample := range backupData.Samples {
-		row := i + 1
-		table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).SetAlign(tview.AlignCenter))
-		table.SetCell(row, 1, tview.NewTableCell(sample.BoringNumber).SetAlign(tview.AlignCenter))
-		table.SetCell(row, 2, tview.NewTableCell(sample.Depth).SetAlign(tview.AlignCenter))
-		table.SetCell(row, 3, tview.NewTableCell(sample.CanNumber).SetAlign(tview.AlignCenter))
-		table.SetCell(row, 4, tview.NewTableCell(sample.CanWeight).SetAlign(tview.AlignCenter))
-		table.SetCell(row, 5, tview.NewTableCell(sample.WetWeight).SetAlign(tview.AlignCenter))
-		table.SetCell(row, 6, tview.NewTableCell(sample.SuctionCanNo).SetAlign(tview.AlignCenter))
+	// rowCells returns every column for backupData.Samples[i], even in compact view
+	rowCells := func(i int) []*tview.TableCell {
+		sample := backupData.Samples[i]
+		_, canInOven := inOven[sample.BoringNumber+"|"+sample.Depth]
+		cells := []*tview.TableCell{
+			tview.NewTableCell(fmt.Sprintf("%d", i+1)).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.BoringNumber).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.Depth).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.CanNumber).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.CanWeight).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.WetWeight).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.SuctionCanNo).SetAlign(tview.AlignCenter),
+			sampleStatusCell(sample.ProcessingStatus(canInOven)),
+			tview.NewTableCell(sample.EnteredBy).SetAlign(tview.AlignCenter),
+			tview.NewTableCell(sample.Notes).SetTextColor(tcell.ColorYellow).SetMaxWidth(30).SetExpansion(1),
+		}
+		if sample.IsSkipped() {
+			// Not tested - gray the row and show the reason instead of weights
+			for col := 0; col < 3; col++ {
+				cells[col].SetTextColor(tcell.ColorGray)
+			}
+			cells[3] = tview.NewTableCell("SKIPPED").SetTextColor(tcell.ColorGray).SetAlign(tview.AlignCenter)
+			cells[9] = tview.NewTableCell(sample.SkipReason).SetTextColor(tcell.ColorGray).SetMaxWidth(30).SetExpansion(1)
+		}
+		if sample.NeedsRecheck {
+			// Flagged for a supervisor - mark it in the columns compact view keeps too
+			cells[0].SetText("⚑ " + cells[0].Text)
+			for col := 0; col < 3; col++ {
+				cells[col].SetTextColor(tcell.ColorFuchsia)
+			}
+		}
+		return cells
 	}
 
+	setRow := func(i int) {
+		cells := rowCells(i)
+		if compact {
+			cells = cells[:compactColumns]
+		}
+		for col, cell := range cells {
+			table.SetCell(i+1, col, cell)
+		}
+	}
+
+	populateTable := func() {
+		table.Clear()
+		shown := headers
+		if compact {
+			shown = headers[:compactColumns]
+		}
+		for col, header := range shown {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorYellow).
+				SetAlign(tview.AlignCenter).
+				SetSelectable(false))
+		}
+		for i := range backupData.Samples {
+			setRow(i)
+		}
+	}
+
+	// In compact view the selected sample is shown in full beside the table
+	showDetail := func(row int) {
+		if row < 1 || row > len(backupData.Samples) {
+			detail.SetText("")
+			return
+		}
+		detail.SetText(compactDetailText(headers, rowCells(row-1)))
+	}
+
+	loadInOven()
+	populateTable()
+	table.Select(1, 0)
+	showDetail(1)
+	table.SetSelectionChangedFunc(func(row, column int) {
+		showDetail(row)
+	})
+
 	table.SetBorder(true).
-		SetTitle(" Select Sample to Edit (↑/↓ to navigate, Enter to edit) ").
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorWhite).
 		SetBackgroundColor(tcell.ColorBlack)
 
+	// The table alone, or in compact view the table beside the selected sample
+	body := tview.NewFlex()
+	arrangeBody := func() {
+		body.Clear()
+		body.AddItem(table, 0, 1, true)
+		if compact {
+			table.SetTitle(" Select Sample ")
+			body.AddItem(detail, 0, 1, false)
+		} else {
+			table.SetTitle(" Select Sample to Edit (↑/↓ to navigate, Enter to edit) ")
+		}
+	}
+	arrangeBody()
+
 	// Info text
 	infoText := tview.NewTextView().
-		SetText(fmt.Sprintf("Job %s - %d samples in backup\n\nUse ↑/↓ to select, Enter to edit, + to go back",
+		SetText(fmt.Sprintf("Job %s - %d samples in backup\n\nUse ↑/↓ to select, Enter to edit, / for dry weights, r to flag for recheck, v to switch view, + to go back",
 			job.ProjectNumber, len(backupData.Samples))).
 		SetTextAlign(tview.AlignCenter).
 		SetDynamicColors(true).
@@ -86,9 +168,10 @@ func NewEditSamplesScreen(app *tview.Application, job models.Job, onBack func())
 
 	// Container
 	container := tview.NewFlex().
-		SetDirection(tview.FlexRow).
-		AddItem(infoText, 3, 0, false).
-		AddItem(table, 0, 1, true)
+		SetDirection(tview.FlexRow)
+	AddTrainingBanner(container)
+	container.AddItem(infoText, 3, 0, false).
+		AddItem(body, 0, 1, true)
 
 	// Handle selection
 	table.SetSelectedFunc(func(row, col int) {
@@ -98,12 +181,16 @@ func NewEditSamplesScreen(app *tview.Application, job models.Job, onBack func())
 		selectedIndex := row - 1
 		if selectedIndex >= 0 && selectedIndex < len(backupData.Samples) {
 			sample := backupData.Samples[selectedIndex]
-			showEditSampleModal(app, job, sample, selectedIndex, backupData, table, container)
+			showEditSampleModal(app, user, job, sample, selectedIndex, backupData, table, container, func() {
+				loadInOven()
+				setRow(selectedIndex)
+				showDetail(row)
+			})
 		}
 	})
 
 	container.SetBorder(true).
-		SetTitle(fmt.Sprintf(" Edit Samples - Job %s ", job.ProjectNumber)).
+		SetTitle(userTitle(fmt.Sprintf("Edit Samples - Job %s", job.ProjectNumber), user)).
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorWhite).
 		SetBackgroundColor(tcell.ColorBlack)
@@ -114,14 +201,65 @@ func NewEditSamplesScreen(app *tview.Application, job models.Job, onBack func())
 			onBack()
 			return nil
 		}
+		if event.Rune() == 'v' {
+			// Switch between the full table and the compact view
+			compact = !compact
+			row, _ := table.GetSelection()
+			populateTable()
+			arrangeBody()
+			table.Select(row, 0)
+			showDetail(row)
+			app.SetFocus(table)
+			return nil
+		}
+		if event.Rune() == 'r' {
+			// Flag the selected sample for recheck, or clear the flag once it has been checked
+			row, _ := table.GetSelection()
+			selectedIndex := row - 1
+			if selectedIndex < 0 || selectedIndex >= len(backupData.Samples) {
+				return nil
+			}
+			sample := &backupData.Samples[selectedIndex]
+			if err := pkg.SetSampleNeedsRecheck(job.ProjectNumber, sample.BoringNumber, sample.Depth, !sample.NeedsRecheck); err != nil {
+				ShowError(app, err, container, table)
+				return nil
+			}
+			sample.NeedsRecheck = !sample.NeedsRecheck
+			setRow(selectedIndex)
+			showDetail(row)
+			return nil
+		}
+		if event.Rune() == '/' {
+			// Switch to re-entering dry weights for this job
+			dryWeightScreen := NewEditDryWeightScreen(app, user, job, func() {
+				editSamplesScreen := NewEditSamplesScreen(app, user, job, onBack)
+				app.SetRoot(editSamplesScreen, true)
+			})
+			app.SetRoot(dryWeightScreen, true)
+			return nil
+		}
 		return event
 	})
 
 	return container
 }
 
-func showEditSampleModal(app *tview.Application, job models.Job, sample pkg.SampleBackupData,
-	sampleIndex int, backupData *pkg.BackupData, table *tview.Table, container tview.Primitive) {
+// sampleStatusCell shows a sample's ProcessingStatus with an icon and color
+func sampleStatusCell(status string) *tview.TableCell {
+	icon, color := "✗", tcell.ColorRed
+	switch status {
+	case pkg.SampleStatusInOven:
+		icon, color = "◐", tcell.ColorYellow
+	case pkg.SampleStatusDryRecorded:
+		icon, color = "✓", tcell.ColorGreen
+	case pkg.SampleStatusSkipped:
+		icon, color = "–", tcell.ColorGray
+	}
+	return tview.NewTableCell(icon + " " + status).SetTextColor(color)
+}
+
+func showEditSampleModal(app *tview.Application, user *pkg.User, job models.Job, sample pkg.SampleBackupData,
+	sampleIndex int, backupData *pkg.BackupData, table *tview.Table, container tview.Primitive, onSaved func()) {
 
 	// Create edit form
 	form := tview.NewForm()
@@ -132,14 +270,23 @@ func showEditSampleModal(app *tview.Application, job models.Job, sample pkg.Samp
 
 	form.AddButton("Save Changes", func() {
 		// Get updated values
-		newCanNo := strings.TrimSpace(form.GetFormItemByLabel("Can #").(*tview.InputField).GetText())
-		newCanWeight := strings.TrimSpace(form.GetFormItemByLabel("Can Weight (g)").(*tview.InputField).GetText())
-		newWetWeight := strings.TrimSpace(form.GetFormItemByLabel("Wet Weight (g)").(*tview.InputField).GetText())
-		newSuctionCanNo := strings.TrimSpace(form.GetFormItemByLabel("Suction Can #").(*tview.InputField).GetText())
+		values := map[string]string{}
+		for _, label := range []string{"Can #", "Can Weight (g)", "Wet Weight (g)", "Suction Can #"} {
+			value, ok := formText(form, label)
+			if !ok {
+				Alert(app, missingFieldError(label).Error(), container, table)
+				return
+			}
+			values[label] = value
+		}
+		newCanNo := values["Can #"]
+		newCanWeight := values["Can Weight (g)"]
+		newWetWeight := values["Wet Weight (g)"]
+		newSuctionCanNo := values["Suction Can #"]
 
 		// Validate
 		if newCanNo == "" || newCanWeight == "" || newWetWeight == "" {
-			showErrorModal(app, "Can #, Can Weight, and Wet Weight are required", table, container)
+			Alert(app, "Can #, Can Weight, and Wet Weight are required", container, table)
 			return
 		}
 
@@ -155,12 +302,14 @@ func showEditSampleModal(app *tview.Application, job models.Job, sample pkg.Samp
 		backupData.Samples[sampleIndex].CanWeight = newCanWeight
 		backupData.Samples[sampleIndex].WetWeight = newWetWeight
 		backupData.Samples[sampleIndex].SuctionCanNo = newSuctionCanNo
+		backupData.Samples[sampleIndex].SkipReason = "" // Weights entered later replace a skip
+		backupData.Samples[sampleIndex].EnteredBy = user.AuditID()
 
 		// Save backup
-		backupFile := fmt.Sprintf("ex_project/%s/backup.json", job.ProjectNumber)
+		backupFile := pkg.BackupPath(job.ProjectNumber)
 		if err := pkg.SaveBackupDataToFile(backupData, backupFile); err != nil {
 			logger.Error.Printf("Failed to save backup: %v", err)
-			showErrorModal(app, fmt.Sprintf("Failed to save backup:\n%v", err), table, container)
+			Alert(app, fmt.Sprintf("Failed to save backup:\n%v", err), container, table)
 			return
 		}
 
@@ -168,7 +317,7 @@ func showEditSampleModal(app *tview.Application, job models.Job, sample pkg.Samp
 		moistureWriter, err := pkg.InitMoistureTestFile(job.ProjectNumber, job.LabFilePath)
 		if err != nil {
 			logger.Error.Printf("Failed to initialize moisture writer: %v", err)
-			showErrorModal(app, fmt.Sprintf("Failed to update Excel:\n%v", err), table, container)
+			Alert(app, fmt.Sprintf("Failed to update Excel:\n%v", err), container, table)
 			return
 		}
 		defer moistureWriter.Close()
@@ -176,7 +325,7 @@ func showEditSampleModal(app *tview.Application, job models.Job, sample pkg.Samp
 		err = moistureWriter.WriteMoistureSample(sample.BoringNumber, sample.Depth, newCanNo, newCanWeight, newWetWeight)
 		if err != nil {
 			logger.Error.Printf("Failed to write moisture sample: %v", err)
-			showErrorModal(app, fmt.Sprintf("Failed to update moisture data:\n%v", err), table, container)
+			Alert(app, fmt.Sprintf("Failed to update moisture data:\n%v", err), container, table)
 			return
 		}
 
@@ -184,40 +333,32 @@ func showEditSampleModal(app *tview.Application, job models.Job, sample pkg.Samp
 		if newSuctionCanNo != "" {
 			suctionWriter, err := pkg.InitSoilSuctionFile(job.ProjectNumber, moistureWriter.GetFile())
 			if err != nil {
-				logger.Error.Printf("Failed to initialize suction writer: %v", err)
-			} else {
-				defer suctionWriter.Close()
-				err = suctionWriter.WriteSoilSuctionSample(sample.BoringNumber, sample.Depth, newSuctionCanNo)
-				if err != nil {
-					logger.Error.Printf("Failed to write suction sample: %v", err)
-				}
+				ShowError(app, fmt.Errorf("failed to update suction data: %v", err), container, table)
+				return
+			}
+			defer suctionWriter.Close()
+			err = suctionWriter.WriteSoilSuctionSample(sample.BoringNumber, sample.Depth, newSuctionCanNo)
+			if err != nil {
+				ShowError(app, fmt.Errorf("failed to update suction data: %v", err), container, table)
+				return
 			}
 		}
 
 		// Update table display
-		table.SetCell(sampleIndex+1, 3, tview.NewTableCell(newCanNo).SetAlign(tview.AlignCenter))
-		table.SetCell(sampleIndex+1, 4, tview.NewTableCell(newCanWeight).SetAlign(tview.AlignCenter))
-		table.SetCell(sampleIndex+1, 5, tview.NewTableCell(newWetWeight).SetAlign(tview.AlignCenter))
-		table.SetCell(sampleIndex+1, 6, tview.NewTableCell(newSuctionCanNo).SetAlign(tview.AlignCenter))
+		onSaved()
 
 		logger.Info.Printf("Successfully updated sample %d", sampleIndex+1)
 
 		// Show success message
-		successModal := tview.NewModal().
-			SetText("Sample updated successfully!").
-			AddButtons([]string{"OK"}).
-			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				app.SetRoot(container, true)
-				app.SetFocus(table)
-			})
-		successModal.SetBackgroundColor(tcell.ColorBlack)
-		app.SetRoot(successModal, true)
+		Alert(app, "Sample updated successfully!", container, table)
 	})
 
-	form.AddButton("Cancel", func() {
+	cancelEdit := func() {
 		app.SetRoot(container, true)
 		app.SetFocus(table)
-	})
+	}
+	form.AddButton("Cancel", cancelEdit)
+	form.SetCancelFunc(cancelEdit)
 
 	form.SetBorder(true).
 		SetTitle(fmt.Sprintf(" Edit Sample - %s | %s ", sample.BoringNumber, sample.Depth)).
@@ -245,15 +386,3 @@ func showEditSampleModal(app *tview.Application, job models.Job, sample pkg.Samp
 	app.SetRoot(modal, true)
 	app.SetFocus(form)
 }
-
-func showErrorModal(app *tview.Application, message string, returnTo tview.Primitive, container tview.Primitive) {
-	modal := tview.NewModal().
-		SetText(message).
-		AddButtons([]string{"OK"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			app.SetRoot(container, true)
-			app.SetFocus(returnTo)
-		})
-	modal.SetBackgroundColor(tcell.ColorBlack)
-	app.SetRoot(modal, true)
-}
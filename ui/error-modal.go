@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// ShowError logs err and shows it to the operator in a modal.
+// Dismissing the modal restores returnTo as the root and focuses focus (if not nil).
+func ShowError(app *tview.Application, err error, returnTo tview.Primitive, focus tview.Primitive) {
+	logger.Error.Printf("Showing error to user: %v", err)
+
+	Alert(app, fmt.Sprintf("⚠ Error\n\n%v", err), returnTo, focus)
+}
+
+// QueueError shows an error once the current event has finished processing.
+// Screen constructors use this for load failures, since the caller sets the
+// screen as root only after the constructor returns.
+func QueueError(app *tview.Application, err error, returnTo tview.Primitive, focus tview.Primitive) {
+	go app.QueueUpdateDraw(func() {
+		ShowError(app, err, returnTo, focus)
+	})
+}
+
+// QueueOvenRecoveryNotice tells the tech if oven_tracking.json was found corrupt and reset
+// while loading this screen. It does nothing when there was no recovery.
+func QueueOvenRecoveryNotice(app *tview.Application, returnTo tview.Primitive, focus tview.Primitive) {
+	recovery := pkg.TakeOvenTrackingRecovery()
+	if recovery == nil {
+		return
+	}
+	QueueError(app, fmt.Errorf("oven tracking was corrupt and has been reset.\n\n"+
+		"Recovered %d can(s). The damaged file was saved as:\n%s\n\n"+
+		"Check the cans in the oven against the list.",
+		recovery.RecoveredCans, recovery.BackupPath), returnTo, focus)
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+)
+
+// formInput returns the form's input field with the given label, or nil when the form
+// has no input field by that label (e.g. an optional field that isn't shown)
+func formInput(form *tview.Form, label string) *tview.InputField {
+	field, _ := form.GetFormItemByLabel(label).(*tview.InputField)
+	return field
+}
+
+// formText returns the trimmed text of the input field with the given label. ok is false
+// when there is no such field, so a renamed or missing label is reported instead of panicking.
+func formText(form *tview.Form, label string) (string, bool) {
+	field := formInput(form, label)
+	if field == nil {
+		return "", false
+	}
+	return strings.TrimSpace(field.GetText()), true
+}
+
+// missingFieldError logs and describes a required form field that could not be found
+func missingFieldError(label string) error {
+	label = strings.TrimSpace(label)
+	logger.Error.Printf("Form has no input field labelled %q", label)
+	return fmt.Errorf("the form is missing the %q field.\n\nGo back and reopen the screen", label)
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// Shortcut describes a key and what it does on a screen
+type Shortcut struct {
+	Key         string
+	Description string
+}
+
+// globalShortcuts lists the help and quit keys and the configured key remaps, shown on every help overlay
+func globalShortcuts() []Shortcut {
+	shortcuts := []Shortcut{{"?", "Show / hide this help"}, {"Ctrl+Q", "Quit (asks first if a sample is unsaved)"}}
+	for _, remap := range pkg.Config.KeyRemaps {
+		shortcuts = append(shortcuts, Shortcut{remap.From, "Acts as " + remap.To})
+	}
+	return shortcuts
+}
+
+var (
+	helpScreenName string
+	helpShortcuts  []Shortcut
+	helpVisible    bool
+	helpView       *tview.TextView
+)
+
+// SetScreenShortcuts registers the shortcuts of the screen currently being shown,
+// so the help overlay lists the keys that actually apply
+func SetScreenShortcuts(screenName string, shortcuts []Shortcut) {
+	helpScreenName = screenName
+	helpShortcuts = shortcuts
+	helpVisible = false
+	screenKeys = nil
+	textInputs = nil
+}
+
+// HelpVisible reports whether the help overlay is currently displayed
+func HelpVisible() bool {
+	return helpVisible
+}
+
+// ToggleHelp shows or hides the help overlay
+func ToggleHelp() {
+	helpVisible = !helpVisible
+	if helpVisible {
+		logger.Info.Printf("Showing help overlay for %s", helpScreenName)
+	}
+}
+
+// DrawHelpOverlay draws the help overlay on top of the current screen.
+// It is installed as the application's after-draw function so it never replaces the root.
+func DrawHelpOverlay(screen tcell.Screen) {
+	if !helpVisible {
+		return
+	}
+
+	var content strings.Builder
+	if len(helpShortcuts) > 0 {
+		content.WriteString(fmt.Sprintf("[yellow]%s[-]\n", helpScreenName))
+		for _, shortcut := range helpShortcuts {
+			content.WriteString(fmt.Sprintf("  [white]%-14s[-] %s\n", shortcut.Key, shortcut.Description))
+		}
+		content.WriteString("\n")
+	}
+	content.WriteString("[yellow]Global[-]\n")
+	for _, shortcut := range globalShortcuts() {
+		content.WriteString(fmt.Sprintf("  [white]%-14s[-] %s\n", shortcut.Key, shortcut.Description))
+	}
+	content.WriteString("\n[gray]Press ? or Esc to close[-]")
+
+	if helpView == nil {
+		helpView = tview.NewTextView().SetDynamicColors(true)
+		helpView.SetBorder(true).
+			SetTitle(" Help ").
+			SetTitleAlign(tview.AlignCenter).
+			SetBorderColor(tcell.ColorYellow).
+			SetBackgroundColor(tcell.ColorBlack)
+	}
+	helpView.SetText(content.String())
+
+	// Center the overlay on screen
+	lineCount := strings.Count(content.String(), "\n") + 1
+	screenWidth, screenHeight := screen.Size()
+	width := 60
+	if width > screenWidth {
+		width = screenWidth
+	}
+	height := lineCount + 2
+	if height > screenHeight {
+		height = screenHeight
+	}
+	helpView.SetRect((screenWidth-width)/2, (screenHeight-height)/2, width, height)
+	helpView.Draw(screen)
+}
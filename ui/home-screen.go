@@ -2,32 +2,53 @@ package ui
 
 import (
 	"lms-tui/logger"
+	"lms-tui/pkg"
 	"github.com/rivo/tview"
 )
 
-func NewHomeScreen(app *tview.Application) (tview.Primitive, *tview.List) {
+func NewHomeScreen(app *tview.Application, user *pkg.User) (tview.Primitive, *tview.List) {
+	SetScreenShortcuts("Home", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"1", "LMS"},
+		{"2", "Change PIN"},
+		{"Enter", "Select"},
+	})
+
+	backToHome := func() {
+		homescreen, homeList := NewHomeScreen(app, user)
+		app.SetRoot(homescreen, true)
+		app.SetFocus(homeList)
+	}
+
 	list := tview.NewList().
 		AddItem("LMS", "Lab Management System", '1', func() {
 			logger.Info.Println("Navigating to LMS screen")
-			lmsScreen, lmsList := NewLMSScreen(app, func() {
+			lmsScreen, lmsList := NewLMSScreen(app, user, func() {
 				// This callback runs when '+' is pressed in LMS screen
 				logger.Info.Println("Returning to home screen from LMS")
-				homescreen, homeList := NewHomeScreen(app)
-				app.SetRoot(homescreen, true)
-				app.SetFocus(homeList)
+				backToHome()
 			})
 			app.SetRoot(lmsScreen, true)
 			app.SetFocus(lmsList)
+		}).
+		AddItem("Change PIN", "Change your login PIN", '2', func() {
+			logger.Info.Println("Navigating to Change PIN screen")
+			changePINScreen, changePINForm := NewChangePINScreen(app, user, func() {
+				logger.Info.Println("Returning to home screen from Change PIN")
+				backToHome()
+			})
+			app.SetRoot(changePINScreen, true)
+			app.SetFocus(changePINForm)
 		})
 
 	// Container with textview and list
 	container := tview.NewFlex().
 		SetDirection(tview.FlexRow).
-		AddItem(tview.NewTextView().SetText("Marco Mascorro").SetTextAlign(tview.AlignCenter), 1, 0, false).
+		AddItem(tview.NewTextView().SetText(user.Name).SetTextAlign(tview.AlignCenter), 1, 0, false).
 		AddItem(list, 0, 1, true)
 
 	container.SetBorder(true).
-		SetTitle(" Home ").
+		SetTitle(userTitle("Home", user)).
 		SetTitleAlign(tview.AlignCenter)
 
 	container.SetBorderPadding(1, 1, 1, 1)
@@ -36,7 +57,7 @@ func NewHomeScreen(app *tview.Application) (tview.Primitive, *tview.List) {
 	vertical := tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(nil, 0, 1, false).
-		AddItem(container, 10, 1, true).
+		AddItem(container, 12, 1, true).
 		AddItem(nil, 0, 1, false)
 
 	horizontal := tview.NewFlex().
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
@@ -60,20 +61,63 @@ func filterEmptyRows(rows [][]string) [][]string {
 	return filtered
 }
 
-func NewJobDetailScreen(app *tview.Application, job models.Job, onBack func()) tview.Primitive {
-	// Build the Excel file path
-	filePath := fmt.Sprintf("projects/%s/Lab_%s.xlsm", job.ProjectNumber, job.ProjectNumber)
+func NewJobDetailScreen(app *tview.Application, user *pkg.User, job models.Job, onBack func()) tview.Primitive {
+	SetScreenShortcuts("Job Detail", []Shortcut{
+		{"Up/Down", "Navigate samples"},
+		{"Ctrl+O", "Open job folder in file manager"},
+		{"c", "Compare the working Lab file to its original"},
+		{"Ctrl+Y", "Copy the selected sample's boring, depth and tests"},
+		{"+", "Back to Job List"},
+	})
 
 	logger.Info.Printf("Opening job detail for: %s", job.ProjectNumber)
 
+	// Reading a large Lab file takes a moment, so it is read in the background.
+	// Use the Lab file discovery found (.xlsm or .xlsx, possibly a revision).
+	return newLoadingModal(app, fmt.Sprintf("Reading Lab file for job %s...", job.ProjectNumber),
+		func() (jobDetail, error) {
+			jobData, err := pkg.ExcelToJSON(job.LabFilePath)
+			if err != nil {
+				return jobDetail{}, err
+			}
+			// Show the office's corrections here too, as the job lists do
+			metaOverride, err := pkg.LoadMetaOverride(filepath.Join(pkg.ProjectsDir(), job.FolderName))
+			if err != nil {
+				logger.Error.Printf("WARNING: Ignoring %s for job %s: %v", pkg.MetaOverrideFileName, job.ProjectNumber, err)
+			} else if metaOverride != nil {
+				if err := metaOverride.ApplyToJobData(jobData); err != nil {
+					logger.Error.Printf("WARNING: Part of %s for job %s was not applied: %v", pkg.MetaOverrideFileName, job.ProjectNumber, err)
+				}
+			}
+			// Where each sample lands on the Moisture sheets, so a tech checking Excel knows where to look
+			locations, locationsErr := pkg.LoadMoistureLocations(job.LabFilePath)
+			if locationsErr != nil {
+				logger.Error.Printf("Failed to load moisture sheet locations: %v", locationsErr)
+			}
+			return jobDetail{jobData: jobData, locations: locations}, nil
+		},
+		func(detail jobDetail, err error) {
+			app.SetRoot(newJobDetailView(app, user, job, detail, err, onBack), true)
+		}, onBack)
+}
+
+// jobDetail is what the job detail screen reads from the Lab file before it is shown
+type jobDetail struct {
+	jobData   *pkg.JobData
+	locations map[string]pkg.MoistureLocation // Missing when the Moisture sheets couldn't be read
+}
+
+// newJobDetailView builds the job detail screen from the details read from its Lab file,
+// or shows err if it couldn't be read
+func newJobDetailView(app *tview.Application, user *pkg.User, job models.Job, detail jobDetail, err error, onBack func()) tview.Primitive {
+	jobData := detail.jobData
+
 	// Create the table for sample data
 	table := tview.NewTable().
 		SetBorders(true).
 		SetSelectable(true, false).
 		SetFixed(1, 0)
 
-	// Convert Excel to JSON and log it
-	jobData, err := pkg.ExcelToJSON(filePath)
 	if err != nil {
 		logger.Error.Printf("Failed to parse Excel file: %v", err)
 		table.SetCell(0, 0, tview.NewTableCell("Error loading job data").
@@ -83,7 +127,7 @@ func NewJobDetailScreen(app *tview.Application, job models.Job, onBack func()) t
 			SetTextColor(tcell.ColorYellow))
 	} else {
 		// Set up table headers
-		headers := []string{"Boring", "Depth", "Tests Required"}
+		headers := []string{"Boring", "Depth", "Tests Required", "Moisture Sheet"}
 		for col, header := range headers {
 			table.SetCell(0, col, tview.NewTableCell(header).
 				SetTextColor(tcell.ColorWhite).
@@ -110,30 +154,57 @@ func NewJobDetailScreen(app *tview.Application, job models.Job, onBack func()) t
 
 			// Tests (joined as comma-separated list)
 			testsStr := strings.Join(sample.Tests, ", ")
-			if testsStr == "" {
-				testsStr = "-"
+			testsColor := tcell.ColorWhite
+			if !sample.HasTests() {
+				// Pull Job passes over these, so say so rather than leave the cell blank
+				testsStr = "None marked - not pulled"
+				testsColor = tcell.ColorGray
 			}
 			testsCell := tview.NewTableCell(testsStr).
-				SetTextColor(tcell.ColorWhite).
+				SetTextColor(testsColor).
 				SetExpansion(2)
 			table.SetCell(row+1, 2, testsCell)
+
+			// Moisture sheet and column - missing is only a problem if the sample needs a moisture test
+			locationCell := tview.NewTableCell("-").
+				SetTextColor(tcell.ColorGray).
+				SetAlign(tview.AlignCenter)
+			if location, ok := detail.locations[sample.BoringNumber+"|"+sample.Depth]; ok {
+				locationCell.SetText(fmt.Sprintf("%s / %s", location.Sheet, location.Column)).
+					SetTextColor(tcell.ColorWhite)
+			} else if strings.Contains(testsStr, "Moisture") {
+				locationCell.SetText("not mapped").
+					SetTextColor(tcell.ColorRed)
+			}
+			table.SetCell(row+1, 3, locationCell)
 		}
 
 		logger.Info.Printf("Displayed %d samples in table", len(jobData.Samples))
 	}
 
-	// Job info header with data from JSON
+	// Job info header with data from JSON, a line taller for each skipped-row warning
 	var headerText string
+	headerHeight := 3
 	if jobData != nil {
+		// Flag a mismatch with the total stated on the Main Form so missed rows are caught early
+		totalText := fmt.Sprintf("Total Samples: %d", jobData.TotalSamples)
+		if jobData.StatedTotal > 0 && jobData.StatedTotal != jobData.TotalSamples {
+			totalText = fmt.Sprintf("[yellow]⚠ Total Samples: %d parsed, %d on form - check for missed rows[-]",
+				jobData.TotalSamples, jobData.StatedTotal)
+		}
+		for _, warning := range jobData.SkippedRowWarnings() {
+			totalText += "\n[yellow]⚠ " + tview.Escape(warning) + "[-]"
+			headerHeight++
+		}
 		headerText = fmt.Sprintf(
 			"Job: %s  Project: %s\n"+
-				"Engineer: %s  Date: %s  Due: %s  Total Samples: %d",
+				"Engineer: %s  Date: %s  Due: %s  %s",
 			jobData.JobNumber,
 			jobData.ProjectName,
 			jobData.Engineer,
 			jobData.Date,
 			jobData.DueDate,
-			jobData.TotalSamples)
+			totalText)
 	} else {
 		headerText = fmt.Sprintf("Job: %s - %s", job.ProjectNumber, job.ProjectName)
 	}
@@ -144,20 +215,21 @@ func NewJobDetailScreen(app *tview.Application, job models.Job, onBack func()) t
 		SetDynamicColors(true)
 
 	// Instructions
+	instructionsText := "Up/Down: Navigate Samples  |  Ctrl+O: Open Folder  |  Ctrl+Y: Copy Sample  |  c: Compare to Original  |  +: Back to Job List"
 	instructions := tview.NewTextView().
-		SetText("Up/Down: Navigate Samples  |  +: Back to Job List").
+		SetText(instructionsText).
 		SetTextAlign(tview.AlignCenter).
 		SetDynamicColors(true)
 
 	// Container
 	container := tview.NewFlex().
 		SetDirection(tview.FlexRow).
-		AddItem(jobInfo, 3, 0, false).
+		AddItem(jobInfo, headerHeight, 0, false).
 		AddItem(table, 0, 1, true).
 		AddItem(instructions, 1, 0, false)
 
 	container.SetBorder(true).
-		SetTitle(fmt.Sprintf(" Sample Test Requirements - Job %s ", job.ProjectNumber)).
+		SetTitle(userTitle(fmt.Sprintf("Sample Test Requirements - Job %s", job.ProjectNumber), user)).
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorWhite)
 
@@ -175,6 +247,34 @@ func NewJobDetailScreen(app *tview.Application, job models.Job, onBack func()) t
 
 	// Input capture for back navigation
 	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyCtrlO {
+			if _, err := pkg.OpenJobFolder(job); err != nil {
+				ShowError(app, err, horizontal, table)
+			}
+			return nil
+		}
+		if event.Key() == tcell.KeyCtrlY {
+			row, _ := table.GetSelection()
+			if jobData == nil || row < 1 || row > len(jobData.Samples) {
+				return nil
+			}
+			sample := jobData.Samples[row-1]
+			if err := pkg.CopyToClipboard(pkg.SampleClipboardText(job.ProjectNumber, sample)); err != nil {
+				ShowError(app, err, horizontal, table)
+				return nil
+			}
+			flashStatus(app, instructions, fmt.Sprintf("[green]Copied %s | %s to the clipboard[-]", sample.BoringNumber, sample.Depth),
+				func() string { return instructionsText })
+			return nil
+		}
+		if event.Rune() == 'c' {
+			diffScreen, diffTable := NewLabFileDiffScreen(app, user, job, func() {
+				app.SetRoot(NewJobDetailScreen(app, user, job, onBack), true)
+			})
+			app.SetRoot(diffScreen, true)
+			app.SetFocus(diffTable)
+			return nil
+		}
 		if event.Rune() == '+' {
 			logger.Info.Println("Returning from job detail to view jobs")
 			onBack()
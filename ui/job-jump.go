@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/models"
+)
+
+// jobJumpTimeout is how long a typed job number is kept after the last digit
+const jobJumpTimeout = 1500 * time.Millisecond
+
+// newJobJump returns a key handler that lets techs type a job number to jump to it in a job
+// list instead of arrowing through every row. Digits build up in status and the first row
+// whose job number starts with them is selected. jobNumbers returns the job number on each
+// table row below the header. The typed number is dropped jobJumpTimeout after the last
+// digit and status goes back to restore's text. The handler reports whether it used the key.
+func newJobJump(app *tview.Application, table *tview.Table, status *tview.TextView,
+	jobNumbers func() []string, restore func() string) func(event *tcell.EventKey) bool {
+
+	typed := ""
+	typedAt := 0 // Counts digits so an old timeout doesn't clear a newer number
+
+	return func(event *tcell.EventKey) bool {
+		r := event.Rune()
+		if event.Key() != tcell.KeyRune || r < '0' || r > '9' {
+			return false
+		}
+		typed += string(r)
+		typedAt++
+		digit := typedAt
+
+		message := fmt.Sprintf("Jump to job: %s", typed)
+		matched := false
+		for i, number := range jobNumbers() {
+			if strings.HasPrefix(number, typed) {
+				table.Select(i+1, 0)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			message += "  (no match)"
+		}
+		status.SetText(message)
+
+		time.AfterFunc(jobJumpTimeout, func() {
+			app.QueueUpdateDraw(func() {
+				if typedAt != digit {
+					return // Another digit was typed since
+				}
+				typed = ""
+				// Leave it alone if something else has replaced the message since
+				if status.GetText(false) == message {
+					status.SetText(restore())
+				}
+			})
+		})
+		return true
+	}
+}
+
+// projectNumbers returns the job number of each job, in order, for newJobJump
+func projectNumbers(jobs []models.Job) []string {
+	numbers := make([]string, len(jobs))
+	for i, job := range jobs {
+		numbers[i] = job.ProjectNumber
+	}
+	return numbers
+}
@@ -0,0 +1,18 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"lms-tui/pkg"
+)
+
+// noJobsMessage is what a job list shows when it has no rows. A missing projects folder is a
+// configuration problem, so it is reported as such; otherwise the list is just empty.
+func noJobsMessage(discoverErr error, empty string) (string, tcell.Color) {
+	if errors.Is(discoverErr, pkg.ErrProjectsDirMissing) {
+		return fmt.Sprintf("Projects folder not found at %s — check configuration", pkg.ProjectsDir()), tcell.ColorRed
+	}
+	return empty, tcell.ColorYellow
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"fmt"
+
+	"lms-tui/models"
+)
+
+// jobVersionLabels returns the job number to show for each of shown, grouping versions of
+// the same job: the first row of a group reads "25490 (3 versions)" and the rest are
+// indented under it. Versions are counted across all jobs, not just the rows shown.
+func jobVersionLabels(shown, all []models.Job) []string {
+	versions := map[string]int{}
+	for _, job := range all {
+		versions[job.BaseJobNumber]++
+	}
+
+	labels := make([]string, len(shown))
+	for i, job := range shown {
+		count := versions[job.BaseJobNumber]
+		switch {
+		case count < 2:
+			labels[i] = job.ProjectNumber
+		case i == 0 || shown[i-1].BaseJobNumber != job.BaseJobNumber:
+			labels[i] = fmt.Sprintf("%s (%d versions)", job.ProjectNumber, count)
+		default:
+			labels[i] = "└ " + job.ProjectNumber
+		}
+	}
+	return labels
+}
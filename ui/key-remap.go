@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// remapKey identifies a key press, either a special key or a printable rune
+type remapKey struct {
+	key tcell.Key
+	ch  rune
+}
+
+// screenKeys are runes the current screen handles itself; they skip the global remap
+var screenKeys map[rune]bool
+
+// KeepScreenKeys gives the current screen precedence over the global remap for these runes,
+// e.g. the pull screen uses '-' for edit last sample instead of arrow down.
+// The claim lasts until the next screen registers its shortcuts.
+func KeepScreenKeys(runes ...rune) {
+	screenKeys = map[rune]bool{}
+	for _, r := range runes {
+		screenKeys[r] = true
+	}
+}
+
+// textInputs are the free-text fields of the current screen (notes, reasons, names).
+// While one has focus, runes are typed as-is instead of acting as shortcuts.
+var textInputs map[*tview.InputField]bool
+
+// addTextField adds a free-text input to form and marks it so its runes skip the shortcuts.
+// Like screen keys, the mark lasts until the next screen registers its shortcuts.
+func addTextField(form *tview.Form, label, value string, fieldWidth int) *tview.InputField {
+	field := tview.NewInputField().
+		SetLabel(label).
+		SetText(value).
+		SetFieldWidth(fieldWidth)
+	form.AddFormItem(field)
+	if textInputs == nil {
+		textInputs = map[*tview.InputField]bool{}
+	}
+	textInputs[field] = true
+	return field
+}
+
+// TypingText reports whether the focused primitive is a free-text field added with addTextField
+func TypingText(app *tview.Application) bool {
+	field, ok := app.GetFocus().(*tview.InputField)
+	return ok && textInputs[field]
+}
+
+// parseRemapKey turns a config key name into a remapKey.
+// A single character is a rune; anything else must be a tcell key name such as "Up" or "Ctrl-J".
+func parseRemapKey(name string) (remapKey, error) {
+	if runes := []rune(name); len(runes) == 1 {
+		return remapKey{key: tcell.KeyRune, ch: runes[0]}, nil
+	}
+	for key, keyName := range tcell.KeyNames {
+		if strings.EqualFold(keyName, name) {
+			return remapKey{key: key}, nil
+		}
+	}
+	return remapKey{}, fmt.Errorf("unknown key name '%s'", name)
+}
+
+// NewKeyRemapCapture builds the global input capture from the configured remap rules.
+// Invalid rules are logged and skipped so a typo in config.json doesn't stop the app.
+func NewKeyRemapCapture(rules []pkg.KeyRemap) func(event *tcell.EventKey) *tcell.EventKey {
+	remaps := map[remapKey]remapKey{}
+	for _, rule := range rules {
+		from, err := parseRemapKey(rule.From)
+		if err != nil {
+			logger.Error.Printf("Ignoring key remap %s -> %s: %v", rule.From, rule.To, err)
+			continue
+		}
+		to, err := parseRemapKey(rule.To)
+		if err != nil {
+			logger.Error.Printf("Ignoring key remap %s -> %s: %v", rule.From, rule.To, err)
+			continue
+		}
+		remaps[from] = to
+		logger.Info.Printf("Key remap: %s -> %s", rule.From, rule.To)
+	}
+
+	return func(event *tcell.EventKey) *tcell.EventKey {
+		pressed := remapKey{key: event.Key()}
+		if event.Key() == tcell.KeyRune {
+			if screenKeys[event.Rune()] {
+				return event
+			}
+			pressed.ch = event.Rune()
+		}
+		to, ok := remaps[pressed]
+		if !ok {
+			return event
+		}
+		return tcell.NewEventKey(to.key, to.ch, tcell.ModNone)
+	}
+}
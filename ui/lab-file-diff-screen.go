@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/models"
+	"lms-tui/pkg"
+)
+
+// NewLabFileDiffScreen lists the cells that changed between a job's working Lab file and the
+// original snapshot, so techs can see exactly what the app has written
+func NewLabFileDiffScreen(app *tview.Application, user *pkg.User, job models.Job, onBack func()) (tview.Primitive, *tview.Table) {
+	SetScreenShortcuts("Compare to Original", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"+", "Back to Job Detail"},
+	})
+
+	logger.Info.Printf("Comparing Lab file to original for job %s", job.ProjectNumber)
+
+	table := tview.NewTable().
+		SetBorders(true).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	summaryText := tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetDynamicColors(true)
+
+	// Set headers
+	headers := []string{"Sheet", "Cell", "Original", "Current"}
+	for col, header := range headers {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorWhite).
+			SetAlign(tview.AlignCenter).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	changes, err := pkg.CompareLabFileToOriginal(job.ProjectNumber)
+	if err != nil {
+		logger.Error.Printf("Failed to compare Lab file to original: %v", err)
+		summaryText.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+	} else if len(changes) == 0 {
+		summaryText.SetText("[green]No cells changed since the original was saved[-]")
+	} else {
+		summaryText.SetText(fmt.Sprintf("[yellow]%d cells changed since the original was saved[-]", len(changes)))
+	}
+
+	for row, change := range changes {
+		table.SetCell(row+1, 0, tview.NewTableCell(change.Sheet).
+			SetTextColor(tcell.ColorWhite))
+		table.SetCell(row+1, 1, tview.NewTableCell(change.Cell).
+			SetAlign(tview.AlignCenter).
+			SetTextColor(tcell.ColorWhite))
+		table.SetCell(row+1, 2, tview.NewTableCell(change.Original).
+			SetTextColor(tcell.ColorGray).
+			SetExpansion(1))
+		table.SetCell(row+1, 3, tview.NewTableCell(change.Current).
+			SetTextColor(tcell.ColorYellow).
+			SetExpansion(1))
+	}
+
+	// Instructions text
+	instructions := tview.NewTextView().
+		SetText("Up/Down: Navigate  |  +: Back to Job Detail").
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorWhite)
+
+	// Container
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summaryText, 1, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(instructions, 1, 0, false)
+
+	container.SetBorder(true).
+		SetTitle(userTitle(fmt.Sprintf("Compare to Original - Job %s", job.ProjectNumber), user)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite)
+
+	// Center it
+	vertical := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(container, 0, 4, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(vertical, 0, 3, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '+' {
+			onBack()
+			return nil
+		}
+		return event
+	})
+
+	return horizontal, table
+}
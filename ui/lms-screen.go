@@ -1,20 +1,28 @@
 package ui
 
 import (
-	"lms-tui/logger"
-	"github.com/rivo/tview"
 	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
 )
 
 
-func NewLMSScreen(app *tview.Application, onBack func()) (tview.Primitive, *tview.List) {
+func NewLMSScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.List) {
+	SetScreenShortcuts("LMS", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"1-9", "Jump to menu item (3, 7 and 8 are for lab managers)"},
+		{"Enter", "Select"},
+		{"+", "Back to Home"},
+	})
+
 	list := tview.NewList().
 		AddItem("View Available Jobs", "View all available jobs", '1', func() {
 			logger.Info.Println("Navigating to View Jobs screen")
-			newJobScreen, newJobTable := NewViewJobScreen(app, func() {
+			newJobScreen, newJobTable := NewViewJobScreen(app, user, func() {
 				// Go back to LMS screen
 				logger.Info.Println("Returning to LMS screen from View Jobs")
-				lmsScreen, lmsList := NewLMSScreen(app, onBack)
+				lmsScreen, lmsList := NewLMSScreen(app, user, onBack)
 				app.SetRoot(lmsScreen, true)
 				app.SetFocus(lmsList)
 			})
@@ -23,55 +31,131 @@ func NewLMSScreen(app *tview.Application, onBack func()) (tview.Primitive, *tvie
 		}).
 		AddItem("Pull Job", "Pull a job from the queue", '2', func() {
 			logger.Info.Println("Navigating to Pull Job List screen")
-			pullJobScreen, pullJobTable := NewPullJobListScreen(app, func() {
+			pullJobScreen, pullJobTable := NewPullJobListScreen(app, user, func() {
 				// Go back to LMS screen
 				logger.Info.Println("Returning to LMS screen from Pull Job List")
-				lmsScreen, lmsList := NewLMSScreen(app, onBack)
+				lmsScreen, lmsList := NewLMSScreen(app, user, onBack)
 				app.SetRoot(lmsScreen, true)
 				app.SetFocus(lmsList)
 			})
 			app.SetRoot(pullJobScreen, true)
 			app.SetFocus(pullJobTable)
-		}).
-		AddItem("Edit Past Samples", "Edit moisture and suction data for past samples", '3', func() {
+		})
+
+	// Editing samples can delete data, so only lab managers see it
+	if user.IsManager() {
+		list.AddItem("Edit Past Samples", "Edit moisture and suction data for past samples", '3', func() {
 			logger.Info.Println("Navigating to Edit Samples (Job Selection)")
-			editJobScreen, editJobTable := NewEditJobSelectionScreen(app, func() {
+			editJobScreen, editJobTable := NewEditJobSelectionScreen(app, user, func() {
 				// Go back to LMS screen
 				logger.Info.Println("Returning to LMS screen from Edit Samples")
-				lmsScreen, lmsList := NewLMSScreen(app, onBack)
+				lmsScreen, lmsList := NewLMSScreen(app, user, onBack)
 				app.SetRoot(lmsScreen, true)
 				app.SetFocus(lmsList)
 			})
 			app.SetRoot(editJobScreen, true)
-			app.SetFocus(editJobTable)
+			if editJobTable != nil { // nil when the user was turned away
+				app.SetFocus(editJobTable)
+			}
+		})
+	}
+
+	list.AddItem("Morning Count", "Measure can weights in the morning", '4', func() {
+		logger.Info.Println("Navigating to Morning Count screen")
+		morningCountScreen := NewMorningCountScreen(app, user, func() {
+			// Go back to LMS screen
+			logger.Info.Println("Returning to LMS screen from Morning Count")
+			lmsScreen, lmsList := NewLMSScreen(app, user, onBack)
+			app.SetRoot(lmsScreen, true)
+			app.SetFocus(lmsList)
+		})
+		app.SetRoot(morningCountScreen, true)
+	}).
+		AddItem("Oven Status", "Lab-wide view of cans drying in the oven", '5', func() {
+			logger.Info.Println("Navigating to Oven Status screen")
+			ovenScreen, ovenTable := NewOvenDashboardScreen(app, user, func() {
+				// Go back to LMS screen
+				logger.Info.Println("Returning to LMS screen from Oven Status")
+				lmsScreen, lmsList := NewLMSScreen(app, user, onBack)
+				app.SetRoot(lmsScreen, true)
+				app.SetFocus(lmsList)
+			})
+			app.SetRoot(ovenScreen, true)
+			app.SetFocus(ovenTable)
 		}).
-		AddItem("Morning Count", "Measure can weights in the morning", '4', func() {
-			logger.Info.Println("Navigating to Morning Count screen")
-			morningCountScreen := NewMorningCountScreen(app, func() {
+		AddItem("Today's Work", "Samples entered today across all jobs", '6', func() {
+			logger.Info.Println("Navigating to Today's Work screen")
+			activityScreen, activityTable := NewDailyActivityScreen(app, user, func() {
+				// Go back to LMS screen
+				logger.Info.Println("Returning to LMS screen from Today's Work")
+				lmsScreen, lmsList := NewLMSScreen(app, user, onBack)
+				app.SetRoot(lmsScreen, true)
+				app.SetFocus(lmsList)
+			})
+			app.SetRoot(activityScreen, true)
+			app.SetFocus(activityTable)
+		})
+
+	if user.IsManager() {
+		list.AddItem("Diagnostics", "Check the environment (folders, config, Excel, oven file)", '7', func() {
+			logger.Info.Println("Navigating to Diagnostics screen")
+			diagnosticsScreen, diagnosticsTable := NewDiagnosticsScreen(app, user, func() {
 				// Go back to LMS screen
-				logger.Info.Println("Returning to LMS screen from Morning Count")
-				lmsScreen, lmsList := NewLMSScreen(app, onBack)
+				logger.Info.Println("Returning to LMS screen from Diagnostics")
+				lmsScreen, lmsList := NewLMSScreen(app, user, onBack)
 				app.SetRoot(lmsScreen, true)
 				app.SetFocus(lmsList)
 			})
-			app.SetRoot(morningCountScreen, true)
+			app.SetRoot(diagnosticsScreen, true)
+			if diagnosticsTable != nil { // nil when the user was turned away
+				app.SetFocus(diagnosticsTable)
+			}
+		}).
+			AddItem("Settings", "Change config.json settings", '8', func() {
+				logger.Info.Println("Navigating to Settings screen")
+				settingsScreen, settingsTable := NewSettingsScreen(app, user, func() {
+					// Go back to LMS screen
+					logger.Info.Println("Returning to LMS screen from Settings")
+					lmsScreen, lmsList := NewLMSScreen(app, user, onBack)
+					app.SetRoot(lmsScreen, true)
+					app.SetFocus(lmsList)
+				})
+				app.SetRoot(settingsScreen, true)
+				if settingsTable != nil { // nil when the user was turned away
+					app.SetFocus(settingsTable)
+				}
+			})
+	}
+
+	list.AddItem("Find Can", "Find which job and sample a can belongs to", '9', func() {
+		logger.Info.Println("Navigating to Find Can screen")
+		lookupScreen, lookupField := NewCanLookupScreen(app, user, func() {
+			// Go back to LMS screen
+			logger.Info.Println("Returning to LMS screen from Find Can")
+			lmsScreen, lmsList := NewLMSScreen(app, user, onBack)
+			app.SetRoot(lmsScreen, true)
+			app.SetFocus(lmsList)
 		})
+		app.SetRoot(lookupScreen, true)
+		app.SetFocus(lookupField)
+	})
 
 	// Container with textview and list
 	container := tview.NewFlex().
-		SetDirection(tview.FlexRow).
-		AddItem(tview.NewTextView().SetText("LMS Screen").SetTextAlign(tview.AlignCenter), 1, 0, false).
+		SetDirection(tview.FlexRow)
+	AddTrainingBanner(container)
+	container.AddItem(tview.NewTextView().SetText("LMS Screen").SetTextAlign(tview.AlignCenter), 1, 0, false).
 		AddItem(list, 0, 1, true)
 
 	container.SetBorder(true).
-		SetTitle(" LMS ").
+		SetTitle(userTitle("LMS", user)).
 		SetTitleAlign(tview.AlignCenter)
 
 	// Center it
 	vertical := tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(nil, 0, 1, false).
-		AddItem(container, 10, 1, true).
+		AddItem(container, 22, 1, true).
 		AddItem(nil, 0, 1, false)
 
 	horizontal := tview.NewFlex().
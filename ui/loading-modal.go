@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+)
+
+// loadingSpinner frames, advanced every loadingTick while the load runs
+var loadingSpinner = []string{"|", "/", "-", "\\"}
+
+const loadingTick = 200 * time.Millisecond
+
+// newLoadingModal runs load off the UI goroutine and shows message with a spinner and the
+// time taken so far, so a slow Lab file doesn't look like a frozen screen. When load
+// returns, show gets its result on the UI goroutine and must set the next root. A panic in
+// load is logged and reaches show as an error. '+' or Esc cancels: onCancel runs straight
+// away and the result is dropped when it arrives, since the parse itself can't be
+// interrupted. A dropped result that is an io.Closer is closed.
+func newLoadingModal[T any](app *tview.Application, message string, load func() (T, error), show func(T, error), onCancel func()) *tview.Modal {
+	modal := tview.NewModal()
+	modal.SetBackgroundColor(tcell.ColorBlack)
+
+	started := time.Now()
+	frame := 0
+	setText := func() {
+		modal.SetText(fmt.Sprintf("%s %s\n\n%ds\n\nPress + or Esc to cancel",
+			loadingSpinner[frame%len(loadingSpinner)], message, int(time.Since(started).Seconds())))
+	}
+	setText()
+
+	// finished is closed by whichever of the load or a cancel happens first
+	var once sync.Once
+	finished := make(chan struct{})
+	finish := func() bool {
+		first := false
+		once.Do(func() {
+			first = true
+			close(finished)
+		})
+		return first
+	}
+
+	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '+' || event.Key() == tcell.KeyEscape {
+			if finish() {
+				logger.Info.Printf("Cancelled after %v: %s", time.Since(started).Round(time.Millisecond), message)
+				onCancel()
+			}
+			return nil
+		}
+		return nil // The modal has no buttons, so other keys do nothing
+	})
+
+	go func() {
+		ticker := time.NewTicker(loadingTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-finished:
+				return
+			case <-ticker.C:
+				app.QueueUpdateDraw(func() {
+					frame++
+					setText()
+				})
+			}
+		}
+	}()
+
+	go func() {
+		var result T
+		var err error
+		func() {
+			// A panic here would take the app down without the crash recovery in main
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Error.Printf("PANIC while loading (%s): %v\n%s", message, r, debug.Stack())
+					err = fmt.Errorf("unexpected error while loading: %v", r)
+				}
+			}()
+			result, err = load()
+		}()
+		app.QueueUpdateDraw(func() {
+			if !finish() {
+				// Cancelled while loading
+				if closer, ok := any(result).(io.Closer); ok {
+					closer.Close()
+				}
+				return
+			}
+			logger.Info.Printf("Finished in %v: %s", time.Since(started).Round(time.Millisecond), message)
+			show(result, err)
+		})
+	}()
+
+	return modal
+}
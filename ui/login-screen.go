@@ -8,6 +8,9 @@ import (
 )
 
 func NewLoginScreen(app *tview.Application, onLogin func(userID, pin string)) tview.Primitive {
+	SetScreenShortcuts("Login", []Shortcut{
+		{"Enter", "Next field / log in"},
+	})
 
 	var userID, pin string
 
@@ -32,9 +35,11 @@ func NewLoginScreen(app *tview.Application, onLogin func(userID, pin string)) tv
 		SetTitle(" LMS Login ").
 		SetTitleAlign(tview.AlignCenter)
 
-		form.GetFormItem(1).(*tview.InputField).SetAcceptanceFunc(func(textToCheck string, lastChar rune) bool {
+	if pinField, ok := form.GetFormItem(1).(*tview.InputField); ok {
+		pinField.SetAcceptanceFunc(func(textToCheck string, lastChar rune) bool {
 			return lastChar >= '0' && lastChar <= '9'
 		})
+	}
 
 	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		// Log all key presses
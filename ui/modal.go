@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Confirm shows text in a modal with one button per option and calls onChoice with the index
+// of the option picked. Number keys 1..n pick an option and Esc picks the last one, so list
+// the option that backs out (Cancel, Go Back) last. onChoice must set the next root.
+func Confirm(app *tview.Application, text string, options []string, onChoice func(index int)) {
+	app.SetRoot(newChoiceModal(text, options, onChoice), true)
+}
+
+// Alert shows text in a modal with an OK button. Dismissing it (Enter, 1 or Esc) restores
+// returnTo as the root and focuses focus (if not nil).
+func Alert(app *tview.Application, text string, returnTo tview.Primitive, focus tview.Primitive) {
+	app.SetRoot(newAlertModal(text, func() {
+		app.SetRoot(returnTo, true)
+		if focus != nil {
+			app.SetFocus(focus)
+		}
+	}), true)
+}
+
+// newChoiceModal builds the modal Confirm shows, for screens that return it as their root
+// instead of showing it straight away. The [1]/[2] hint line is added to text here so it
+// always matches the buttons.
+func newChoiceModal(text string, options []string, onChoice func(index int)) *tview.Modal {
+	hints := make([]string, len(options))
+	for i, option := range options {
+		hints[i] = fmt.Sprintf("[%d] %s", i+1, option)
+	}
+	cancel := len(options) - 1
+
+	modal := tview.NewModal().
+		SetText(text + "\n\n" + strings.Join(hints, "    ")).
+		AddButtons(options).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			if buttonIndex < 0 {
+				buttonIndex = cancel
+			}
+			onChoice(buttonIndex)
+		})
+	modal.SetBackgroundColor(tcell.ColorBlack)
+	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			onChoice(cancel)
+			return nil
+		}
+		if r := event.Rune(); r >= '1' && int(r-'1') < len(options) {
+			onChoice(int(r - '1'))
+			return nil
+		}
+		return event
+	})
+	return modal
+}
+
+// newAlertModal builds a modal with an OK button that calls onDismiss, for screens that
+// return it as their root or need more than a focus change when it is dismissed
+func newAlertModal(text string, onDismiss func()) *tview.Modal {
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			onDismiss()
+		})
+	modal.SetBackgroundColor(tcell.ColorBlack)
+	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '1' || event.Key() == tcell.KeyEscape {
+			onDismiss()
+			return nil
+		}
+		return event
+	})
+	return modal
+}
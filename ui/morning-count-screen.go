@@ -1,8 +1,10 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -10,11 +12,22 @@ import (
 	"lms-tui/pkg"
 )
 
-func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitive {
+func NewMorningCountScreen(app *tview.Application, user *pkg.User, onBack func()) tview.Primitive {
+	SetScreenShortcuts("Morning Count", []Shortcut{
+		{"Enter", "Next field / save dry weight"},
+		{"Tab", "Next field"},
+		{"/", "Toggle walk mode (step through oven cans)"},
+		{"F2", "Select the oven you are weighing from"},
+		{"F3", "Show only cans put in recently / all cans"},
+		{"F4", "Show one oven batch at a time / all batches"},
+		{"+", "Back to menu"},
+	})
+
 	logger.Info.Println("Opening Morning Count screen")
 
 	// Load cans currently in oven
 	cansInOven, err := pkg.GetCansInOven()
+	loadErr := err
 	if err != nil {
 		logger.Error.Printf("Failed to load oven tracking: %v", err)
 		cansInOven = []pkg.OvenCanData{}
@@ -25,18 +38,81 @@ func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitiv
 		SetDynamicColors(true).
 		SetScrollable(false)
 
+	// Walk mode presents cans one at a time so the tech only types the dry weight
+	walkMode := false
+	walkIndex := 0
+
+	// Oven being weighed from; "" means no oven selected (single-oven labs)
+	selectedOven := pkg.Config.WorkstationOven
+
+	// When the oven holds several days of samples, the list and walk mode can be narrowed to
+	// the cans put in within recent_oven_hours. Typing a can number still finds any can.
+	recentOnly := false
+	// Cans tagged as a batch on the pull screen are listed together, and F4 steps through
+	// the batches to show one at a time; "" shows every batch
+	batchFilter := ""
+	// shown is the filtered list as of the last F3/F4, so walk mode's index keeps pointing
+	// at the can on screen even if a can ages out of the recent window meanwhile
+	var shown []pkg.OvenCanData
+	refreshShown := func() {
+		cans := cansInOven
+		if recentOnly {
+			cans = pkg.CansAddedWithin(cans, pkg.Config.RecentOvenHours, time.Now())
+		}
+		if batchFilter != "" {
+			shown = pkg.CansInBatch(cans, batchFilter)
+		} else {
+			shown = pkg.GroupCansByBatch(cans)
+		}
+	}
+	refreshShown()
+	canListTitle := func() string {
+		if !recentOnly && batchFilter == "" {
+			return fmt.Sprintf(" Cans in Oven (%d) ", len(cansInOven))
+		}
+		filters := []string{}
+		if batchFilter != "" {
+			filters = append(filters, "Batch "+batchFilter)
+		}
+		if recentOnly {
+			filters = append(filters, fmt.Sprintf("In Last %dh", pkg.Config.RecentOvenHours))
+		}
+		return fmt.Sprintf(" Cans %s (%d of %d) ", tview.Escape(strings.Join(filters, ", ")), len(shown), len(cansInOven))
+	}
+
 	updateCanList := func() {
 		var listContent strings.Builder
+		cans := shown
 		if len(cansInOven) == 0 {
 			listContent.WriteString("[gray]No cans in oven[-]")
+		} else if len(cans) == 0 && batchFilter != "" {
+			listContent.WriteString(fmt.Sprintf("[gray]No cans shown from batch %s\n\nF4: Next batch[-]", tview.Escape(batchFilter)))
+		} else if len(cans) == 0 {
+			listContent.WriteString(fmt.Sprintf("[gray]No cans put in within the last %d hours\n\nF3: Show all cans[-]", pkg.Config.RecentOvenHours))
 		} else {
-			for i, can := range cansInOven {
-				listContent.WriteString(fmt.Sprintf("[yellow]%d.[-] Can #[white]%s[-]\n", i+1, can.CanNumber))
+			batched := len(pkg.OvenBatchIDs(cans)) > 0
+			for i, can := range cans {
+				// Head each batch's group once any shown can belongs to a batch
+				if batched && (i == 0 || can.BatchID != cans[i-1].BatchID) {
+					if can.BatchID == "" {
+						listContent.WriteString("[aqua]── No batch ──[-]\n")
+					} else {
+						listContent.WriteString(fmt.Sprintf("[aqua]── Batch %s (started %s) ──[-]\n", tview.Escape(can.BatchID), can.BatchStartedAt))
+					}
+				}
+				if walkMode && i == walkIndex {
+					listContent.WriteString(fmt.Sprintf("[green]▶ %d.[-] Can #[green]%s[-]\n", i+1, can.CanNumber))
+				} else {
+					listContent.WriteString(fmt.Sprintf("[yellow]%d.[-] Can #[white]%s[-]\n", i+1, can.CanNumber))
+				}
 				listContent.WriteString(fmt.Sprintf("   Job: %s\n", can.JobNumber))
 				listContent.WriteString(fmt.Sprintf("   Boring: %s\n", can.BoringNumber))
 				listContent.WriteString(fmt.Sprintf("   Depth: %s\n", can.Depth))
 				listContent.WriteString(fmt.Sprintf("   Time In: %s\n", can.TimeIn))
-				if i < len(cansInOven)-1 {
+				if can.OvenID != "" {
+					listContent.WriteString(fmt.Sprintf("   Oven: %s\n", can.OvenID))
+				}
+				if i < len(cans)-1 {
 					listContent.WriteString("\n")
 				}
 			}
@@ -50,7 +126,7 @@ func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitiv
 		AddItem(canListText, 0, 1, false)
 
 	canListBox.SetBorder(true).
-		SetTitle(fmt.Sprintf(" Cans in Oven (%d) ", len(cansInOven))).
+		SetTitle(canListTitle()).
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorWhite).
 		SetBackgroundColor(tcell.ColorBlack)
@@ -58,6 +134,30 @@ func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitiv
 	// ===== RIGHT BOX - Input form =====
 	form := tview.NewForm()
 
+	// Current can context (walk mode) or mode hint (manual mode)
+	currentCanText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+	currentCanText.SetBackgroundColor(tcell.ColorBlack)
+
+	updateCurrentCan := func() {
+		if !walkMode {
+			currentCanText.SetText("[gray]Manual lookup - type the can number[-]")
+			return
+		}
+		cans := shown
+		if len(cans) == 0 {
+			currentCanText.SetText("[green]All cans weighed[-]")
+			return
+		}
+		can := cans[walkIndex]
+		currentCanText.SetText(fmt.Sprintf(
+			"Can [yellow]%d[-] of [yellow]%d[-]\n"+
+				"Can #: [green]%s[-]\n"+
+				"Job: %s  Boring: %s  Depth: %s",
+			walkIndex+1, len(cans), can.CanNumber, can.JobNumber, can.BoringNumber, can.Depth))
+	}
+
 	// Status text to show results
 	statusText := tview.NewTextView().
 		SetDynamicColors(true).
@@ -71,38 +171,55 @@ func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitiv
 		statusText.SetText(fmt.Sprintf("%s\n\nCompleted: %d / %d", message, completedCount, len(cansInOven)))
 	}
 	updateStatus("Enter can number and dry weight")
+	updateCurrentCan()
 
 	// Declare container early for modal references
 	var container *tview.Flex
 
 	// Helper to show error modal
 	showErrorModal := func(message string, focusField tview.FormItem) {
-		modal := tview.NewModal().
-			SetText(message).
-			AddButtons([]string{"OK"}).
-			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				app.SetRoot(container, true)
-				if focusField != nil {
-					app.SetFocus(focusField)
-				} else {
-					app.SetFocus(form)
-				}
-			})
-		modal.SetBackgroundColor(tcell.ColorBlack)
-		app.SetRoot(modal, true)
+		var focus tview.Primitive = form
+		if focusField != nil {
+			focus = focusField
+		}
+		Alert(app, message, container, focus)
 	}
 
+	// Declared early so saveDryWeight can hand off after the oven check
+	var commitDryWeight func(foundCan pkg.OvenCanData, dryWeight string, canNumField, dryWeightField *tview.InputField)
+
 	// Save function
 	saveDryWeight := func() {
-		canNumField := form.GetFormItemByLabel("Can #").(*tview.InputField)
-		dryWeightField := form.GetFormItemByLabel("Dry Weight (g)").(*tview.InputField)
-
-		canNum := strings.TrimSpace(canNumField.GetText())
-		dryWeight := strings.TrimSpace(dryWeightField.GetText())
+		dryWeightField := formInput(form, "Dry Weight (g)")
+		if dryWeightField == nil {
+			showErrorModal(missingFieldError("Dry Weight (g)").Error(), nil)
+			return
+		}
+		dryWeight := pkg.NormalizeWeight(dryWeightField.GetText())
+
+		// In walk mode the can comes from the list; otherwise it is typed in
+		var canNumField *tview.InputField
+		var canFocus tview.FormItem = dryWeightField
+		canNum := ""
+		if walkMode {
+			cans := shown
+			if len(cans) == 0 {
+				return
+			}
+			canNum = cans[walkIndex].CanNumber
+		} else {
+			canNumField = formInput(form, "Can #")
+			if canNumField == nil {
+				showErrorModal(missingFieldError("Can #").Error(), nil)
+				return
+			}
+			canFocus = canNumField
+			canNum = strings.TrimSpace(canNumField.GetText())
+		}
 
 		// Validate inputs
 		if canNum == "" {
-			showErrorModal("Can # is required", canNumField)
+			showErrorModal("Can # is required", canFocus)
 			return
 		}
 		if dryWeight == "" {
@@ -110,6 +227,14 @@ func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitiv
 			return
 		}
 
+		// Validate can number against the configured allowed range
+		if err := pkg.ValidateCanNumber(canNum); err != nil {
+			logger.Error.Printf("Validation failed: %v", err)
+			showErrorModal(fmt.Sprintf("Can # %s is out of range.\n\nAllowed can numbers: %d - %d\n\nPlease check the can number.",
+				canNum, pkg.Config.CanNumberMin, pkg.Config.CanNumberMax), canFocus)
+			return
+		}
+
 		// Find the can in the oven
 		var foundCan *pkg.OvenCanData
 		for i := range cansInOven {
@@ -120,52 +245,240 @@ func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitiv
 		}
 
 		if foundCan == nil {
-			showErrorModal(fmt.Sprintf("Can # %s is not in the oven.\n\nPlease check the can number.", canNum), canNumField)
+			showErrorModal(fmt.Sprintf("Can # %s is not in the oven.\n\nPlease check the can number.", canNum), canFocus)
 			return
 		}
 
+		// Warn (but allow) when the can is tracked in a different oven than the one selected,
+		// e.g. it was physically moved without updating tracking
+		if selectedOven != "" {
+			if _, trackedCan, err := pkg.IsCanInOven(canNum); err == nil && trackedCan != nil &&
+				trackedCan.OvenID != "" && trackedCan.OvenID != selectedOven {
+				logger.Info.Printf("Can %s is tracked in oven %s but Morning Count is on oven %s", canNum, trackedCan.OvenID, selectedOven)
+				can := *foundCan
+				recordAnyway := func() {
+					logger.Info.Printf("User recorded can %s from oven %s while on oven %s", canNum, trackedCan.OvenID, selectedOven)
+					app.SetRoot(container, true)
+					commitDryWeight(can, dryWeight, canNumField, dryWeightField)
+				}
+				cancel := func() {
+					app.SetRoot(container, true)
+					app.SetFocus(canFocus)
+				}
+				Confirm(app, fmt.Sprintf("⚠️ Can # %s is tracked in Oven %s\n\n"+
+					"You are weighing from Oven %s.\n"+
+					"Job: %s  Boring: %s  Depth: %s\n\n"+
+					"Record the dry weight anyway?",
+					canNum, trackedCan.OvenID, selectedOven, can.JobNumber, can.BoringNumber, can.Depth), []string{"Record Anyway", "Cancel"}, func(choice int) {
+					if choice == 0 {
+						recordAnyway()
+					} else {
+						cancel()
+					}
+				})
+				return
+			}
+		}
+
+		commitDryWeight(*foundCan, dryWeight, canNumField, dryWeightField)
+	}
+
+	// Take a can off the list once it has left the oven tracking
+	forgetCan := func(canNum string) {
+		newCans := []pkg.OvenCanData{}
+		for _, can := range cansInOven {
+			if can.CanNumber != canNum {
+				newCans = append(newCans, can)
+			}
+		}
+		cansInOven = newCans
+		shownLeft := []pkg.OvenCanData{}
+		for _, can := range shown {
+			if can.CanNumber != canNum {
+				shownLeft = append(shownLeft, can)
+			}
+		}
+		shown = shownLeft
+
+		// In walk mode the next can slides into the current position
+		if walkIndex >= len(shown) {
+			walkIndex = 0
+		}
+		updateCanList()
+		updateCurrentCan()
+		canListBox.SetTitle(canListTitle())
+	}
+
+	// The can's job has no working Lab file any more (cleaned up or moved while the can was
+	// in the oven), so offer to drop the oven entry instead of leaving it to come up every morning
+	offerDropOrphanCan := func(foundCan pkg.OvenCanData, dryWeightField *tview.InputField) {
+		dropCan := func() {
+			app.SetRoot(container, true)
+			if _, err := pkg.DropOrphanCan(foundCan.CanNumber, user.Name); err != nil {
+				ShowError(app, fmt.Errorf("failed to drop can #%s from the oven: %v", foundCan.CanNumber, err), container, form.GetFormItem(0))
+				return
+			}
+			forgetCan(foundCan.CanNumber)
+			dryWeightField.SetText("")
+			updateStatus(fmt.Sprintf("[yellow]Dropped Can #%s from the oven (job %s has no working file)[-]", foundCan.CanNumber, foundCan.JobNumber))
+			app.SetFocus(form.GetFormItem(0))
+		}
+		keepCan := func() {
+			app.SetRoot(container, true)
+			app.SetFocus(form.GetFormItem(0))
+		}
+		Confirm(app, fmt.Sprintf("⚠️ Job %s's working Lab file is missing\n\n%s\n\n"+
+			"The job may have been cleaned up or moved while Can # %s (Boring: %s, Depth: %s) was in the oven, "+
+			"so the dry weight can't be recorded.",
+			foundCan.JobNumber, pkg.WorkingLabFilePath(foundCan.JobNumber), foundCan.CanNumber, foundCan.BoringNumber, foundCan.Depth), []string{"Drop Oven Entry", "Keep It"}, func(choice int) {
+			if choice == 0 {
+				dropCan()
+			} else {
+				keepCan()
+			}
+		})
+	}
+
+	// Write the dry weight for a can and take it out of the oven
+	writeDryWeight := func(foundCan pkg.OvenCanData, dryWeight string, canNumField, dryWeightField *tview.InputField) {
+		canNum := foundCan.CanNumber
+
 		// Write dry weight to moisture sheet
-		if err := pkg.WriteDryWeightToMoistureSheet(*foundCan, dryWeight); err != nil {
+		moistureContent, err := pkg.WriteDryWeightToMoistureSheet(foundCan, dryWeight)
+		if errors.Is(err, pkg.ErrWorkingFileMissing) {
+			offerDropOrphanCan(foundCan, dryWeightField)
+			return
+		}
+		if err != nil {
 			logger.Error.Printf("Failed to write dry weight to moisture sheet: %v", err)
 			showErrorModal(fmt.Sprintf("Failed to save dry weight:\n%v", err), nil)
 			return
 		}
 
+		// Record the dry weight in the job's backup so it can be corrected later
+		var saveErrs []error
+		if err := pkg.UpdateSampleDryWeight(foundCan.JobNumber, foundCan.BoringNumber, foundCan.Depth, dryWeight, user.AuditID()); err != nil {
+			logger.Error.Printf("Failed to record dry weight in backup: %v", err)
+			saveErrs = append(saveErrs, fmt.Errorf("dry weight not recorded in backup: %v", err))
+		}
+
 		// Remove can from oven
 		if _, err := pkg.RemoveCanFromOven(canNum); err != nil {
 			logger.Error.Printf("Failed to remove can from oven: %v", err)
+			saveErrs = append(saveErrs, fmt.Errorf("can not removed from oven tracking: %v", err))
 		}
 
 		logger.Info.Printf("Saved dry weight for can %s: %s g (Job: %s, Boring: %s, Depth: %s)",
 			canNum, dryWeight, foundCan.JobNumber, foundCan.BoringNumber, foundCan.Depth)
 
 		// Update the cans list
-		newCans := []pkg.OvenCanData{}
-		for _, can := range cansInOven {
-			if can.CanNumber != canNum {
-				newCans = append(newCans, can)
-			}
-		}
-		cansInOven = newCans
-		updateCanList()
-		canListBox.SetTitle(fmt.Sprintf(" Cans in Oven (%d) ", len(cansInOven)))
+		forgetCan(canNum)
 
 		// Clear inputs for next entry
-		canNumField.SetText("")
+		if canNumField != nil {
+			canNumField.SetText("")
+		}
 		dryWeightField.SetText("")
 
 		// Update status
 		completedCount++
-		updateStatus(fmt.Sprintf("[green]Saved Can #%s: %s g[-]", canNum, dryWeight))
+		statusMessage := fmt.Sprintf("[green]Saved Can #%s: %s g[-]\nMoisture Content: [white]%.1f%%[-]", canNum, dryWeight, moistureContent)
+		if maxMoisture := pkg.Config.MoistureContentWarnMax; maxMoisture > 0 && moistureContent > maxMoisture {
+			statusMessage += fmt.Sprintf("\n[yellow]Saved above %.0f%% after confirming[-]", maxMoisture)
+		}
+		updateStatus(statusMessage)
+
+		// Focus back to the first field
+		app.SetFocus(form.GetFormItem(0))
+
+		if len(saveErrs) > 0 {
+			ShowError(app, fmt.Errorf("dry weight for can #%s was written to Excel, but:\n\n%v", canNum, errors.Join(saveErrs...)),
+				container, form.GetFormItem(0))
+		}
+	}
+
+	// Check the result against the configured maximum before writing. Organic soils can
+	// legitimately run high, so this only asks; the tech can save anyway.
+	commitDryWeight = func(foundCan pkg.OvenCanData, dryWeight string, canNumField, dryWeightField *tview.InputField) {
+		maxMoisture := pkg.Config.MoistureContentWarnMax
+		if maxMoisture <= 0 {
+			writeDryWeight(foundCan, dryWeight, canNumField, dryWeightField)
+			return
+		}
+
+		// Impossible weights are rejected when writing, so let that report them
+		moistureContent, err := pkg.PreviewMoistureContent(foundCan, dryWeight)
+		if err != nil || moistureContent <= maxMoisture {
+			writeDryWeight(foundCan, dryWeight, canNumField, dryWeightField)
+			return
+		}
+
+		logger.Info.Printf("Moisture content %.1f%% for can %s exceeds the %.1f%% maximum, asking to confirm",
+			moistureContent, foundCan.CanNumber, maxMoisture)
+		saveAnyway := func() {
+			logger.Info.Printf("User overrode moisture content warning for can %s (Job: %s, %s %s): %.1f%% > %.1f%%, dry weight %s g",
+				foundCan.CanNumber, foundCan.JobNumber, foundCan.BoringNumber, foundCan.Depth, moistureContent, maxMoisture, dryWeight)
+			app.SetRoot(container, true)
+			writeDryWeight(foundCan, dryWeight, canNumField, dryWeightField)
+		}
+		reweigh := func() {
+			logger.Info.Printf("User chose to re-weigh can %s after moisture content warning", foundCan.CanNumber)
+			app.SetRoot(container, true)
+			dryWeightField.SetText("")
+			app.SetFocus(dryWeightField)
+		}
+		Confirm(app, fmt.Sprintf("⚠️ Moisture Content %.1f%% is above %.0f%%\n\n"+
+			"Can # %s  Dry Wt %s g\n"+
+			"Job: %s  Boring: %s  Depth: %s\n\n"+
+			"This usually means a weight was mis-entered.\n"+
+			"Organic soils can run this high.",
+			moistureContent, maxMoisture, foundCan.CanNumber, dryWeight,
+			foundCan.JobNumber, foundCan.BoringNumber, foundCan.Depth), []string{"Save Anyway", "Re-weigh"}, func(choice int) {
+			if choice == 0 {
+				saveAnyway()
+			} else {
+				reweigh()
+			}
+		})
+	}
+
+	// Skip the current can in walk mode (e.g., not dry yet)
+	skipCan := func() {
+		cans := shown
+		if len(cans) == 0 {
+			return
+		}
+		logger.Info.Printf("Skipped can %s in walk mode", cans[walkIndex].CanNumber)
+		walkIndex = (walkIndex + 1) % len(cans)
+		updateCanList()
+		updateCurrentCan()
+		if dryWeightField := formInput(form, "Dry Weight (g)"); dryWeightField != nil {
+			dryWeightField.SetText("")
+		}
+		app.SetFocus(form.GetFormItem(0))
+	}
 
-		// Focus back to can number field
-		app.SetFocus(canNumField)
+	// Helper to rebuild form for the current mode
+	rebuildForm := func() {
+		form.Clear(true)
+		if !walkMode {
+			form.AddInputField("Can #", "", 20, nil, nil)
+		}
+		// Check the normalized text so a numpad's decimal comma can be typed
+		form.AddInputField("Dry Weight (g)", "", 20, func(text string, ch rune) bool {
+			return tview.InputFieldFloat(pkg.NormalizeWeight(text), ch)
+		}, nil)
+		form.AddButton("Save", saveDryWeight)
+		if walkMode {
+			form.AddButton("Skip Can", skipCan)
+		}
+
+		// Start on the first input whenever the form gains focus, including first render
+		form.SetFocus(0)
 	}
 
-	// Add input fields
-	form.AddInputField("Can #", "", 20, nil, nil)
-	form.AddInputField("Dry Weight (g)", "", 20, tview.InputFieldFloat, nil)
-	form.AddButton("Save", saveDryWeight)
+	// Initial form build
+	rebuildForm()
 
 	// Handle Enter key to move between fields
 	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
@@ -175,6 +488,10 @@ func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitiv
 				saveDryWeight()
 				return nil
 			}
+			if walkMode && form.GetButton(1) != nil && app.GetFocus() == form.GetButton(1) {
+				skipCan()
+				return nil
+			}
 
 			// Move to next field
 			currentIndex, _ := form.GetFocusedItemIndex()
@@ -207,15 +524,24 @@ func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitiv
 	// Right box containing form and status
 	rightBox := tview.NewFlex().
 		SetDirection(tview.FlexRow).
+		AddItem(currentCanText, 3, 0, false).
 		AddItem(form, 10, 0, true).
 		AddItem(statusText, 0, 1, false)
 
 	rightBox.SetBorder(true).
-		SetTitle(" Enter Dry Weight ").
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorWhite).
 		SetBackgroundColor(tcell.ColorBlack)
 
+	updateOvenTitle := func() {
+		if selectedOven == "" {
+			rightBox.SetTitle(" Enter Dry Weight ")
+		} else {
+			rightBox.SetTitle(fmt.Sprintf(" Enter Dry Weight - Oven %s ", selectedOven))
+		}
+	}
+	updateOvenTitle()
+
 	// ===== MAIN LAYOUT =====
 	mainContent := tview.NewFlex().
 		SetDirection(tview.FlexColumn).
@@ -224,23 +550,29 @@ func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitiv
 
 	// Instructions
 	instructions := tview.NewTextView().
-		SetText("Tab: Next Field  |  Enter: Save  |  +: Back to Menu").
+		SetText("Tab: Next Field  |  Enter: Save  |  /: Toggle Walk Mode  |  F2: Select Oven  |  F3: Recent Cans  |  F4: Batch  |  +: Back to Menu").
 		SetTextAlign(tview.AlignCenter).
 		SetDynamicColors(true).
 		SetBackgroundColor(tcell.ColorBlack)
 
 	// Container
 	container = tview.NewFlex().
-		SetDirection(tview.FlexRow).
-		AddItem(mainContent, 0, 1, true).
+		SetDirection(tview.FlexRow)
+	AddTrainingBanner(container)
+	container.AddItem(mainContent, 0, 1, true).
 		AddItem(instructions, 1, 0, false)
 
 	container.SetBorder(true).
-		SetTitle(" Morning Count - Dry Weights ").
+		SetTitle(userTitle("Morning Count - Dry Weights", user)).
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorWhite).
 		SetBackgroundColor(tcell.ColorBlack)
 
+	if loadErr != nil {
+		QueueError(app, fmt.Errorf("failed to load cans in the oven: %v", loadErr), container, form)
+	}
+	QueueOvenRecoveryNotice(app, container, form)
+
 	// Back navigation
 	container.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Rune() == '+' {
@@ -248,6 +580,91 @@ func NewMorningCountScreen(app *tview.Application, onBack func()) tview.Primitiv
 			onBack()
 			return nil
 		}
+		if event.Key() == tcell.KeyF2 {
+			// Cycle through the configured ovens ("" = no oven check)
+			if len(pkg.Config.Ovens) == 0 {
+				updateStatus("[gray]No ovens configured - add \"ovens\" to config.json[-]")
+				return nil
+			}
+			choices := append([]string{""}, pkg.Config.Ovens...)
+			next := 0
+			for i, oven := range choices {
+				if oven == selectedOven {
+					next = (i + 1) % len(choices)
+					break
+				}
+			}
+			selectedOven = choices[next]
+			logger.Info.Printf("Morning Count oven selected: %q", selectedOven)
+			updateOvenTitle()
+			if selectedOven == "" {
+				updateStatus("No oven selected - cans from any oven are accepted")
+			} else {
+				updateStatus(fmt.Sprintf("Weighing from Oven %s", selectedOven))
+			}
+			return nil
+		}
+		if event.Key() == tcell.KeyF3 {
+			recentOnly = !recentOnly
+			walkIndex = 0
+			refreshShown()
+			logger.Info.Printf("Morning Count showing recent cans only: %v", recentOnly)
+			updateCanList()
+			updateCurrentCan()
+			canListBox.SetTitle(canListTitle())
+			if recentOnly {
+				updateStatus(fmt.Sprintf("Showing %d of %d cans, put in within the last %d hours",
+					len(shown), len(cansInOven), pkg.Config.RecentOvenHours))
+			} else {
+				updateStatus("Showing all cans in the oven")
+			}
+			return nil
+		}
+		if event.Key() == tcell.KeyF4 {
+			batches := append([]string{""}, pkg.OvenBatchIDs(cansInOven)...)
+			if len(batches) == 1 {
+				updateStatus("No cans in the oven are tagged with a batch")
+				return nil
+			}
+			next := 0
+			for i, id := range batches {
+				if id == batchFilter {
+					next = (i + 1) % len(batches)
+					break
+				}
+			}
+			batchFilter = batches[next]
+			walkIndex = 0
+			refreshShown()
+			logger.Info.Printf("Morning Count batch filter: %q", batchFilter)
+			updateCanList()
+			updateCurrentCan()
+			canListBox.SetTitle(canListTitle())
+			if batchFilter != "" {
+				updateStatus(fmt.Sprintf("Showing batch %s (%d cans)", batchFilter, len(shown)))
+			} else {
+				updateStatus("Showing all batches")
+			}
+			return nil
+		}
+		if event.Rune() == '/' {
+			// Toggle between walking through the oven list and manual can lookup
+			walkMode = !walkMode
+			if walkIndex >= len(shown) {
+				walkIndex = 0
+			}
+			logger.Info.Printf("Morning Count walk mode: %v", walkMode)
+			rebuildForm()
+			updateCanList()
+			updateCurrentCan()
+			if walkMode {
+				updateStatus("Enter the dry weight for the highlighted can")
+			} else {
+				updateStatus("Enter can number and dry weight")
+			}
+			app.SetFocus(form.GetFormItem(0))
+			return nil
+		}
 		return event
 	})
 
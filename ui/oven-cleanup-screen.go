@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// NewOvenCleanupScreen lets lab managers clear cans that left the oven without a dry weight
+// being recorded, so they stop showing up in Morning Count
+func NewOvenCleanupScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.Table) {
+	if denied := managerOnly(app, user, "Clear Stale Cans", onBack); denied != nil {
+		return denied, nil
+	}
+
+	SetScreenShortcuts("Clear Stale Cans", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"Enter/Space", "Mark or unmark a can"},
+		{"d", "Remove the marked cans without recording a dry weight"},
+		{"+", "Back to Oven Status"},
+	})
+
+	logger.Info.Println("Opening Clear Stale Cans screen")
+
+	var cans []pkg.OvenCanData
+	marked := map[string]bool{} // Can number -> marked for removal
+
+	table := tview.NewTable().
+		SetBorders(true).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	statusText := tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetDynamicColors(true)
+	statusFor := func() string {
+		return fmt.Sprintf("%d cans in oven  |  %d marked for removal", len(cans), len(marked))
+	}
+
+	// Load the oven and list its cans oldest first, since those are the likely strays
+	loadCans := func() {
+		loaded, err := pkg.GetCansInOven()
+		if err != nil {
+			logger.Error.Printf("Failed to load oven tracking: %v", err)
+		}
+		cans = loaded
+		sort.SliceStable(cans, func(i, j int) bool {
+			ti, erri := cans[i].ParseTimeIn()
+			tj, errj := cans[j].ParseTimeIn()
+			if erri != nil || errj != nil {
+				return erri != nil && errj == nil // Unreadable times first; they need a look anyway
+			}
+			return ti.Before(tj)
+		})
+		marked = map[string]bool{}
+	}
+
+	dryTime := time.Duration(pkg.Config.OvenDryTimeHours) * time.Hour
+
+	populateTable := func() {
+		table.Clear()
+		headers := []string{"Remove", "Can #", "Job", "Boring", "Depth", "Oven", "Time In", "In Oven"}
+		for col, header := range headers {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorWhite).
+				SetAlign(tview.AlignCenter).
+				SetSelectable(false).
+				SetAttributes(tcell.AttrBold))
+		}
+
+		if len(cans) == 0 {
+			table.SetCell(1, 0, tview.NewTableCell("No cans in oven").
+				SetTextColor(tcell.ColorYellow).
+				SetSelectable(false))
+			return
+		}
+
+		now := time.Now()
+		for row, can := range cans {
+			rowColor := tcell.ColorWhite
+			age := "-"
+			if timeIn, err := can.ParseTimeIn(); err == nil {
+				age = formatOvenAge(now.Sub(timeIn))
+				if dryTime > 0 && now.Sub(timeIn) > dryTime {
+					rowColor = tcell.ColorRed
+				}
+			}
+			mark := "[ ]"
+			if marked[can.CanNumber] {
+				mark = "[x]"
+				rowColor = tcell.ColorYellow
+			}
+			oven := can.OvenID
+			if oven == "" {
+				oven = "-"
+			}
+
+			for col, value := range []string{mark, can.CanNumber, can.JobNumber, can.BoringNumber, can.Depth, oven, can.TimeIn, age} {
+				cell := tview.NewTableCell(value).
+					SetAlign(tview.AlignCenter).
+					SetTextColor(rowColor)
+				if col == 6 {
+					cell.SetExpansion(1)
+				}
+				table.SetCell(row+1, col, cell)
+			}
+		}
+	}
+
+	loadCans()
+	populateTable()
+	statusText.SetText(statusFor())
+	table.Select(1, 0)
+
+	toggleMark := func() {
+		row, _ := table.GetSelection()
+		if row == 0 || row > len(cans) {
+			return
+		}
+		canNumber := cans[row-1].CanNumber
+		if marked[canNumber] {
+			delete(marked, canNumber)
+		} else {
+			marked[canNumber] = true
+		}
+		populateTable()
+		statusText.SetText(statusFor())
+	}
+	table.SetSelectedFunc(func(row, column int) {
+		toggleMark()
+	})
+
+	var horizontal *tview.Flex
+
+	// Confirm, then take the marked cans out of oven tracking without writing dry weights
+	removeMarked := func() {
+		if len(marked) == 0 {
+			flashStatus(app, statusText, "[yellow]Mark cans with Enter or Space first[-]", statusFor)
+			return
+		}
+		var canNumbers []string
+		for _, can := range cans {
+			if marked[can.CanNumber] {
+				canNumbers = append(canNumbers, can.CanNumber)
+			}
+		}
+
+		backToList := func() {
+			app.SetRoot(horizontal, true)
+			app.SetFocus(table)
+		}
+		confirmRemove := func() {
+			removed, err := pkg.RemoveCansWithoutDryWeight(canNumbers, user.Name)
+			loadCans()
+			populateTable()
+			statusText.SetText(statusFor())
+			backToList()
+			if err != nil {
+				ShowError(app, fmt.Errorf("some cans were not removed: %v", err), horizontal, table)
+				return
+			}
+			flashStatus(app, statusText, fmt.Sprintf("[green]Removed %d cans without a dry weight[-]", len(removed)), statusFor)
+		}
+
+		Confirm(app, fmt.Sprintf("Remove %d cans from the oven without recording a dry weight?\n\n"+
+			"Can #: %s\n\n"+
+			"Only do this for cans that are no longer in the oven. Their samples keep no dry weight.", len(canNumbers), strings.Join(canNumbers, ", ")), []string{"Remove", "Cancel"}, func(choice int) {
+			if choice == 0 {
+				confirmRemove()
+			} else {
+				backToList()
+			}
+		})
+	}
+
+	// Instructions text
+	instructions := tview.NewTextView().
+		SetText("Up/Down: Navigate  |  Enter/Space: Mark  |  d: Remove Marked  |  +: Back to Oven Status").
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorWhite)
+
+	// Container
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(statusText, 1, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(instructions, 1, 0, false)
+
+	container.SetBorder(true).
+		SetTitle(userTitle("Clear Stale Cans", user)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite)
+
+	// Center it
+	vertical := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(container, 0, 4, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(vertical, 0, 3, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case ' ':
+			toggleMark()
+			return nil
+		case 'd':
+			removeMarked()
+			return nil
+		case '+':
+			onBack()
+			return nil
+		}
+		return event
+	})
+
+	return horizontal, table
+}
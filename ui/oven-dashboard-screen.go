@@ -0,0 +1,252 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// ovenJobSummary holds the per-job oven totals shown on the dashboard
+type ovenJobSummary struct {
+	JobNumber    string
+	CanCount     int
+	OldestTimeIn time.Time
+	OldestText   string
+	OverdueCount int
+}
+
+// formatOvenAge formats a duration as hours and minutes (e.g., "26h 05m")
+func formatOvenAge(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func NewOvenDashboardScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.Table) {
+	SetScreenShortcuts("Oven Status", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"p", "Print a morning worksheet of cans to weigh"},
+		{"c", "Clear cans that left the oven without a dry weight (lab managers)"},
+		{"+", "Back to LMS"},
+	})
+
+	logger.Info.Println("Opening Oven Status dashboard")
+
+	// Load oven tracking data directly
+	tracking, err := pkg.LoadOvenTracking()
+	if err != nil {
+		logger.Error.Printf("Failed to load oven tracking: %v", err)
+		tracking = &pkg.OvenTrackingData{Cans: []pkg.OvenCanData{}}
+	}
+
+	dryTime := time.Duration(pkg.Config.OvenDryTimeHours) * time.Hour
+	now := time.Now()
+
+	// Group cans by job
+	summaries := map[string]*ovenJobSummary{}
+	totalOverdue := 0
+	for _, can := range tracking.Cans {
+		summary, exists := summaries[can.JobNumber]
+		if !exists {
+			summary = &ovenJobSummary{JobNumber: can.JobNumber}
+			summaries[can.JobNumber] = summary
+		}
+		summary.CanCount++
+
+		timeIn, err := can.ParseTimeIn()
+		if err != nil {
+			logger.Info.Printf("Could not parse time in '%s' for can %s: %v", can.TimeIn, can.CanNumber, err)
+			continue
+		}
+		if summary.OldestText == "" || timeIn.Before(summary.OldestTimeIn) {
+			summary.OldestTimeIn = timeIn
+			summary.OldestText = can.TimeIn
+		}
+		if dryTime > 0 && now.Sub(timeIn) > dryTime {
+			summary.OverdueCount++
+			totalOverdue++
+		}
+	}
+
+	jobSummaries := []*ovenJobSummary{}
+	for _, summary := range summaries {
+		jobSummaries = append(jobSummaries, summary)
+	}
+	sort.Slice(jobSummaries, func(i, j int) bool {
+		return jobSummaries[i].JobNumber < jobSummaries[j].JobNumber
+	})
+
+	table := tview.NewTable().
+		SetBorders(true).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	// Set headers
+	headers := []string{"Job #", "Cans", "Oldest Time In", "Longest in Oven", "Overdue"}
+	for col, header := range headers {
+		cell := tview.NewTableCell(header).
+			SetTextColor(tcell.ColorWhite).
+			SetAlign(tview.AlignCenter).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold)
+		table.SetCell(0, col, cell)
+	}
+
+	// Populate table
+	if len(jobSummaries) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No cans in oven").
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignCenter))
+	} else {
+		for row, summary := range jobSummaries {
+			rowColor := tcell.ColorWhite
+			if summary.OverdueCount > 0 {
+				rowColor = tcell.ColorRed
+			}
+
+			oldest := "-"
+			age := "-"
+			if summary.OldestText != "" {
+				oldest = summary.OldestText
+				age = formatOvenAge(now.Sub(summary.OldestTimeIn))
+			}
+
+			table.SetCell(row+1, 0, tview.NewTableCell(summary.JobNumber).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(rowColor))
+			table.SetCell(row+1, 1, tview.NewTableCell(fmt.Sprintf("%d", summary.CanCount)).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(rowColor))
+			table.SetCell(row+1, 2, tview.NewTableCell(oldest).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(rowColor).
+				SetExpansion(1))
+			table.SetCell(row+1, 3, tview.NewTableCell(age).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(rowColor))
+			table.SetCell(row+1, 4, tview.NewTableCell(fmt.Sprintf("%d", summary.OverdueCount)).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(rowColor))
+		}
+	}
+
+	// Summary line with total count
+	summaryFor := func() string {
+		return fmt.Sprintf("Total cans in oven: %d  |  Jobs: %d  |  Dry time: %dh",
+			len(tracking.Cans), len(jobSummaries), pkg.Config.OvenDryTimeHours)
+	}
+	summaryText := tview.NewTextView().
+		SetText(summaryFor()).
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorWhite)
+
+	// Warning banner if any can has exceeded the dry time
+	bannerText := tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetDynamicColors(true)
+	if totalOverdue > 0 {
+		bannerText.SetText(fmt.Sprintf("[red]⚠ %d can(s) have been in the oven longer than %dh - weigh them now[-]",
+			totalOverdue, pkg.Config.OvenDryTimeHours))
+	} else {
+		bannerText.SetText("[green]No cans past the configured dry time[-]")
+	}
+
+	// Instructions text
+	instructions := tview.NewTextView().
+		SetText("Up/Down: Navigate  |  p: Print Morning Worksheet  |  c: Clear Stale Cans  |  +: Back to LMS").
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorWhite).
+		SetDynamicColors(true)
+
+	// Container
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summaryText, 1, 0, false).
+		AddItem(bannerText, 1, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(instructions, 1, 0, false)
+
+	container.SetBorder(true).
+		SetTitle(userTitle("Oven Status - All Jobs", user)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite)
+
+	// Center it
+	vertical := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(container, 0, 4, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(vertical, 0, 3, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '+' {
+			onBack()
+			return nil
+		}
+		if event.Rune() == 'p' {
+			printMorningWorksheet(app, horizontal, table)
+			return nil
+		}
+		if event.Rune() == 'c' {
+			if !user.IsManager() {
+				flashStatus(app, summaryText, "Only lab managers can clear stale cans", summaryFor)
+				return nil
+			}
+			logger.Info.Println("Navigating to Clear Stale Cans screen")
+			cleanupScreen, cleanupTable := NewOvenCleanupScreen(app, user, func() {
+				// Go back to a fresh Oven Status, since cans may have been removed
+				logger.Info.Println("Returning to Oven Status from Clear Stale Cans")
+				ovenScreen, ovenTable := NewOvenDashboardScreen(app, user, onBack)
+				app.SetRoot(ovenScreen, true)
+				app.SetFocus(ovenTable)
+			})
+			app.SetRoot(cleanupScreen, true)
+			if cleanupTable != nil { // nil when the user was turned away
+				app.SetFocus(cleanupTable)
+			}
+			return nil
+		}
+		return event
+	})
+
+	QueueOvenRecoveryNotice(app, horizontal, table)
+
+	return horizontal, table
+}
+
+// printMorningWorksheet exports the cans in the oven to a paper worksheet and prints it
+func printMorningWorksheet(app *tview.Application, returnTo tview.Primitive, focus tview.Primitive) {
+	logger.Info.Println("Printing morning worksheet")
+	printExport(app, "Morning worksheet", pkg.ExportMorningWorksheet, returnTo, focus)
+}
+
+// printExport writes a sheet with export and sends it to the printer, saying where the
+// file went if printing fails. In training mode nothing is written, so nothing is printed.
+func printExport(app *tview.Application, name string, export func() (string, error), returnTo tview.Primitive, focus tview.Primitive) {
+	exportPath, err := export()
+	if err != nil {
+		ShowError(app, fmt.Errorf("failed to export %s: %v", strings.ToLower(name), err), returnTo, focus)
+		return
+	}
+	if pkg.Config.DryRun {
+		Alert(app, fmt.Sprintf("Training mode: %s not written or printed.\n\n%s\n\nPress Enter to continue", strings.ToLower(name), exportPath), returnTo, focus)
+		return
+	}
+	if err := pkg.PrintFile(exportPath); err != nil {
+		ShowError(app, fmt.Errorf("%s saved to:\n%s\n\nbut printing failed: %v", name, exportPath, err), returnTo, focus)
+		return
+	}
+	Alert(app, fmt.Sprintf("%s sent to the printer.\n\n%s\n\nPress Enter to continue", name, exportPath), returnTo, focus)
+}
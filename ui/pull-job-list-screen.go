@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"lms-tui/logger"
@@ -9,11 +12,20 @@ import (
 )
 
 // NewPullJobListScreen displays a list of jobs for the user to select for pulling samples
-func NewPullJobListScreen(app *tview.Application, onBack func()) (tview.Primitive, *tview.Table) {
+func NewPullJobListScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.Table) {
+	SetScreenShortcuts("Pull Job", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"Enter", "Start pulling the selected job"},
+		{"0-9", "Type a job number to jump to it"},
+		{"/", "Show / hide completed and on-hold jobs"},
+		{"r", "Refresh the job list"},
+		{"+", "Back to LMS"},
+	})
+
 	// Dynamically discover jobs from projects folder
-	jobs, err := pkg.DiscoverJobs()
-	if err != nil {
-		logger.Error.Printf("Failed to discover jobs: %v", err)
+	jobs, discoverErr := pkg.DiscoverJobs()
+	if discoverErr != nil {
+		logger.Error.Printf("Failed to discover jobs: %v", discoverErr)
 		jobs = []models.Job{}
 	}
 
@@ -23,7 +35,7 @@ func NewPullJobListScreen(app *tview.Application, onBack func()) (tview.Primitiv
 		SetFixed(1, 0)
 
 	// Set headers
-	headers := []string{"Project #", "Project Name", "Engineer", "Assigned", "Due Date"}
+	headers := []string{"Project #", "Project Name", "Engineer", "Assigned", "Due Date", "Status"}
 	for col, header := range headers {
 		cell := tview.NewTableCell(header).
 			SetTextColor(tcell.ColorWhite).
@@ -33,46 +45,168 @@ func NewPullJobListScreen(app *tview.Application, onBack func()) (tview.Primitiv
 		table.SetCell(0, col, cell)
 	}
 
+	// Load saved progress so completed jobs can be marked
+	progressByJob := map[string]*pkg.ProgressData{}
+	loadProgress := func() {
+		progressByJob = map[string]*pkg.ProgressData{}
+		for _, job := range jobs {
+			progress, err := pkg.LoadProgressData(job.ProjectNumber)
+			if err != nil {
+				logger.Error.Printf("Failed to load progress for job %s: %v", job.ProjectNumber, err)
+				continue
+			}
+			progressByJob[job.ProjectNumber] = progress
+		}
+	}
+	loadProgress()
+
+	// Completed and on-hold jobs are hidden by default to keep the daily list short
+	showCompleted := false
+	var visibleJobs []models.Job
+
+	isCompleted := func(job models.Job) bool {
+		progress := progressByJob[job.ProjectNumber]
+		return progress != nil && progress.Completed
+	}
+
 	// Populate table with job data
-	for row, job := range jobs {
-		table.SetCell(row+1, 0, tview.NewTableCell(job.ProjectNumber).
-			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite))
+	populateTable := func() {
+		// Clear everything below the header
+		for row := table.GetRowCount() - 1; row > 0; row-- {
+			table.RemoveRow(row)
+		}
 
-		table.SetCell(row+1, 1, tview.NewTableCell(job.ProjectName).
-			SetTextColor(tcell.ColorWhite).
-			SetExpansion(2))
+		visibleJobs = []models.Job{}
+		for _, job := range jobs {
+			if showCompleted || (!isCompleted(job) && !job.OnHold) {
+				visibleJobs = append(visibleJobs, job)
+			}
+		}
 
-		table.SetCell(row+1, 2, tview.NewTableCell(job.EngineerInitials).
-			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite))
+		labels := jobVersionLabels(visibleJobs, jobs)
+		for row, job := range visibleJobs {
+			textColor := tcell.ColorWhite
+			projectNumber := labels[row]
+			status := ""
+			statusColor := tcell.ColorGreen
+			if isCompleted(job) {
+				textColor = tcell.ColorGray
+				projectNumber += " (done)"
+				status = "✓ Completed"
+			} else if job.OnHold {
+				textColor = tcell.ColorGray
+				statusColor = tcell.ColorGray
+				status = "On Hold"
+				if job.HoldReason != "" {
+					status += ": " + job.HoldReason
+				}
+			} else if progress := progressByJob[job.ProjectNumber]; progress != nil && progress.CurrentSampleIndex > 0 {
+				// Partially pulled - make it stand out so the right job gets resumed
+				textColor = tcell.ColorYellow
+				statusColor = tcell.ColorYellow
+				if progress.TotalSamples > 0 {
+					status = fmt.Sprintf("In Progress (%d/%d)", progress.CurrentSampleIndex, progress.TotalSamples)
+				} else {
+					status = fmt.Sprintf("In Progress (%d)", progress.CurrentSampleIndex)
+				}
+			}
+			// Two folders claim this job number, so make it clear which one is being pulled
+			if warning := job.DuplicateWarning(); warning != "" {
+				status = warning
+				statusColor = tcell.ColorRed
+			}
 
-		table.SetCell(row+1, 3, tview.NewTableCell(job.FormatDateAssigned()).
-			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite))
+			table.SetCell(row+1, 0, tview.NewTableCell(projectNumber).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(textColor))
 
-		table.SetCell(row+1, 4, tview.NewTableCell(job.FormatDueDate()).
-			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite))
+			table.SetCell(row+1, 1, tview.NewTableCell(job.ProjectName).
+				SetTextColor(textColor).
+				SetExpansion(2))
+
+			table.SetCell(row+1, 2, tview.NewTableCell(job.EngineerInitials).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(textColor))
+
+			table.SetCell(row+1, 3, tview.NewTableCell(job.FormatDateAssigned()).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(textColor))
+
+			table.SetCell(row+1, 4, tview.NewTableCell(job.FormatDueDate()).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(textColor))
+
+			table.SetCell(row+1, 5, tview.NewTableCell(status).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(statusColor))
+		}
+
+		if len(visibleJobs) == 0 && len(jobs) > 0 {
+			table.SetCell(1, 0, tview.NewTableCell("All jobs are completed or on hold - press / to show them").
+				SetTextColor(tcell.ColorYellow).
+				SetSelectable(false))
+		} else if len(jobs) == 0 {
+			message, color := noJobsMessage(discoverErr, "No jobs found")
+			table.SetCell(1, 0, tview.NewTableCell(message).
+				SetTextColor(color).
+				SetSelectable(false))
+		}
+		table.Select(1, 0)
 	}
+	populateTable()
+
+	// Declare container early so it can be referenced in closures
+	var horizontal *tview.Flex
 
 	// Handle job selection function
 	selectJob := func() {
 		row, _ := table.GetSelection()
-		if row == 0 {
+		if row == 0 || row > len(visibleJobs) {
 			return
 		}
-		selectedJob := jobs[row-1]
+		selectedJob := visibleJobs[row-1]
 		logger.Info.Printf("Job selected for pulling: %s - %s", selectedJob.ProjectNumber, selectedJob.ProjectName)
 
-		// Navigate directly to pull sample screen
-		pullScreen := NewPullSampleScreen(app, selectedJob, func() {
-			// Go back to pull job list screen
-			pullJobScreen, pullJobTable := NewPullJobListScreen(app, onBack)
-			app.SetRoot(pullJobScreen, true)
-			app.SetFocus(pullJobTable)
+		openPullScreen := func() {
+			pullScreen := NewPullSampleScreen(app, user, selectedJob, func() {
+				// Go back to pull job list screen
+				pullJobScreen, pullJobTable := NewPullJobListScreen(app, user, onBack)
+				app.SetRoot(pullJobScreen, true)
+				app.SetFocus(pullJobTable)
+			})
+			app.SetRoot(pullScreen, true)
+		}
+
+		progress := progressByJob[selectedJob.ProjectNumber]
+		if progress == nil || !progress.Completed {
+			// Navigate directly to pull sample screen; in-progress jobs ask whether to resume there
+			if progress != nil && progress.CurrentSampleIndex > 0 {
+				logger.Info.Printf("Resuming in-progress job %s at sample %d", selectedJob.ProjectNumber, progress.CurrentSampleIndex+1)
+			}
+			openPullScreen()
+			return
+		}
+
+		// Completed jobs must be reopened explicitly
+		reopen := func() {
+			if err := pkg.ReopenJob(selectedJob.ProjectNumber); err != nil {
+				ShowError(app, fmt.Errorf("failed to reopen job %s: %v", selectedJob.ProjectNumber, err), horizontal, table)
+				return
+			}
+			openPullScreen()
+		}
+		cancel := func() {
+			app.SetRoot(horizontal, true)
+			app.SetFocus(table)
+		}
+		Confirm(app, fmt.Sprintf("Job %s was marked complete on %s.\n\nReopen it to pull more samples?",
+			selectedJob.ProjectNumber, progress.CompletedAt), []string{"Reopen", "Cancel"}, func(choice int) {
+			if choice == 0 {
+				reopen()
+			} else {
+				cancel()
+			}
 		})
-		app.SetRoot(pullScreen, true)
 	}
 
 	// Handle job selection - navigate directly to pull sample screen
@@ -82,14 +216,20 @@ func NewPullJobListScreen(app *tview.Application, onBack func()) (tview.Primitiv
 
 
 	// Title text
+	titleFor := func() string {
+		if showCompleted {
+			return "Select Job to Pull  (showing all jobs)"
+		}
+		return fmt.Sprintf("Select Job to Pull  (%d completed or on hold hidden)", len(jobs)-len(visibleJobs))
+	}
 	titleText := tview.NewTextView().
-		SetText("Select Job to Pull").
+		SetText(titleFor()).
 		SetTextAlign(tview.AlignCenter).
 		SetTextColor(tcell.ColorWhite)
 
 	// Instructions text
 	instructions := tview.NewTextView().
-		SetText("Up/Down: Navigate  |  +: Back to LMS  |  Enter: Select Job").
+		SetText("Up/Down: Navigate  |  +: Back to LMS  |  Enter: Select Job  |  /: Show/Hide Completed & On Hold  |  r: Refresh").
 		SetTextAlign(tview.AlignCenter).
 		SetTextColor(tcell.ColorWhite).
 		SetDynamicColors(true)
@@ -102,7 +242,7 @@ func NewPullJobListScreen(app *tview.Application, onBack func()) (tview.Primitiv
 		AddItem(instructions, 1, 0, false)
 
 	container.SetBorder(true).
-		SetTitle(" Pull Job - Select Project ").
+		SetTitle(userTitle("Pull Job - Select Project", user)).
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorWhite)
 
@@ -113,12 +253,38 @@ func NewPullJobListScreen(app *tview.Application, onBack func()) (tview.Primitiv
 		AddItem(container, 0, 4, true).
 		AddItem(nil, 0, 1, false)
 
-	horizontal := tview.NewFlex().
+	horizontal = tview.NewFlex().
 		AddItem(nil, 0, 1, false).
 		AddItem(vertical, 0, 3, true).
 		AddItem(nil, 0, 1, false)
 
+	jumpToJob := newJobJump(app, table, titleText, func() []string { return projectNumbers(visibleJobs) }, titleFor)
+
 	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if jumpToJob(event) {
+			return nil
+		}
+		if event.Rune() == '/' {
+			showCompleted = !showCompleted
+			logger.Info.Printf("Pull job list show completed: %v", showCompleted)
+			populateTable()
+			titleText.SetText(titleFor())
+			return nil
+		}
+		if event.Rune() == 'r' {
+			// Re-read the projects folder so newly dropped jobs show up
+			refreshed, err := pkg.DiscoverJobs()
+			if err != nil && !errors.Is(err, pkg.ErrProjectsDirMissing) {
+				ShowError(app, fmt.Errorf("failed to discover jobs: %v", err), horizontal, table)
+				return nil
+			}
+			jobs, discoverErr = refreshed, err
+			loadProgress()
+			populateTable()
+			logger.Info.Printf("Refreshed Pull Job list: %d jobs", len(jobs))
+			flashStatus(app, titleText, fmt.Sprintf("Refreshed — %d jobs", len(jobs)), titleFor)
+			return nil
+		}
 		if event.Rune() == '+' {
 			onBack()
 			return nil
@@ -126,5 +292,10 @@ func NewPullJobListScreen(app *tview.Application, onBack func()) (tview.Primitiv
 		return event
 	})
 
+	// A missing projects folder is explained in the list itself
+	if discoverErr != nil && !errors.Is(discoverErr, pkg.ErrProjectsDirMissing) {
+		QueueError(app, fmt.Errorf("failed to discover jobs: %v", discoverErr), horizontal, table)
+	}
+
 	return horizontal, table
 }
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -13,37 +14,127 @@ import (
 	"lms-tui/pkg"
 )
 
-func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func()) tview.Primitive {
+// pulledSample is a sample saved during this pull session, kept for edit last sample and undo
+type pulledSample struct {
+	boringNumber string
+	depth        string
+	canNumber    string
+	canWeight    string
+	wetWeight    string
+	suctionCanNo string
+	sampleIndex  int
+	skipped      bool
+}
+
+func NewPullSampleScreen(app *tview.Application, user *pkg.User, job models.Job, onBack func()) tview.Primitive {
+	SetScreenShortcuts("Pull Sample", []Shortcut{
+		{"Enter", "Next field / save sample"},
+		{"Tab", "Next field"},
+		{"/", "Reset fields for current sample"},
+		{"-", "Edit last saved sample (not Arrow Down here)"},
+		{"Ctrl+Z", "Undo the last saved sample (repeat to step back further)"},
+		{"Ctrl+S", "Skip the current sample (not tested) with a reason"},
+		{"Ctrl+N", "Show / hide notes for this sample"},
+		{"Ctrl+O", "Open job folder in file manager"},
+		{"Ctrl+Y", "Copy the current sample's boring, depth and tests"},
+		{"Ctrl+B", "Tag the next cans put in the oven as a named batch"},
+		{"Ctrl+R", "Flag / unflag the last saved sample for a supervisor to recheck"},
+		{"+", "Stop and go back to menu"},
+	})
+	// '-' is remapped to arrow down globally, so claim it for edit last sample
+	KeepScreenKeys('-')
+
 	logger.Info.Printf("Starting pull sample for Job: %s", job.ProjectNumber)
 
-	// Load job data from Excel using the specific Lab file path
-	jobData, err := pkg.ExcelToJSON(job.LabFilePath)
+	// Only one pull session per job, so two writers never hold the same Lab file
+	if err := pkg.ClaimPullSession(job.ProjectNumber); err != nil {
+		return newAlertModal(fmt.Sprintf("⚠ %v\n\nFinish or stop the existing session for this job first.\n\nPress Enter to go back", err), onBack)
+	}
+	// Release the registration whenever the session is left (stop or finish)
+	leave := onBack
+	onBack = func() {
+		pkg.ReleasePullSession(job.ProjectNumber)
+		leave()
+	}
+
+	// Reading a large Lab file and opening its working copy take a moment, so both happen in the background
+	return newLoadingModal(app, fmt.Sprintf("Reading Lab file for job %s...", job.ProjectNumber),
+		func() (pullSetup, error) {
+			var setup pullSetup
+			jobData, err := pkg.ExcelToJSON(job.LabFilePath)
+			setup.jobData = jobData
+			// Initialize moisture test writer - creates ex_project/[job_number]/ directory and Excel file
+			// Each Lab file version gets its own directory (e.g., ex_project/25490/ and ex_project/25490_03/)
+			setup.moistureWriter, setup.writerErr = pkg.InitMoistureTestFile(job.ProjectNumber, job.LabFilePath)
+			return setup, err
+		},
+		func(setup pullSetup, err error) {
+			app.SetRoot(newPullSampleSession(app, user, job, setup, err, onBack), true)
+		}, onBack)
+}
+
+// pullSetup is what the pull screen reads before it is shown
+type pullSetup struct {
+	jobData        *pkg.JobData
+	moistureWriter *pkg.MoistureTestWriter
+	writerErr      error
+}
+
+// Close frees the working Lab file of a setup cancelled before the screen was shown
+func (s pullSetup) Close() error {
+	if s.moistureWriter == nil {
+		return nil
+	}
+	return s.moistureWriter.Close()
+}
+
+// newPullSampleSession builds the pull screen from what was read in the background.
+// err is the Lab file read failure, if any, and is shown with the other setup problems.
+func newPullSampleSession(app *tview.Application, user *pkg.User, job models.Job, setup pullSetup, err error, onBack func()) tview.Primitive {
+	// Collect setup failures so they can be shown once the screen is displayed
+	var initErrs []error
 
+	jobData := setup.jobData
 	var samples []pkg.SampleData
 	var totalSamples int = 0
 	if err == nil && jobData != nil {
 		samples = jobData.Samples
 		totalSamples = len(samples)
 		logger.Info.Printf("Loaded %d samples from job %s", totalSamples, job.ProjectNumber)
+		for _, warning := range jobData.SkippedRowWarnings() {
+			initErrs = append(initErrs, errors.New(warning))
+		}
 	} else {
 		logger.Error.Printf("Failed to load job data: %v", err)
+		initErrs = append(initErrs, fmt.Errorf("failed to load samples from Lab file: %v", err))
 	}
 
-	// Initialize moisture test writer - creates ex_project/[job_number]/ directory and Excel file
-	// Each Lab file version gets its own directory (e.g., ex_project/25490/ and ex_project/25490_03/)
-	moistureWriter, err := pkg.InitMoistureTestFile(job.ProjectNumber, job.LabFilePath)
-	if err != nil {
+	moistureWriter := setup.moistureWriter
+	if err := setup.writerErr; err != nil {
 		logger.Error.Printf("Failed to initialize moisture test file: %v", err)
+		initErrs = append(initErrs, fmt.Errorf("moisture data will NOT be written to Excel: %v", err))
 	} else {
 		logger.Info.Printf("Initialized moisture test file for job %s", job.ProjectNumber)
 	}
 
+	// Cross-check every sample against the Moisture column map so unwritable samples are
+	// reported up front instead of failing one at a time during the pull
+	var unmappedSamples []pkg.SampleData
+	if moistureWriter != nil {
+		unmappedSamples = moistureWriter.UnmappedSamples(samples)
+		if len(unmappedSamples) > 0 {
+			logger.Error.Printf("%d of %d samples in job %s have no Moisture column",
+				len(unmappedSamples), len(samples), job.ProjectNumber)
+		}
+	}
+
 	// Initialize soil suction test writer - shares the same file handle as moisture writer
 	var suctionWriter *pkg.SoilSuctionWriter
 	if moistureWriter != nil {
 		suctionWriter, err = pkg.InitSoilSuctionFile(job.ProjectNumber, moistureWriter.GetFile())
 		if err != nil {
 			logger.Error.Printf("Failed to initialize soil suction test file: %v", err)
+			initErrs = append(initErrs, fmt.Errorf("soil suction data will NOT be written to Excel: %v", err))
 		} else {
 			logger.Info.Printf("Initialized soil suction test file for job %s", job.ProjectNumber)
 		}
@@ -51,27 +142,65 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 
 	// Track current sample index (0-based) - load saved progress
 	currentSampleIndex := 0
+	resuming := false // Asked on entry whether to resume or start over
 	savedIndex, err := pkg.LoadProgress(job.ProjectNumber)
 	if err == nil && savedIndex > 0 {
 		currentSampleIndex = savedIndex
+		resuming = true
 		logger.Info.Printf("Resuming job %s from sample %d", job.ProjectNumber, currentSampleIndex+1)
 	}
 
+	// A job reopened for added samples only visits the samples not recorded yet
+	newSamplesOnly := false
+	if progress, err := pkg.LoadProgressData(job.ProjectNumber); err == nil {
+		newSamplesOnly = progress.NewSamplesOnly
+	}
+	skipRecordedSamples := func() {
+		if !newSamplesOnly {
+			return
+		}
+		newIndexes, err := pkg.FindNewSamples(job.ProjectNumber, samples)
+		if err != nil {
+			logger.Error.Printf("Failed to find new samples for job %s: %v", job.ProjectNumber, err)
+			return
+		}
+		for _, i := range newIndexes {
+			if i >= currentSampleIndex {
+				currentSampleIndex = i
+				return
+			}
+		}
+		currentSampleIndex = totalSamples
+	}
+	// Samples with no tests marked on the Main Form have nothing to pull, so pass over them
+	skipNoTestSamples := func() {
+		next := pkg.NextSampleWithTests(samples, currentSampleIndex)
+		for i := currentSampleIndex; i < next; i++ {
+			logger.Info.Printf("Passing over sample %s|%s in job %s: no tests marked on the Main Form",
+				samples[i].BoringNumber, samples[i].Depth, job.ProjectNumber)
+		}
+		currentSampleIndex = next
+	}
+	skipRecordedSamples()
+	skipNoTestSamples()
+
+	// If the app crashes mid-pull, keep the place in the job and release the Lab file
+	pkg.SetPullSessionRecovery(job.ProjectNumber, func() {
+		if err := pkg.SaveProgress(job.ProjectNumber, currentSampleIndex, totalSamples); err != nil {
+			logger.Error.Printf("Failed to save progress during crash recovery: %v", err)
+		}
+		if moistureWriter != nil {
+			moistureWriter.Close()
+		}
+	})
+
 	// Track used can numbers to prevent duplicates
 	usedMoistureCans := make(map[string]bool)
 	usedSuctionCans := make(map[string]bool)
 
-	// Track last saved sample for edit feature
-	var lastSampleData struct {
-		boringNumber string
-		depth        string
-		canNumber    string
-		canWeight    string
-		wetWeight    string
-		suctionCanNo string
-		sampleIndex  int
-	}
-	lastSampleData.sampleIndex = -1 // -1 means no sample saved yet
+	// Samples saved this session, newest last, for edit last sample and undo.
+	// The stack belongs to this screen, so it is cleared when the job changes.
+	var savedSamples []*pulledSample
 
 	// Track timing
 	startTime := time.Now()
@@ -81,18 +210,7 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 	getCurrentSampleInfo := func() (string, string, string, bool, bool) {
 		if currentSampleIndex < len(samples) {
 			sample := samples[currentSampleIndex]
-			hasSuction := false
-			hasOtherTests := false
-
-			for _, test := range sample.Tests {
-				if strings.Contains(test, "Soil Suction") {
-					hasSuction = true
-				} else if !strings.Contains(test, "Moisture Content") && !strings.Contains(test, "Moisture") {
-					// Check if there are tests other than Moisture Content and Soil Suction
-					hasOtherTests = true
-				}
-			}
-			return sample.BoringNumber, sample.Depth, strings.Join(sample.Tests, ", "), hasSuction, hasOtherTests
+			return sample.BoringNumber, sample.Depth, strings.Join(sample.Tests, ", "), sample.HasSoilSuction(), sample.HasOtherTests()
 		}
 		return "-", "-", "-", false, false
 	}
@@ -131,29 +249,75 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 			buttonText = "Get test and save sample"
 		}
 		form.AddButton(buttonText, saveSample)
+
+		// Start on Can # (item 0 is the section header) so the tech can type right away.
+		// The form hands focus to this item whenever it gains focus, including when the
+		// screen is first set as root.
+		form.SetFocus(1)
 	}
 
 	// Initial form build
 	rebuildForm()
 
+	// Optional notes field, toggled with Ctrl+N so the fast path stays clean.
+	// rebuildForm clears it, so notes are always hidden for the next sample.
+	toggleNotes := func() {
+		if index := form.GetFormItemIndex("  Notes"); index >= 0 {
+			form.RemoveFormItem(index)
+			app.SetFocus(form.GetFormItem(1))
+			return
+		}
+		addTextField(form, "  Notes", "", 40)
+		app.SetFocus(form.GetFormItemByLabel("  Notes"))
+	}
+
+	// Anything typed into the sample form is lost on quit, so quitting asks first
+	pkg.SetPullSessionUnsavedCheck(job.ProjectNumber, func() bool {
+		for _, label := range []string{"  Can #", "  Can Weight (g)", "  Wet Weight (g)", "  Suction Can #", "  Notes"} {
+			if text, ok := formText(form, label); ok && text != "" {
+				return true
+			}
+		}
+		return false
+	})
+
 	// ===== TOP RIGHT BOX - Job Info =====
 	jobInfoText := tview.NewTextView()
 	jobInfoText.SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter).
 		SetBackgroundColor(tcell.ColorBlack)
 
+	// Samples with an entry in backup.json - the real completion count, which can
+	// differ from the position once samples are revisited or undone. Skipped samples
+	// are resolved but shown separately so they don't read as tested.
+	recordedSamples := 0
+	skippedSamples := 0
+
+	// Batch the next cans put in the oven are tagged with (Ctrl+B), nil when none
+	var ovenBatch *pkg.OvenBatch
+
 	// Update job info display
 	updateJobInfo := func() {
 		boringNumber, depth, tests, hasSuction, hasOtherTests = getCurrentSampleInfo()
 		sampleProgress := fmt.Sprintf("%d of %d", currentSampleIndex+1, totalSamples)
 
-		// Create visual progress bar
+		if tested, skipped, err := pkg.CountRecordedSamples(job.ProjectNumber); err != nil {
+			logger.Error.Printf("Failed to count recorded samples: %v", err)
+		} else {
+			recordedSamples, skippedSamples = tested, skipped
+		}
+		resolvedSamples := recordedSamples + skippedSamples
+
+		// Create visual progress bar from the recorded and skipped samples
 		progressBar := ""
 		percentage := 0
 		if totalSamples > 0 {
-			percentage = (currentSampleIndex * 100) / totalSamples
+			percentage = (resolvedSamples * 100) / totalSamples
+			if percentage > 100 {
+				percentage = 100
+			}
 			barLength := 20
-			filledLength := (currentSampleIndex * barLength) / totalSamples
+			filledLength := (resolvedSamples * barLength) / totalSamples
 			if filledLength > barLength {
 				filledLength = barLength
 			}
@@ -170,20 +334,42 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 		}
 
 		if currentSampleIndex >= totalSamples {
-			sampleProgress = "COMPLETE"
-			progressBar = "[████████████████████] 100%"
+			sampleProgress = "End of list"
+		}
+		recorded := fmt.Sprintf("%d of %d", recordedSamples, totalSamples)
+		if skippedSamples > 0 {
+			recorded += fmt.Sprintf(" [gray](%d skipped)[white]", skippedSamples)
+		}
+
+		// Suction samples are slower to enter, so show them apart to help estimate finish time
+		remaining := "-"
+		if moistureLeft, suctionLeft, err := pkg.CountRemainingSamples(job.ProjectNumber, samples); err != nil {
+			logger.Error.Printf("Failed to count remaining samples: %v", err)
+		} else {
+			remaining = fmt.Sprintf("%d moisture, [yellow]%d suction[white]", moistureLeft, suctionLeft)
+		}
+
+		batchLine := ""
+		if ovenBatch.Active() {
+			batchLine = fmt.Sprintf("[aqua]Batch %s: %d more cans[white]\n", tview.Escape(ovenBatch.ID), ovenBatch.Remaining)
 		}
 
 		jobInfoText.SetText(fmt.Sprintf(
 			"Job Number: %s\n\n"+
-				"Progress: %s\n"+
-				"%s\n\n"+
+				"Sample: %s\n"+
+				"Recorded: %s\n"+
+				"Remaining: %s\n"+
+				"%s\n"+
+				"%s\n"+
 				"Boring: %s\n\n"+
 				"Depth: %s\n\n"+
 				"Tests: %s",
 			job.ProjectNumber,
 			sampleProgress,
+			recorded,
+			remaining,
 			progressBar,
+			batchLine,
 			boringNumber,
 			depth,
 			tests))
@@ -197,35 +383,126 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 
 	// Helper to show error modal and focus back to a specific field
 	showErrorModal := func(message string, focusField tview.FormItem) {
-		modal := tview.NewModal().
-			SetText(message).
-			AddButtons([]string{"OK"}).
-			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+		var focus tview.Primitive = form
+		if focusField != nil {
+			focus = focusField
+		}
+		Alert(app, message, container, focus)
+	}
+
+	// Set once the tech agrees to overwrite a sample that is already in the backup
+	overwriteConfirmed := false
+	// Set once the tech has checked the ConfirmEachSample summary for this save
+	summaryConfirmed := false
+
+	// The share dropped while saving: the writer holds the sample in memory. Ask the tech to
+	// reconnect, then save it again without repeating the confirmations already given.
+	showShareDroppedModal := func(canNum, canWeight, wetWeight, suctionNum string) {
+		logger.Error.Printf("WARNING: Share dropped saving sample %s|%s for job %s; waiting for the tech to retry",
+			boringNumber, depth, job.ProjectNumber)
+		retrySave := func() {
+			logger.Info.Printf("Retrying save of sample %s|%s after the share dropped", boringNumber, depth)
+			overwriteConfirmed = true
+			summaryConfirmed = true
+			app.SetRoot(container, true)
+			continueSaveSample(canNum, canWeight, wetWeight, suctionNum)
+		}
+		backToSample := func() {
+			// The sample stays unsaved, so take it back out of the workbook in memory; otherwise
+			// the next save that gets through would write weights the tech backed out of
+			if err := moistureWriter.DiscardMoistureSample(boringNumber, depth); err != nil {
+				logger.Error.Printf("WARNING: Failed to discard unsaved sample %s|%s: %v", boringNumber, depth, err)
+			}
+			app.SetRoot(container, true)
+			app.SetFocus(form.GetFormItemByLabel("  Can #"))
+		}
+		Confirm(app, fmt.Sprintf("⚠️ The share dropped while saving sample %s | %s\n\n"+
+			"Nothing has moved on: the sample is still on screen and held in memory.\n"+
+			"Reconnect the share (the status line turns green), then retry.", boringNumber, depth), []string{"Retry Save", "Back to Sample"}, func(choice int) {
+			if choice == 0 {
+				retrySave()
+			} else {
+				backToSample()
+			}
+		})
+	}
+
+	// Helper function to continue saving after validations pass
+	continueSaveSample = func(canNum, canWeight, wetWeight, suctionNum string) {
+		// Optionally show what is about to be saved so less experienced techs can catch typos
+		if pkg.Config.ConfirmEachSample && !summaryConfirmed {
+			confirmSample := func() {
+				logger.Info.Printf("User confirmed sample summary for %s|%s", boringNumber, depth)
+				summaryConfirmed = true
 				app.SetRoot(container, true)
-				if focusField != nil {
-					app.SetFocus(focusField)
+				continueSaveSample(canNum, canWeight, wetWeight, suctionNum)
+			}
+			editSample := func() {
+				app.SetRoot(container, true)
+				app.SetFocus(form.GetFormItemByLabel("  Can #"))
+			}
+			suctionLine := ""
+			if suctionNum != "" {
+				suctionLine = fmt.Sprintf("\nSuction Can #: %s", suctionNum)
+			}
+			Confirm(app, fmt.Sprintf("Save this sample?\n\n"+
+				"Boring: %s\nDepth: %s\n\n"+
+				"Can #: %s\nCan Weight: %s g\nWet Weight: %s g%s",
+				boringNumber, depth, canNum, canWeight, wetWeight, suctionLine), []string{"Save", "Edit"}, func(choice int) {
+				if choice == 0 {
+					confirmSample()
 				} else {
-					app.SetFocus(form)
+					editSample()
 				}
 			})
-		modal.SetBackgroundColor(tcell.ColorBlack)
-		modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-			if event.Rune() == '1' || event.Key() == tcell.KeyEnter {
-				app.SetRoot(container, true)
-				if focusField != nil {
-					app.SetFocus(focusField)
-				} else {
-					app.SetFocus(form)
+			return
+		}
+
+		// Re-entering a sample that already has data must be confirmed
+		if !overwriteConfirmed {
+			existing, err := pkg.FindSampleBackup(job.ProjectNumber, boringNumber, depth)
+			if err != nil {
+				logger.Error.Printf("Failed to check backup for existing sample: %v", err)
+			}
+			// A skipped sample has no data to lose, so recording it now just replaces the skip
+			if existing != nil && !existing.IsSkipped() {
+				logger.Info.Printf("Sample %s|%s already has data in backup (Can #%s)", boringNumber, depth, existing.CanNumber)
+				confirmOverwrite := func() {
+					logger.Info.Printf("User confirmed overwrite of sample %s|%s", boringNumber, depth)
+					// Take the previous can for this sample out of the oven so it is not counted twice
+					if inOven, canData, _ := pkg.IsCanInOven(existing.CanNumber); inOven &&
+						canData.JobNumber == job.ProjectNumber && canData.BoringNumber == boringNumber && canData.Depth == depth {
+						if _, err := pkg.RemoveCanFromOven(existing.CanNumber); err != nil {
+							logger.Error.Printf("Failed to remove previous can %s from oven: %v", existing.CanNumber, err)
+						}
+					}
+					overwriteConfirmed = true
+					app.SetRoot(container, true)
+					continueSaveSample(canNum, canWeight, wetWeight, suctionNum)
 				}
-				return nil
+				cancelOverwrite := func() {
+					summaryConfirmed = false
+					app.SetRoot(container, true)
+					app.SetFocus(form.GetFormItemByLabel("  Can #"))
+				}
+				Confirm(app, fmt.Sprintf("⚠️ This sample already has data — overwrite?\n\n"+
+					"Boring: %s\nDepth: %s\n\n"+
+					"Saved: Can #%s, Can Wt %s g, Wet Wt %s g (%s)\n"+
+					"New:   Can #%s, Can Wt %s g, Wet Wt %s g",
+					boringNumber, depth, existing.CanNumber, existing.CanWeight, existing.WetWeight, existing.Timestamp,
+					canNum, canWeight, wetWeight), []string{"Overwrite", "Cancel"}, func(choice int) {
+					if choice == 0 {
+						confirmOverwrite()
+					} else {
+						cancelOverwrite()
+					}
+				})
+				return
 			}
-			return event
-		})
-		app.SetRoot(modal, true)
-	}
+		}
+		overwriteConfirmed = false
+		summaryConfirmed = false
 
-	// Helper function to continue saving after validations pass
-	continueSaveSample = func(canNum, canWeight, wetWeight, suctionNum string) {
 		// Check for duplicate can numbers (if enabled in config)
 		if pkg.CheckDuplicateCans {
 			// Check for duplicate moisture can number (already used in this session)
@@ -246,6 +523,20 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 				showErrorModal(fmt.Sprintf("Suction Can # %s has already been used in this session.\n\nPlease use a different can.", suctionNum), form.GetFormItemByLabel("  Suction Can #"))
 				return
 			}
+			// A can is either a moisture can or a suction can, so the same number in both is
+			// usually a typo (labs that number the two sets independently turn this off)
+			if hasSuction && pkg.Config.CheckSuctionCanOverlap {
+				if suctionNum == canNum || usedMoistureCans[suctionNum] {
+					logger.Error.Printf("Validation failed: Suction Can # %s matches a moisture can number", suctionNum)
+					showErrorModal(fmt.Sprintf("Suction Can # %s is also being used as a moisture can.\n\nPlease recheck the suction can number.", suctionNum), form.GetFormItemByLabel("  Suction Can #"))
+					return
+				}
+				if usedSuctionCans[canNum] {
+					logger.Error.Printf("Validation failed: Moisture Can # %s matches a suction can number", canNum)
+					showErrorModal(fmt.Sprintf("Moisture Can # %s has already been used as a suction can in this session.\n\nPlease recheck the can number.", canNum), form.GetFormItemByLabel("  Can #"))
+					return
+				}
+			}
 		}
 
 		logger.Info.Printf("Sample %d/%d saved - Boring: %s, Depth: %s, Can #: %s, Can Weight: %s, Wet Weight: %s, Suction #: %s",
@@ -259,57 +550,107 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 			}
 		}
 
+		// Notes are only present if the tech opened the notes field
+		notes := ""
+		if text, ok := formText(form, "  Notes"); ok {
+			notes = text
+		}
+
+		// Collect save failures so the tech is told which parts did not save
+		var saveErrs []error
+
 		// Write moisture data to Excel file
 		if moistureWriter != nil {
 			err := moistureWriter.WriteMoistureSample(boringNumber, depth, canNum, canWeight, wetWeight)
+			if errors.Is(err, pkg.ErrWritePending) {
+				// The share dropped mid-save. Stay on this sample so nothing moves on until it saves.
+				delete(usedMoistureCans, canNum)
+				delete(usedSuctionCans, suctionNum)
+				showShareDroppedModal(canNum, canWeight, wetWeight, suctionNum)
+				return
+			}
 			if err != nil {
 				logger.Error.Printf("Failed to write moisture sample to Excel: %v", err)
+				saveErrs = append(saveErrs, fmt.Errorf("moisture data not written to Excel: %v", err))
 			}
+		} else {
+			saveErrs = append(saveErrs, fmt.Errorf("moisture data not written to Excel: Lab file is not open"))
 		}
 
 		// Write soil suction data to Excel file
-		if suctionWriter != nil && suctionNum != "" {
-			err := suctionWriter.WriteSoilSuctionSample(boringNumber, depth, suctionNum)
-			if err != nil {
-				logger.Error.Printf("Failed to write soil suction sample to Excel: %v", err)
+		if suctionNum != "" {
+			if suctionWriter != nil {
+				err := suctionWriter.WriteSoilSuctionSample(boringNumber, depth, suctionNum)
+				if err != nil {
+					logger.Error.Printf("Failed to write soil suction sample to Excel: %v", err)
+					saveErrs = append(saveErrs, fmt.Errorf("soil suction data not written to Excel: %v", err))
+				}
+			} else {
+				saveErrs = append(saveErrs, fmt.Errorf("soil suction data not written to Excel: suction file is not open"))
+			}
+		}
+
+		// Flag the sample on the Moisture sheet with the tech's note, or clear an earlier one
+		if moistureWriter != nil {
+			if err := moistureWriter.WriteSampleNote(boringNumber, depth, notes); err != nil {
+				logger.Error.Printf("Failed to write sample note to Excel: %v", err)
+				saveErrs = append(saveErrs, fmt.Errorf("note not written to Excel: %v", err))
+			}
+		}
+
+		// Look up where this sample lives on the Moisture sheets
+		moistureSheet, moistureColumn, mappingFound := "", "", false
+		if moistureWriter != nil {
+			moistureSheet, moistureColumn, mappingFound = moistureWriter.GetSampleMapping(boringNumber, depth)
+			if !mappingFound {
+				logger.Error.Printf("Could not find moisture sheet mapping for %s at %s", boringNumber, depth)
 			}
 		}
 
 		// Save backup to JSON file
-		if err := pkg.SaveSampleBackup(job.ProjectNumber, boringNumber, depth, canNum, canWeight, wetWeight, suctionNum); err != nil {
+		if err := pkg.SaveSampleBackup(job.ProjectNumber, boringNumber, depth, canNum, canWeight, wetWeight, suctionNum, moistureSheet, moistureColumn, notes, user.AuditID()); err != nil {
 			logger.Error.Printf("Failed to save sample backup: %v", err)
+			saveErrs = append(saveErrs, fmt.Errorf("backup not saved: %v", err))
 		}
 
 		// Add moisture can to oven tracking
-		if moistureWriter != nil {
-			moistureSheet, moistureColumn, found := moistureWriter.GetSampleMapping(boringNumber, depth)
-			if found {
-				if err := pkg.AddCanToOven(canNum, job.ProjectNumber, boringNumber, depth, moistureSheet, moistureColumn); err != nil {
-					logger.Error.Printf("Failed to add can to oven: %v", err)
-				}
-			} else {
-				logger.Error.Printf("Could not find moisture sheet mapping for %s at %s", boringNumber, depth)
+		if mappingFound {
+			if err := pkg.AddCanToOven(canNum, job.ProjectNumber, boringNumber, depth, moistureSheet, moistureColumn, ovenBatch); err != nil {
+				logger.Error.Printf("Failed to add can to oven: %v", err)
+				saveErrs = append(saveErrs, fmt.Errorf("can not added to oven tracking: %v", err))
+			}
+			if recovery := pkg.TakeOvenTrackingRecovery(); recovery != nil {
+				saveErrs = append(saveErrs, fmt.Errorf("oven tracking was corrupt and has been reset (%d cans recovered, damaged file saved as %s)",
+					recovery.RecoveredCans, recovery.BackupPath))
 			}
 		}
 
-		// Save last sample data for edit feature
-		lastSampleData.boringNumber = boringNumber
-		lastSampleData.depth = depth
-		lastSampleData.canNumber = canNum
-		lastSampleData.canWeight = canWeight
-		lastSampleData.wetWeight = wetWeight
-		lastSampleData.suctionCanNo = suctionNum
-		lastSampleData.sampleIndex = currentSampleIndex
+		// Remember the sample for edit last sample and undo, keeping only the configured number
+		savedSamples = append(savedSamples, &pulledSample{
+			boringNumber: boringNumber,
+			depth:        depth,
+			canNumber:    canNum,
+			canWeight:    canWeight,
+			wetWeight:    wetWeight,
+			suctionCanNo: suctionNum,
+			sampleIndex:  currentSampleIndex,
+		})
+		if limit := pkg.Config.UndoStackSize; limit > 0 && len(savedSamples) > limit {
+			savedSamples = savedSamples[len(savedSamples)-limit:]
+		}
 
 		// Move to next sample
 		currentSampleIndex++
+		skipRecordedSamples()
+		skipNoTestSamples()
 
 		// Reset sample timer for next sample
 		sampleStartTime = time.Now()
 
 		// Save progress so user can resume later
-		if err := pkg.SaveProgress(job.ProjectNumber, currentSampleIndex); err != nil {
+		if err := pkg.SaveProgress(job.ProjectNumber, currentSampleIndex, totalSamples); err != nil {
 			logger.Error.Printf("Failed to save progress: %v", err)
+			saveErrs = append(saveErrs, fmt.Errorf("progress not saved: %v", err))
 		}
 
 		// Update the job info display
@@ -321,13 +662,291 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 		// Focus back to first input field (skip the text views)
 		app.SetFocus(form.GetFormItem(1))
 
+		// Tell the tech about anything that failed to save. If this was the last
+		// sample, + still opens the completion screen after dismissing.
+		if len(saveErrs) > 0 {
+			ShowError(app, fmt.Errorf("sample %s | %s was only partially saved:\n\n%v", boringNumber, depth, errors.Join(saveErrs...)),
+				container, form.GetFormItem(1))
+			return
+		}
+
 		// Check if all samples are done
 		if currentSampleIndex >= totalSamples {
 			logger.Info.Printf("All %d samples completed for job %s", totalSamples, job.ProjectNumber)
-			showCompletionScreen(app, job, moistureWriter, container, onBack)
+			showCompletionScreen(app, user, job, moistureWriter, container, onBack)
+		}
+	}
+
+	// Undo the most recent saved sample: clear its Lab cells, drop it from the backup,
+	// take its can back out of the oven and return to that sample
+	undoLastSample := func() {
+		if len(savedSamples) == 0 {
+			return
+		}
+		undone := savedSamples[len(savedSamples)-1]
+		logger.Info.Printf("Undoing sample %s|%s (Can #%s)", undone.boringNumber, undone.depth, undone.canNumber)
+
+		var undoErrs []error
+		if moistureWriter != nil {
+			if err := moistureWriter.ClearMoistureSample(undone.boringNumber, undone.depth); err != nil {
+				undoErrs = append(undoErrs, fmt.Errorf("moisture data not cleared from Excel: %v", err))
+			}
+		} else {
+			undoErrs = append(undoErrs, fmt.Errorf("moisture data not cleared from Excel: Lab file is not open"))
+		}
+		if undone.suctionCanNo != "" {
+			if suctionWriter != nil {
+				if err := suctionWriter.ClearSoilSuctionSample(undone.boringNumber, undone.depth); err != nil {
+					undoErrs = append(undoErrs, fmt.Errorf("soil suction data not cleared from Excel: %v", err))
+				}
+			} else {
+				undoErrs = append(undoErrs, fmt.Errorf("soil suction data not cleared from Excel: suction file is not open"))
+			}
+		}
+		if _, err := pkg.RemoveSampleBackup(job.ProjectNumber, undone.boringNumber, undone.depth); err != nil {
+			undoErrs = append(undoErrs, fmt.Errorf("backup entry not removed: %v", err))
+		}
+		if undone.skipped {
+			// Nothing went in the oven for a skipped sample
+		} else if inOven, canData, _ := pkg.IsCanInOven(undone.canNumber); inOven &&
+			canData.JobNumber == job.ProjectNumber && canData.BoringNumber == undone.boringNumber && canData.Depth == undone.depth {
+			if _, err := pkg.RemoveCanFromOven(undone.canNumber); err != nil {
+				undoErrs = append(undoErrs, fmt.Errorf("can not removed from oven tracking: %v", err))
+			}
+		}
+
+		// The cans are free to use again
+		delete(usedMoistureCans, undone.canNumber)
+		if undone.suctionCanNo != "" {
+			delete(usedSuctionCans, undone.suctionCanNo)
+		}
+
+		savedSamples = savedSamples[:len(savedSamples)-1]
+		currentSampleIndex = undone.sampleIndex
+		sampleStartTime = time.Now()
+		if err := pkg.SaveProgress(job.ProjectNumber, currentSampleIndex, totalSamples); err != nil {
+			undoErrs = append(undoErrs, fmt.Errorf("progress not saved: %v", err))
+		}
+
+		updateJobInfo()
+		rebuildForm()
+		app.SetRoot(container, true)
+		app.SetFocus(form.GetFormItem(1))
+
+		if len(undoErrs) > 0 {
+			ShowError(app, fmt.Errorf("sample %s | %s was only partially undone:\n\n%v", undone.boringNumber, undone.depth, errors.Join(undoErrs...)),
+				container, form.GetFormItem(1))
 		}
 	}
 
+	// Ask before undoing, since it throws away the sample's saved data
+	confirmUndo := func() {
+		if len(savedSamples) == 0 {
+			showErrorModal("Nothing to undo.\n\nOnly samples saved since opening this job can be undone.", nil)
+			return
+		}
+		undone := savedSamples[len(savedSamples)-1]
+		savedText := fmt.Sprintf("Can #%s, Can Wt %s g, Wet Wt %s g", undone.canNumber, undone.canWeight, undone.wetWeight)
+		if undone.skipped {
+			savedText = "Skipped (not tested)"
+		}
+		cancelUndo := func() {
+			app.SetRoot(container, true)
+			app.SetFocus(form)
+		}
+		Confirm(app, fmt.Sprintf("Undo the last saved sample?\n\n"+
+			"Boring: %s\nDepth: %s\n%s\n\n"+
+			"Its Excel cells and backup entry are cleared and the can comes out of the oven.\n"+
+			"%d more can be undone after this.",
+			undone.boringNumber, undone.depth, savedText, len(savedSamples)-1), []string{"Undo", "Cancel"}, func(choice int) {
+			if choice == 0 {
+				undoLastSample()
+			} else {
+				cancelUndo()
+			}
+		})
+	}
+
+	// Record the current sample as not tested and move on to the next one
+	skipSample := func(reason string) {
+		logger.Info.Printf("Skipping sample %d/%d - Boring: %s, Depth: %s, Reason: %s",
+			currentSampleIndex+1, totalSamples, boringNumber, depth, reason)
+
+		if err := pkg.SaveSkippedSample(job.ProjectNumber, boringNumber, depth, reason, user.AuditID()); err != nil {
+			logger.Error.Printf("Failed to save skipped sample: %v", err)
+			ShowError(app, fmt.Errorf("sample was not skipped: %v", err), container, form)
+			return
+		}
+
+		var skipErrs []error
+		// Flag the skip on the Moisture sheet so it is visible in Excel too
+		if moistureWriter != nil {
+			if err := moistureWriter.WriteSampleNote(boringNumber, depth, "Skipped: "+reason); err != nil {
+				logger.Error.Printf("Failed to write skip note to Excel: %v", err)
+				skipErrs = append(skipErrs, fmt.Errorf("skip note not written to Excel: %v", err))
+			}
+		}
+
+		savedSamples = append(savedSamples, &pulledSample{
+			boringNumber: boringNumber,
+			depth:        depth,
+			sampleIndex:  currentSampleIndex,
+			skipped:      true,
+		})
+		if limit := pkg.Config.UndoStackSize; limit > 0 && len(savedSamples) > limit {
+			savedSamples = savedSamples[len(savedSamples)-limit:]
+		}
+
+		skippedBoring, skippedDepth := boringNumber, depth
+		currentSampleIndex++
+		skipRecordedSamples()
+		skipNoTestSamples()
+		sampleStartTime = time.Now()
+		if err := pkg.SaveProgress(job.ProjectNumber, currentSampleIndex, totalSamples); err != nil {
+			logger.Error.Printf("Failed to save progress: %v", err)
+			skipErrs = append(skipErrs, fmt.Errorf("progress not saved: %v", err))
+		}
+
+		updateJobInfo()
+		rebuildForm()
+		app.SetRoot(container, true)
+		app.SetFocus(form.GetFormItem(1))
+
+		if len(skipErrs) > 0 {
+			ShowError(app, fmt.Errorf("sample %s | %s was skipped with problems:\n\n%v", skippedBoring, skippedDepth, errors.Join(skipErrs...)),
+				container, form.GetFormItem(1))
+			return
+		}
+
+		if currentSampleIndex >= totalSamples {
+			logger.Info.Printf("All %d samples completed for job %s", totalSamples, job.ProjectNumber)
+			showCompletionScreen(app, user, job, moistureWriter, container, onBack)
+		}
+	}
+
+	// Ask for the reason a sample is being skipped
+	showSkipSampleModal := func() {
+		if currentSampleIndex >= totalSamples {
+			return
+		}
+		if existing, err := pkg.FindSampleBackup(job.ProjectNumber, boringNumber, depth); err == nil && existing != nil && !existing.IsSkipped() {
+			showErrorModal(fmt.Sprintf("Sample %s | %s already has saved data (Can #%s).\n\nEdit or undo it instead of skipping.",
+				boringNumber, depth, existing.CanNumber), nil)
+			return
+		}
+
+		cancelSkip := func() {
+			app.SetRoot(container, true)
+			app.SetFocus(form)
+		}
+
+		skipForm := tview.NewForm()
+		addTextField(skipForm, "Reason", "", 40)
+		skipForm.AddButton("Skip Sample", func() {
+			reason, _ := formText(skipForm, "Reason")
+			if reason == "" {
+				showErrorModal("A reason is required to skip a sample\n\n(e.g. lost sample, insufficient material)", nil)
+				return
+			}
+			skipSample(reason)
+		})
+		skipForm.AddButton("Cancel", cancelSkip)
+		skipForm.SetCancelFunc(cancelSkip)
+
+		skipForm.SetBorder(true).
+			SetTitle(fmt.Sprintf(" Skip Sample - %s | %s ", boringNumber, depth)).
+			SetTitleAlign(tview.AlignCenter).
+			SetBorderColor(tcell.ColorYellow).
+			SetBackgroundColor(tcell.ColorBlack)
+
+		skipForm.SetFieldBackgroundColor(tcell.ColorBlack).
+			SetFieldTextColor(tcell.ColorWhite).
+			SetButtonBackgroundColor(tcell.ColorWhite).
+			SetButtonTextColor(tcell.ColorBlack).
+			SetLabelColor(tcell.ColorWhite).
+			SetBackgroundColor(tcell.ColorBlack)
+
+		// Center the form
+		modal := tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(skipForm, 9, 0, true).
+				AddItem(nil, 0, 1, false), 60, 0, true).
+			AddItem(nil, 0, 1, false)
+
+		modal.SetBackgroundColor(tcell.ColorBlack)
+		app.SetRoot(modal, true)
+		app.SetFocus(skipForm)
+	}
+
+	// Tag the next cans put in the oven as a batch, or end the current one early
+	showBatchModal := func() {
+		backToForm := func() {
+			app.SetRoot(container, true)
+			app.SetFocus(form)
+		}
+
+		if ovenBatch.Active() {
+			Confirm(app, fmt.Sprintf("Batch %s still has %d cans to tag.\n\nEnd it now?", ovenBatch.ID, ovenBatch.Remaining),
+				[]string{"End Batch", "Keep Going"}, func(choice int) {
+					if choice == 0 {
+						logger.Info.Printf("Ended oven batch %s early with %d cans untagged", ovenBatch.ID, ovenBatch.Remaining)
+						ovenBatch = nil
+						updateJobInfo()
+					}
+					backToForm()
+				})
+			return
+		}
+
+		batchForm := tview.NewForm()
+		addTextField(batchForm, "Batch Name", "", 30)
+		batchForm.AddInputField("Number of Cans", "", 6, tview.InputFieldInteger, nil)
+		batchForm.AddButton("Start Batch", func() {
+			name, _ := formText(batchForm, "Batch Name")
+			countText, _ := formText(batchForm, "Number of Cans")
+			count, _ := strconv.Atoi(countText)
+			batch, err := pkg.NewOvenBatch(name, count)
+			if err != nil {
+				showErrorModal(fmt.Sprintf("Can't start the batch: %v", err), nil)
+				return
+			}
+			ovenBatch = batch
+			logger.Info.Printf("Started oven batch %s for the next %d cans (Job: %s)", batch.ID, batch.Remaining, job.ProjectNumber)
+			updateJobInfo()
+			backToForm()
+		})
+		batchForm.AddButton("Cancel", backToForm)
+		batchForm.SetCancelFunc(backToForm)
+
+		batchForm.SetBorder(true).
+			SetTitle(" Oven Batch ").
+			SetTitleAlign(tview.AlignCenter).
+			SetBorderColor(tcell.ColorAqua).
+			SetBackgroundColor(tcell.ColorBlack)
+
+		batchForm.SetFieldBackgroundColor(tcell.ColorBlack).
+			SetFieldTextColor(tcell.ColorWhite).
+			SetButtonBackgroundColor(tcell.ColorWhite).
+			SetButtonTextColor(tcell.ColorBlack).
+			SetLabelColor(tcell.ColorWhite).
+			SetBackgroundColor(tcell.ColorBlack)
+
+		// Center the form
+		modal := tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(batchForm, 11, 0, true).
+				AddItem(nil, 0, 1, false), 60, 0, true).
+			AddItem(nil, 0, 1, false)
+
+		modal.SetBackgroundColor(tcell.ColorBlack)
+		app.SetRoot(modal, true)
+		app.SetFocus(batchForm)
+	}
+
 	// Save sample function (shared by button and keyboard shortcut)
 	saveSample = func() {
 		if currentSampleIndex >= totalSamples {
@@ -335,15 +954,21 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 			return
 		}
 
-		canNum := strings.TrimSpace(form.GetFormItemByLabel("  Can #").(*tview.InputField).GetText())
-		canWeight := strings.TrimSpace(form.GetFormItemByLabel("  Can Weight (g)").(*tview.InputField).GetText())
-		wetWeight := strings.TrimSpace(form.GetFormItemByLabel("  Wet Weight (g)").(*tview.InputField).GetText())
+		values := map[string]string{}
+		for _, label := range []string{"  Can #", "  Can Weight (g)", "  Wet Weight (g)"} {
+			value, ok := formText(form, label)
+			if !ok {
+				showErrorModal(missingFieldError(label).Error(), nil)
+				return
+			}
+			values[label] = value
+		}
+		canNum := values["  Can #"]
+		canWeight := pkg.NormalizeWeight(values["  Can Weight (g)"])
+		wetWeight := pkg.NormalizeWeight(values["  Wet Weight (g)"])
 
 		// Get suction can number only if the field exists
-		suctionNum := ""
-		if suctionItem := form.GetFormItemByLabel("  Suction Can #"); suctionItem != nil {
-			suctionNum = strings.TrimSpace(suctionItem.(*tview.InputField).GetText())
-		}
+		suctionNum, _ := formText(form, "  Suction Can #")
 
 		// Validate required fields
 		if canNum == "" {
@@ -368,6 +993,22 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 			return
 		}
 
+		// Validate can numbers against the configured allowed range
+		if err := pkg.ValidateCanNumber(canNum); err != nil {
+			logger.Error.Printf("Validation failed: %v", err)
+			showErrorModal(fmt.Sprintf("Can # %s is out of range.\n\nAllowed can numbers: %d - %d\n\nPlease check the can number.",
+				canNum, pkg.Config.CanNumberMin, pkg.Config.CanNumberMax), form.GetFormItemByLabel("  Can #"))
+			return
+		}
+		if suctionNum != "" {
+			if err := pkg.ValidateCanNumber(suctionNum); err != nil {
+				logger.Error.Printf("Validation failed: %v", err)
+				showErrorModal(fmt.Sprintf("Suction Can # %s is out of range.\n\nAllowed can numbers: %d - %d\n\nPlease check the can number.",
+					suctionNum, pkg.Config.CanNumberMin, pkg.Config.CanNumberMax), form.GetFormItemByLabel("  Suction Can #"))
+				return
+			}
+		}
+
 		// Validate numeric values and minimum sample weight (100g)
 		canWeightFloat, err := strconv.ParseFloat(canWeight, 64)
 		if err != nil {
@@ -395,43 +1036,24 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 			logger.Info.Printf("Warning: Sample weight (%.2fg) is less than recommended 100g minimum", sampleWeight)
 
 			// Show warning modal with override option
-			modal := tview.NewModal().
-				SetText(fmt.Sprintf("⚠️ Sample Weight Below Minimum\n\n"+
-					"Can Weight: %.2fg\n"+
-					"Wet Weight: %.2fg\n"+
-					"Sample Weight: %.2fg\n\n"+
-					"Recommended minimum: 100g\n"+
-					"This sample is %.2fg under the minimum.\n\n"+
-					"Do you want to proceed anyway?\n\n"+
-					"[1] Override & Save    [2] Cancel",
-					canWeightFloat, wetWeightFloat, sampleWeight, 100.0-sampleWeight)).
-				AddButtons([]string{"Override & Save", "Cancel"}).
-				SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-					if buttonLabel == "Override & Save" {
-						logger.Info.Printf("User overrode minimum sample weight warning for %.2fg sample", sampleWeight)
-						// Continue with save - call the rest of saveSample logic
-						continueSaveSample(canNum, canWeight, wetWeight, suctionNum)
-					} else {
-						// Cancel - go back to form
-						app.SetRoot(container, true)
-						app.SetFocus(form.GetFormItemByLabel("  Wet Weight (g)"))
-					}
-				})
-			modal.SetBackgroundColor(tcell.ColorBlack)
-			// Add keyboard shortcut support for 1 and 2
-			modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-				if event.Rune() == '1' {
+			Confirm(app, fmt.Sprintf("⚠️ Sample Weight Below Minimum\n\n"+
+				"Can Weight: %.2fg\n"+
+				"Wet Weight: %.2fg\n"+
+				"Sample Weight: %.2fg\n\n"+
+				"Recommended minimum: 100g\n"+
+				"This sample is %.2fg under the minimum.\n\n"+
+				"Do you want to proceed anyway?",
+				canWeightFloat, wetWeightFloat, sampleWeight, 100.0-sampleWeight), []string{"Override & Save", "Cancel"}, func(choice int) {
+				if choice == 0 {
 					logger.Info.Printf("User overrode minimum sample weight warning for %.2fg sample", sampleWeight)
+					// Continue with save - call the rest of saveSample logic
 					continueSaveSample(canNum, canWeight, wetWeight, suctionNum)
-					return nil
-				} else if event.Rune() == '2' {
+				} else {
+					// Cancel - go back to form
 					app.SetRoot(container, true)
 					app.SetFocus(form.GetFormItemByLabel("  Wet Weight (g)"))
-					return nil
 				}
-				return event
 			})
-			app.SetRoot(modal, true)
 			return
 		}
 
@@ -469,17 +1091,10 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 		// Handle / key to reset all fields for current sample
 		if event.Rune() == '/' {
 			// Clear all input fields
-			if canField := form.GetFormItemByLabel("  Can #"); canField != nil {
-				canField.(*tview.InputField).SetText("")
-			}
-			if canWeightField := form.GetFormItemByLabel("  Can Weight (g)"); canWeightField != nil {
-				canWeightField.(*tview.InputField).SetText("")
-			}
-			if wetWeightField := form.GetFormItemByLabel("  Wet Weight (g)"); wetWeightField != nil {
-				wetWeightField.(*tview.InputField).SetText("")
-			}
-			if suctionField := form.GetFormItemByLabel("  Suction Can #"); suctionField != nil {
-				suctionField.(*tview.InputField).SetText("")
+			for _, label := range []string{"  Can #", "  Can Weight (g)", "  Wet Weight (g)", "  Suction Can #"} {
+				if field := formInput(form, label); field != nil {
+					field.SetText("")
+				}
 			}
 			// Focus back to first input field
 			app.SetFocus(form.GetFormItem(1))
@@ -602,7 +1217,7 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 			coloredProgressBar,
 			elapsedStr,
 			avgTime,
-			currentSampleIndex))
+			recordedSamples))
 	}
 
 	// Initial update
@@ -642,41 +1257,146 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 		AddItem(rightSide, 0, 1, false)
 
 	// Instructions at bottom
+	instructionsText := "Tab: Next Field  |  Enter: Save Sample  |  /: Reset Fields  |  -: Edit Last Sample  |  Ctrl+Z: Undo  |  Ctrl+S: Skip  |  Ctrl+N: Notes  |  Ctrl+Y: Copy  |  Ctrl+B: Batch  |  Ctrl+R: Recheck  |  +: Back to Menu  |  ?: Help"
 	instructions := tview.NewTextView().
-		SetText("Tab: Next Field  |  Enter: Save Sample  |  /: Reset Fields  |  -: Edit Last Sample  |  +: Back to Menu").
+		SetText(instructionsText).
 		SetTextAlign(tview.AlignCenter).
-		SetDynamicColors(true).
-		SetBackgroundColor(tcell.ColorBlack)
+		SetDynamicColors(true)
+	instructions.SetBackgroundColor(tcell.ColorBlack)
 
 	// Container with instructions - FULLSCREEN
 	container = tview.NewFlex().
-		SetDirection(tview.FlexRow).
-		AddItem(mainContent, 0, 1, true).
+		SetDirection(tview.FlexRow)
+	AddTrainingBanner(container)
+	container.AddItem(mainContent, 0, 1, true).
 		AddItem(instructions, 1, 0, false)
 
+	// Flag in the title when the duplicate-can safety net is turned off in config
+	title := userTitle(fmt.Sprintf("Pull Sample - Job %s", job.ProjectNumber), user)
+	if !pkg.Config.CheckDuplicateCans {
+		title += "[red]" + tview.Escape("[DUP CHECK OFF]") + "[-] "
+	}
+
 	container.SetBorder(true).
-		SetTitle(fmt.Sprintf(" Pull Sample - Job %s ", job.ProjectNumber)).
+		SetTitle(title).
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorWhite).
 		SetBackgroundColor(tcell.ColorBlack)
 
+	// Show the resume prompt and the unmapped-sample report before any data is entered, in
+	// that order; setup failures are shown first and return to them
+	var startScreen tview.Primitive = container
+	var startFocus tview.Primitive = form
+	if len(unmappedSamples) > 0 {
+		report := newUnmappedSamplesModal(app, unmappedSamples, len(samples), container, form, onBack)
+		startScreen, startFocus = report, report
+	}
+	if resuming {
+		prompt := newResumePrompt(app, job, samples, currentSampleIndex, startScreen, startFocus, func() {
+			currentSampleIndex = 0
+			skipRecordedSamples()
+			skipNoTestSamples()
+			logger.Info.Printf("Starting job %s over from sample %d instead of resuming", job.ProjectNumber, currentSampleIndex+1)
+			if err := pkg.SaveProgress(job.ProjectNumber, currentSampleIndex, totalSamples); err != nil {
+				logger.Error.Printf("Failed to save progress after starting over: %v", err)
+			}
+			sampleStartTime = time.Now()
+			updateJobInfo()
+			rebuildForm()
+		}, func() {
+			if moistureWriter != nil {
+				moistureWriter.Close()
+			}
+			onBack()
+		})
+		startScreen, startFocus = prompt, prompt
+	}
+	if startScreen != container && len(initErrs) == 0 {
+		go app.QueueUpdateDraw(func() {
+			app.SetRoot(startScreen, true)
+		})
+	}
+
+	// Surface setup failures once the screen is shown
+	if len(initErrs) > 0 {
+		QueueError(app, fmt.Errorf("problems opening job %s:\n\n%v", job.ProjectNumber, errors.Join(initErrs...)), startScreen, startFocus)
+	}
+
 	// Input capture for back navigation and edit last sample
 	container.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyCtrlN {
+			toggleNotes()
+			return nil
+		}
+		if event.Key() == tcell.KeyCtrlS {
+			showSkipSampleModal()
+			return nil
+		}
+		if event.Key() == tcell.KeyCtrlZ {
+			confirmUndo()
+			return nil
+		}
+		if event.Key() == tcell.KeyCtrlB {
+			showBatchModal()
+			return nil
+		}
+		if event.Key() == tcell.KeyCtrlR {
+			// Flag a doubtful reading for QA without stopping to write a note
+			if len(savedSamples) == 0 {
+				Alert(app, "No samples have been saved yet.\n\nSave the sample first, then flag it for recheck.", container, form)
+				return nil
+			}
+			last := savedSamples[len(savedSamples)-1]
+			existing, err := pkg.FindSampleBackup(job.ProjectNumber, last.boringNumber, last.depth)
+			if err == nil && existing == nil {
+				err = fmt.Errorf("sample %s|%s not found in backup", last.boringNumber, last.depth)
+			}
+			if err == nil {
+				err = pkg.SetSampleNeedsRecheck(job.ProjectNumber, last.boringNumber, last.depth, !existing.NeedsRecheck)
+			}
+			if err != nil {
+				ShowError(app, err, container, form)
+				return nil
+			}
+			status := fmt.Sprintf("[fuchsia]⚑ %s | %s flagged for recheck[-]", last.boringNumber, last.depth)
+			if existing.NeedsRecheck {
+				status = fmt.Sprintf("[green]Recheck flag cleared for %s | %s[-]", last.boringNumber, last.depth)
+			}
+			flashStatus(app, instructions, status, func() string { return instructionsText })
+			return nil
+		}
+		if event.Key() == tcell.KeyCtrlO {
+			if _, err := pkg.OpenJobFolder(job); err != nil {
+				ShowError(app, err, container, form)
+			}
+			return nil
+		}
+		if event.Key() == tcell.KeyCtrlY {
+			if currentSampleIndex >= totalSamples {
+				return nil
+			}
+			sample := samples[currentSampleIndex]
+			if err := pkg.CopyToClipboard(pkg.SampleClipboardText(job.ProjectNumber, sample)); err != nil {
+				ShowError(app, err, container, form)
+				return nil
+			}
+			flashStatus(app, instructions, fmt.Sprintf("[green]Copied %s | %s to the clipboard[-]", sample.BoringNumber, sample.Depth),
+				func() string { return instructionsText })
+			return nil
+		}
+		// In Notes, '-' and '+' are part of the text; only the Ctrl shortcuts above apply
+		if TypingText(app) {
+			return event
+		}
 		if event.Rune() == '-' {
 			// Edit last sample
-			if lastSampleData.sampleIndex >= 0 {
-				showEditLastSampleModal(app, job, &lastSampleData, moistureWriter, container, form)
+			if len(savedSamples) > 0 && savedSamples[len(savedSamples)-1].skipped {
+				showErrorModal("The last sample was skipped, so there are no weights to edit.\n\nUse Ctrl+Z to undo the skip.", nil)
+			} else if len(savedSamples) > 0 {
+				showEditLastSampleModal(app, job, savedSamples[len(savedSamples)-1], moistureWriter, container, form)
 			} else {
 				// No samples saved yet
-				modal := tview.NewModal().
-					SetText("No samples have been saved yet.\n\nSave at least one sample before using edit feature.").
-					AddButtons([]string{"OK"}).
-					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-						app.SetRoot(container, true)
-						app.SetFocus(form)
-					})
-				modal.SetBackgroundColor(tcell.ColorBlack)
-				app.SetRoot(modal, true)
+				Alert(app, "No samples have been saved yet.\n\nSave at least one sample before using edit feature.", container, form)
 			}
 			return nil
 		}
@@ -684,28 +1404,8 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 			// Check if job is not complete
 			if currentSampleIndex < totalSamples {
 				// Show confirmation modal
-				modal := tview.NewModal().
-					SetText(fmt.Sprintf("You have completed %d of %d samples.\n\nAre you sure you want to stop for now?\n\n[1] Yes, Stop    [2] No, Continue", currentSampleIndex, totalSamples)).
-					AddButtons([]string{"Yes, Stop", "No, Continue"}).
-					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-						if buttonLabel == "Yes, Stop" {
-							logger.Info.Printf("User confirmed stop - Samples completed: %d/%d, Total time: %v", currentSampleIndex, totalSamples, time.Since(startTime))
-							// Close the moisture writer (this also closes the shared file)
-							if moistureWriter != nil {
-								moistureWriter.Close()
-								logger.Info.Printf("Closed Lab file for job %s", job.ProjectNumber)
-							}
-							onBack()
-						} else {
-							// Go back to form
-							app.SetRoot(container, true)
-							app.SetFocus(form)
-						}
-					})
-				modal.SetBackgroundColor(tcell.ColorBlack)
-				// Add keyboard shortcut support for 1 and 2
-				modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-					if event.Rune() == '1' {
+				Confirm(app, fmt.Sprintf("You have completed %d of %d samples.\n\nAre you sure you want to stop for now?", currentSampleIndex, totalSamples), []string{"Yes, Stop", "No, Continue"}, func(choice int) {
+					if choice == 0 {
 						logger.Info.Printf("User confirmed stop - Samples completed: %d/%d, Total time: %v", currentSampleIndex, totalSamples, time.Since(startTime))
 						// Close the moisture writer (this also closes the shared file)
 						if moistureWriter != nil {
@@ -713,20 +1413,16 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 							logger.Info.Printf("Closed Lab file for job %s", job.ProjectNumber)
 						}
 						onBack()
-						return nil
-					} else if event.Rune() == '2' {
+					} else {
 						// Go back to form
 						app.SetRoot(container, true)
 						app.SetFocus(form)
-						return nil
 					}
-					return event
 				})
-				app.SetRoot(modal, true)
 			} else {
 				// Job is complete, show completion screen
 				logger.Info.Printf("All samples completed for job %s", job.ProjectNumber)
-				showCompletionScreen(app, job, moistureWriter, container, onBack)
+				showCompletionScreen(app, user, job, moistureWriter, container, onBack)
 			}
 			return nil
 		}
@@ -736,15 +1432,7 @@ func NewPullSampleScreen(app *tview.Application, job models.Job, onBack func())
 	return container
 }
 
-func showEditLastSampleModal(app *tview.Application, job models.Job, lastSample *struct {
-	boringNumber string
-	depth        string
-	canNumber    string
-	canWeight    string
-	wetWeight    string
-	suctionCanNo string
-	sampleIndex  int
-}, moistureWriter *pkg.MoistureTestWriter, returnContainer tview.Primitive, returnFocus tview.Primitive) {
+func showEditLastSampleModal(app *tview.Application, job models.Job, lastSample *pulledSample, moistureWriter *pkg.MoistureTestWriter, returnContainer tview.Primitive, returnFocus tview.Primitive) {
 
 	logger.Info.Printf("Opening edit last sample modal for %s | %s", lastSample.boringNumber, lastSample.depth)
 
@@ -759,17 +1447,18 @@ func showEditLastSampleModal(app *tview.Application, job models.Job, lastSample
 
 	editForm.AddButton("Save Changes", func() {
 		// Get updated values
-		newCanNo := strings.TrimSpace(editForm.GetFormItemByLabel("Can #").(*tview.InputField).GetText())
-		newCanWeight := strings.TrimSpace(editForm.GetFormItemByLabel("Can Weight (g)").(*tview.InputField).GetText())
-		newWetWeight := strings.TrimSpace(editForm.GetFormItemByLabel("Wet Weight (g)").(*tview.InputField).GetText())
-		newSuctionCanNo := ""
-		if suctionField := editForm.GetFormItemByLabel("Suction Can #"); suctionField != nil {
-			newSuctionCanNo = strings.TrimSpace(suctionField.(*tview.InputField).GetText())
+		newCanNo, okCan := formText(editForm, "Can #")
+		newCanWeight, okCanWeight := formText(editForm, "Can Weight (g)")
+		newWetWeight, okWetWeight := formText(editForm, "Wet Weight (g)")
+		if !okCan || !okCanWeight || !okWetWeight {
+			Alert(app, missingFieldError("Can #, Can Weight or Wet Weight").Error(), returnContainer, returnFocus)
+			return
 		}
+		newSuctionCanNo, _ := formText(editForm, "Suction Can #")
 
 		// Validate
 		if newCanNo == "" || newCanWeight == "" || newWetWeight == "" {
-			showEditErrorModal(app, "Can #, Can Weight, and Wet Weight are required", returnContainer, returnFocus)
+			Alert(app, "Can #, Can Weight, and Wet Weight are required", returnContainer, returnFocus)
 			return
 		}
 
@@ -781,11 +1470,11 @@ func showEditLastSampleModal(app *tview.Application, job models.Job, lastSample
 			lastSample.suctionCanNo, newSuctionCanNo)
 
 		// Load backup data
-		backupFile := fmt.Sprintf("ex_project/%s/backup.json", job.ProjectNumber)
+		backupFile := pkg.BackupPath(job.ProjectNumber)
 		backupData, err := pkg.LoadBackupData(backupFile)
 		if err != nil {
 			logger.Error.Printf("Failed to load backup data: %v", err)
-			showEditErrorModal(app, fmt.Sprintf("Failed to load backup:\n%v", err), returnContainer, returnFocus)
+			Alert(app, fmt.Sprintf("Failed to load backup:\n%v", err), returnContainer, returnFocus)
 			return
 		}
 
@@ -805,22 +1494,26 @@ func showEditLastSampleModal(app *tview.Application, job models.Job, lastSample
 
 		if !sampleFound {
 			logger.Error.Printf("Could not find sample in backup: %s|%s", lastSample.boringNumber, lastSample.depth)
-			showEditErrorModal(app, "Sample not found in backup file", returnContainer, returnFocus)
+			Alert(app, "Sample not found in backup file", returnContainer, returnFocus)
 			return
 		}
 
 		// Save backup
 		if err := pkg.SaveBackupDataToFile(backupData, backupFile); err != nil {
 			logger.Error.Printf("Failed to save backup: %v", err)
-			showEditErrorModal(app, fmt.Sprintf("Failed to save backup:\n%v", err), returnContainer, returnFocus)
+			Alert(app, fmt.Sprintf("Failed to save backup:\n%v", err), returnContainer, returnFocus)
 			return
 		}
 
 		// Update Excel file - moisture data
+		if moistureWriter == nil {
+			ShowError(app, fmt.Errorf("backup updated, but the Lab file is not open so Excel was not changed"), returnContainer, returnFocus)
+			return
+		}
 		err = moistureWriter.WriteMoistureSample(lastSample.boringNumber, lastSample.depth, newCanNo, newCanWeight, newWetWeight)
 		if err != nil {
 			logger.Error.Printf("Failed to write moisture sample: %v", err)
-			showEditErrorModal(app, fmt.Sprintf("Failed to update moisture data:\n%v", err), returnContainer, returnFocus)
+			Alert(app, fmt.Sprintf("Failed to update moisture data:\n%v", err), returnContainer, returnFocus)
 			return
 		}
 
@@ -828,13 +1521,14 @@ func showEditLastSampleModal(app *tview.Application, job models.Job, lastSample
 		if newSuctionCanNo != "" {
 			suctionWriter, err := pkg.InitSoilSuctionFile(job.ProjectNumber, moistureWriter.GetFile())
 			if err != nil {
-				logger.Error.Printf("Failed to initialize suction writer: %v", err)
-			} else {
-				defer suctionWriter.Close()
-				err = suctionWriter.WriteSoilSuctionSample(lastSample.boringNumber, lastSample.depth, newSuctionCanNo)
-				if err != nil {
-					logger.Error.Printf("Failed to write suction sample: %v", err)
-				}
+				ShowError(app, fmt.Errorf("failed to update suction data: %v", err), returnContainer, returnFocus)
+				return
+			}
+			defer suctionWriter.Close()
+			err = suctionWriter.WriteSoilSuctionSample(lastSample.boringNumber, lastSample.depth, newSuctionCanNo)
+			if err != nil {
+				ShowError(app, fmt.Errorf("failed to update suction data: %v", err), returnContainer, returnFocus)
+				return
 			}
 		}
 
@@ -847,21 +1541,15 @@ func showEditLastSampleModal(app *tview.Application, job models.Job, lastSample
 		logger.Info.Printf("Successfully updated last sample")
 
 		// Show success message
-		successModal := tview.NewModal().
-			SetText("Last sample updated successfully!").
-			AddButtons([]string{"OK"}).
-			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-				app.SetRoot(returnContainer, true)
-				app.SetFocus(returnFocus)
-			})
-		successModal.SetBackgroundColor(tcell.ColorBlack)
-		app.SetRoot(successModal, true)
+		Alert(app, "Last sample updated successfully!", returnContainer, returnFocus)
 	})
 
-	editForm.AddButton("Cancel", func() {
+	cancelEdit := func() {
 		app.SetRoot(returnContainer, true)
 		app.SetFocus(returnFocus)
-	})
+	}
+	editForm.AddButton("Cancel", cancelEdit)
+	editForm.SetCancelFunc(cancelEdit)
 
 	editForm.SetBorder(true).
 		SetTitle(fmt.Sprintf(" Edit Last Sample - %s | %s ", lastSample.boringNumber, lastSample.depth)).
@@ -890,19 +1578,13 @@ func showEditLastSampleModal(app *tview.Application, job models.Job, lastSample
 	app.SetFocus(editForm)
 }
 
-func showEditErrorModal(app *tview.Application, message string, returnContainer tview.Primitive, returnFocus tview.Primitive) {
-	modal := tview.NewModal().
-		SetText(message).
-		AddButtons([]string{"OK"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			app.SetRoot(returnContainer, true)
-			app.SetFocus(returnFocus)
-		})
-	modal.SetBackgroundColor(tcell.ColorBlack)
-	app.SetRoot(modal, true)
-}
+func showCompletionScreen(app *tview.Application, user *pkg.User, job models.Job, moistureWriter *pkg.MoistureTestWriter, returnContainer tview.Primitive, onBack func()) {
+	SetScreenShortcuts("Job Complete", []Shortcut{
+		{"1", "Finish job (marks it completed)"},
+		{"2", "Print suction sheet"},
+		{"3", "Print moisture content sheet"},
+	})
 
-func showCompletionScreen(app *tview.Application, job models.Job, moistureWriter *pkg.MoistureTestWriter, returnContainer tview.Primitive, onBack func()) {
 	// Completion message
 	completionText := tview.NewTextView().
 		SetText(fmt.Sprintf("[green]✓ All samples completed for Job %s![white]\n\nWhat would you like to do next?", job.ProjectNumber)).
@@ -916,8 +1598,13 @@ func showCompletionScreen(app *tview.Application, job models.Job, moistureWriter
 
 	// Create menu options
 	menu = tview.NewList().
-		AddItem("Finish Job", "Close files and return to main menu", '1', func() {
+		AddItem("Finish Job", "Mark the job completed, close files and return to main menu", '1', func() {
 			logger.Info.Printf("Finishing job %s", job.ProjectNumber)
+			// Record the explicit finish so job lists show it as completed
+			if err := pkg.MarkJobComplete(job.ProjectNumber); err != nil {
+				ShowError(app, fmt.Errorf("failed to mark job %s complete: %v", job.ProjectNumber, err), completionContainer, menu)
+				return
+			}
 			// Close the moisture writer
 			if moistureWriter != nil {
 				moistureWriter.Close()
@@ -927,13 +1614,16 @@ func showCompletionScreen(app *tview.Application, job models.Job, moistureWriter
 		}).
 		AddItem("Print Suction Sheet", "Print the soil suction test sheet", '2', func() {
 			logger.Info.Printf("Printing suction sheet for job %s", job.ProjectNumber)
-			// TODO: Implement print suction sheet functionality
-			showInfoModal(app, "Print Suction Sheet feature is coming soon!\n\nPress Enter to continue", completionContainer, menu)
+			// The separate suction file is the sheet, filled in as each suction can was saved
+			printExport(app, "Suction sheet", func() (string, error) {
+				return pkg.SoilSuctionSheet(job.ProjectNumber)
+			}, completionContainer, menu)
 		}).
 		AddItem("Print Moisture Content Sheet", "Print the moisture content test sheet", '3', func() {
 			logger.Info.Printf("Printing moisture content sheet for job %s", job.ProjectNumber)
-			// TODO: Implement print moisture content sheet functionality
-			showInfoModal(app, "Print Moisture Content Sheet feature is coming soon!\n\nPress Enter to continue", completionContainer, menu)
+			printExport(app, "Moisture content sheet", func() (string, error) {
+				return pkg.ExportMoistureSheet(job.ProjectNumber)
+			}, completionContainer, menu)
 		})
 
 	// Create container
@@ -943,7 +1633,7 @@ func showCompletionScreen(app *tview.Application, job models.Job, moistureWriter
 		AddItem(menu, 0, 1, true)
 
 	completionContainer.SetBorder(true).
-		SetTitle(" Job Complete ").
+		SetTitle(userTitle("Job Complete", user)).
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorGreen).
 		SetBackgroundColor(tcell.ColorBlack)
@@ -952,16 +1642,94 @@ func showCompletionScreen(app *tview.Application, job models.Job, moistureWriter
 	app.SetFocus(menu)
 }
 
-func showInfoModal(app *tview.Application, message string, returnTo tview.Primitive, focusTo tview.Primitive) {
-	modal := tview.NewModal().
-		SetText(message).
-		AddButtons([]string{"OK"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			app.SetRoot(returnTo, true)
-			if focusTo != nil {
-				app.SetFocus(focusTo)
+// newResumePrompt asks whether to resume a job at its saved place (index) or start from the
+// first sample. Both continue to next; starting over calls startOver first, after warning
+// that saving samples again overwrites their backup entries. goBack leaves the job.
+func newResumePrompt(app *tview.Application, job models.Job, samples []pkg.SampleData, index int,
+	next tview.Primitive, nextFocus tview.Primitive, startOver func(), goBack func()) *tview.Modal {
+
+	place := fmt.Sprintf("at sample %d of %d", index+1, len(samples))
+	if index < len(samples) {
+		place += fmt.Sprintf(" (%s %s)", samples[index].BoringNumber, samples[index].Depth)
+	} else {
+		place = fmt.Sprintf("at the end of the list (%d samples)", len(samples))
+	}
+
+	proceed := func() {
+		app.SetRoot(next, true)
+		app.SetFocus(nextFocus)
+	}
+
+	var prompt *tview.Modal
+	confirmStartOver := func() {
+		tested, skipped, err := pkg.CountRecordedSamples(job.ProjectNumber)
+		if err != nil {
+			logger.Error.Printf("Failed to count recorded samples: %v", err)
+		}
+		if tested+skipped == 0 {
+			startOver()
+			proceed()
+			return
+		}
+		Confirm(app, fmt.Sprintf("⚠ Start job %s from the beginning?\n\n"+
+			"%d samples already have backup entries (%d skipped). Saving any of them again "+
+			"overwrites its backup entry and Excel cells.", job.ProjectNumber, tested+skipped, skipped),
+			[]string{"Start Over", "Go Back"}, func(choice int) {
+				if choice == 0 {
+					startOver()
+					proceed()
+				} else {
+					app.SetRoot(prompt, true)
+				}
+			})
+	}
+
+	prompt = newChoiceModal(fmt.Sprintf("Resume job %s %s, or start from the beginning?", job.ProjectNumber, place),
+		[]string{"Resume", "Start Over", "Leave Job"}, func(choice int) {
+			switch choice {
+			case 0:
+				logger.Info.Printf("Resuming job %s %s", job.ProjectNumber, place)
+				proceed()
+			case 1:
+				confirmStartOver()
+			default:
+				logger.Info.Printf("Leaving job %s from the resume prompt", job.ProjectNumber)
+				goBack()
 			}
 		})
-	modal.SetBackgroundColor(tcell.ColorBlack)
-	app.SetRoot(modal, true)
+	return prompt
+}
+
+// newUnmappedSamplesModal warns that some samples have no Moisture column and will not be
+// saved, letting the tech continue anyway or go back before entering any data
+func newUnmappedSamplesModal(app *tview.Application, unmapped []pkg.SampleData, total int,
+	container tview.Primitive, form *tview.Form, onBack func()) *tview.Modal {
+
+	var listed []string
+	for _, sample := range unmapped[:min(len(unmapped), 10)] {
+		listed = append(listed, sample.BoringNumber+" "+sample.Depth)
+	}
+	list := strings.Join(listed, ", ")
+	if len(unmapped) > len(listed) {
+		list += fmt.Sprintf(", … and %d more", len(unmapped)-len(listed))
+	}
+
+	continuePull := func() {
+		logger.Info.Printf("Continuing pull with %d unmapped samples", len(unmapped))
+		app.SetRoot(container, true)
+		app.SetFocus(form)
+	}
+	goBack := func() {
+		logger.Info.Println("Leaving pull because of unmapped samples")
+		onBack()
+	}
+
+	return newChoiceModal(fmt.Sprintf("⚠ %d of %d samples have no Moisture column and will not be saved:\n\n%s\n\n"+
+		"Check the Moisture sheets in the Lab file.", len(unmapped), total, list), []string{"Continue", "Go Back"}, func(choice int) {
+		if choice == 0 {
+			continuePull()
+		} else {
+			goBack()
+		}
+	})
 }
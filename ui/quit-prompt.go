@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+var (
+	quitPromptVisible bool
+	quitPromptJobs    []string
+	quitView          *tview.TextView
+)
+
+// QuitPromptVisible reports whether the unsaved-sample quit prompt is displayed
+func QuitPromptVisible() bool {
+	return quitPromptVisible
+}
+
+// RequestQuit quits the app, first asking if a pull session has a sample typed in but not
+// saved. Like the help overlay, the prompt is drawn over the current screen so going back
+// leaves the screen exactly as it was.
+func RequestQuit(app *tview.Application) {
+	jobs := pkg.UnsavedPullSessions()
+	if len(jobs) == 0 {
+		quitApp(app)
+		return
+	}
+	logger.Info.Printf("Quit requested with unsaved sample input for job %s", strings.Join(jobs, ", "))
+	quitPromptJobs = jobs
+	quitPromptVisible = true
+}
+
+// HandleQuitPromptKey handles a key while the quit prompt is showing: 1 or Esc goes back
+// to the sample, 2 quits without it
+func HandleQuitPromptKey(app *tview.Application, event *tcell.EventKey) {
+	switch {
+	case event.Rune() == '1' || event.Key() == tcell.KeyEscape:
+		logger.Info.Println("Quit cancelled to finish the unsaved sample")
+		quitPromptVisible = false
+	case event.Rune() == '2':
+		logger.Info.Printf("Quitting without the unsaved sample for job %s", strings.Join(quitPromptJobs, ", "))
+		quitPromptVisible = false
+		quitApp(app)
+	}
+}
+
+// quitApp saves progress and closes the Lab file of any open pull session, then stops the app
+func quitApp(app *tview.Application) {
+	if jobs := pkg.ShutdownPullSessions(); len(jobs) > 0 {
+		logger.Info.Printf("Saved progress for jobs %s before quitting", strings.Join(jobs, ", "))
+	}
+	logger.Info.Println("Application quitting")
+	app.Stop()
+}
+
+// DrawQuitPrompt draws the quit prompt on top of the current screen.
+// It is called from the application's after-draw function alongside the help overlay.
+func DrawQuitPrompt(screen tcell.Screen) {
+	if !quitPromptVisible {
+		return
+	}
+
+	text := fmt.Sprintf("Job %s has a sample typed in that hasn't been saved.\n\n"+
+		"Progress and the Lab file are saved either way.\n\n"+
+		"[1] Go Back and Save It    [2] Quit Without It", strings.Join(quitPromptJobs, ", "))
+
+	if quitView == nil {
+		quitView = tview.NewTextView().
+			SetTextAlign(tview.AlignCenter).
+			SetWordWrap(true)
+		quitView.SetBorder(true).
+			SetTitle(" Quit LMS? ").
+			SetTitleAlign(tview.AlignCenter).
+			SetBorderColor(tcell.ColorYellow).
+			SetBackgroundColor(tcell.ColorBlack)
+	}
+	quitView.SetText(text)
+
+	// Center the prompt on screen
+	screenWidth, screenHeight := screen.Size()
+	width := 60
+	if width > screenWidth {
+		width = screenWidth
+	}
+	height := 9
+	if height > screenHeight {
+		height = screenHeight
+	}
+	quitView.SetRect((screenWidth-width)/2, (screenHeight-height)/2, width, height)
+	quitView.Draw(screen)
+}
@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// managerOnly returns a modal turning the user away when they are not a lab manager, or nil
+// when they may continue. Restricted screens call it first so hiding the menu item is not
+// the only thing keeping a tech out.
+func managerOnly(app *tview.Application, user *pkg.User, screenName string, onBack func()) tview.Primitive {
+	if user.IsManager() {
+		return nil
+	}
+
+	userID := "unknown"
+	if user != nil {
+		userID = user.ID
+	}
+	logger.Info.Printf("User %s denied access to %s (manager only)", userID, screenName)
+
+	return newAlertModal(fmt.Sprintf("%s is only available to lab managers.\n\nPress Enter to go back", screenName), onBack)
+}
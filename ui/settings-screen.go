@@ -0,0 +1,344 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// setting is one editable AppConfig field on the Settings screen
+type setting struct {
+	label   string
+	isBool  bool
+	restart bool // Only read at startup, so a change needs the app restarted
+	get     func() string
+	set     func(value string) error // Parses and stores the new value
+}
+
+func boolSetting(label string, field *bool, restart bool, apply func()) setting {
+	return setting{
+		label:   label,
+		isBool:  true,
+		restart: restart,
+		get: func() string {
+			if *field {
+				return "On"
+			}
+			return "Off"
+		},
+		set: func(string) error {
+			*field = !*field
+			if apply != nil {
+				apply()
+			}
+			return nil
+		},
+	}
+}
+
+func intSetting(label string, field *int, restart bool) setting {
+	return setting{
+		label:   label,
+		restart: restart,
+		get:     func() string { return strconv.Itoa(*field) },
+		set: func(value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%s must be a whole number of 0 or more", label)
+			}
+			*field = n
+			return nil
+		},
+	}
+}
+
+func floatSetting(label string, field *float64, restart bool) setting {
+	return setting{
+		label:   label,
+		restart: restart,
+		get:     func() string { return strconv.FormatFloat(*field, 'f', -1, 64) },
+		set: func(value string) error {
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%s must be a number of 0 or more", label)
+			}
+			*field = n
+			return nil
+		},
+	}
+}
+
+func stringSetting(label string, field *string, restart bool, check func(string) error) setting {
+	return setting{
+		label:   label,
+		restart: restart,
+		get:     func() string { return *field },
+		set: func(value string) error {
+			if check != nil {
+				if err := check(value); err != nil {
+					return err
+				}
+			}
+			*field = value
+			return nil
+		},
+	}
+}
+
+// editableSettings lists the config fields the Settings screen can change. Key remaps,
+// ovens and the sheet layouts stay in config.json.
+func editableSettings() []setting {
+	c := &pkg.Config
+	return []setting{
+		boolSetting("Check duplicate cans", &c.CheckDuplicateCans, false, func() {
+			pkg.CheckDuplicateCans = c.CheckDuplicateCans
+		}),
+		boolSetting("Suction/moisture can overlap", &c.CheckSuctionCanOverlap, false, nil),
+		boolSetting("Confirm each sample", &c.ConfirmEachSample, false, nil),
+		boolSetting("Numeric validation", &c.EnableNumericValidation, false, nil),
+		boolSetting("Accept decimal comma", &c.AcceptDecimalComma, false, nil),
+		boolSetting("Moisture as formulas", &c.MoistureFormulas, false, nil),
+		boolSetting("Backup on save", &c.BackupOnSave, false, nil),
+		boolSetting("Keep original Lab file", &c.KeepOriginalLabFile, false, nil),
+		boolSetting("Training mode (dry run)", &c.DryRun, true, nil),
+		stringSetting("Log level", &c.LogLevel, false, func(value string) error {
+			switch value {
+			case "debug", "info", "error":
+				logger.SetLevel(value)
+				return nil
+			}
+			return fmt.Errorf("log level must be debug, info or error")
+		}),
+		stringSetting("Log directory", &c.LogDir, true, nil),
+		intSetting("Auto-save interval (s)", &c.AutoSaveIntervalSeconds, false),
+		intSetting("Max samples per job", &c.MaxSamplesPerJob, false),
+		intSetting("Oven dry time (hours)", &c.OvenDryTimeHours, false),
+		intSetting("Recent oven cans (hours)", &c.RecentOvenHours, false),
+		intSetting("Can # minimum", &c.CanNumberMin, false),
+		intSetting("Can # maximum", &c.CanNumberMax, false),
+		floatSetting("Moisture warning max (%)", &c.MoistureContentWarnMax, false),
+		intSetting("Undo stack size", &c.UndoStackSize, false),
+		intSetting("Suction rows per sheet", &c.SuctionRowsPerSheet, false),
+		intSetting("Minimum PIN length", &c.MinPINLength, false),
+		stringSetting("Boring label pattern", &c.BoringPattern, false, func(value string) error {
+			if _, err := regexp.Compile(value); err != nil || value == "" {
+				return fmt.Errorf("boring label pattern must be a valid regexp")
+			}
+			return nil
+		}),
+		stringSetting("Timezone", &c.Timezone, false, func(value string) error {
+			if _, err := time.LoadLocation(value); err != nil {
+				return fmt.Errorf("timezone must be an IANA name like America/Chicago, or empty for local time")
+			}
+			return nil
+		}),
+		stringSetting("Workstation oven", &c.WorkstationOven, false, nil),
+		stringSetting("Open folder command", &c.OpenFolderCommand, false, nil),
+		stringSetting("Print command", &c.PrintCommand, false, nil),
+		stringSetting("Print converter", &c.PrintConverter, false, nil),
+		stringSetting("Clipboard command", &c.ClipboardCommand, false, nil),
+		stringSetting("Project root", &c.ProjectRoot, true, nil),
+	}
+}
+
+// NewSettingsScreen lets lab managers change config.json from inside the app
+func NewSettingsScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.Table) {
+	if denied := managerOnly(app, user, "Settings", onBack); denied != nil {
+		return denied, nil
+	}
+
+	SetScreenShortcuts("Settings", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"Enter", "Toggle an On/Off setting or edit a value"},
+		{"+", "Back to LMS"},
+	})
+
+	logger.Info.Println("Opening Settings screen")
+
+	settings := editableSettings()
+
+	table := tview.NewTable().
+		SetBorders(true).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	statusText := tview.NewTextView().
+		SetText(fmt.Sprintf("Saved to %s", pkg.ConfigPath())).
+		SetTextAlign(tview.AlignCenter).
+		SetDynamicColors(true)
+
+	populateTable := func() {
+		headers := []string{"Setting", "Value", "Applies"}
+		for col, header := range headers {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.ColorWhite).
+				SetAlign(tview.AlignCenter).
+				SetSelectable(false).
+				SetAttributes(tcell.AttrBold))
+		}
+
+		for row, s := range settings {
+			applies := "Now"
+			appliesColor := tcell.ColorGreen
+			if s.restart {
+				applies = "After restart"
+				appliesColor = tcell.ColorYellow
+			}
+			table.SetCell(row+1, 0, tview.NewTableCell(s.label).
+				SetTextColor(tcell.ColorWhite))
+			table.SetCell(row+1, 1, tview.NewTableCell(s.get()).
+				SetTextColor(tcell.ColorWhite).
+				SetExpansion(1))
+			table.SetCell(row+1, 2, tview.NewTableCell(applies).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(appliesColor))
+		}
+	}
+	populateTable()
+
+	var horizontal *tview.Flex
+
+	// Store a new value and write config.json, putting the old value back if it fails
+	// the checks LoadConfig makes or can't be saved
+	applySetting := func(s setting, value string) error {
+		previous := s.get()
+		if err := s.set(value); err != nil {
+			return err
+		}
+		revert := func() {
+			if s.isBool {
+				s.set("")
+			} else {
+				s.set(previous)
+			}
+		}
+		// Validate corrects in place, so check a copy and refuse anything it would change
+		candidate := pkg.Config
+		candidate.Tests = slices.Clone(pkg.Config.Tests)
+		if corrections := candidate.Validate(); len(corrections) > 0 {
+			revert()
+			return fmt.Errorf("not saved - %s", corrections[0])
+		}
+		if err := pkg.SaveConfig(pkg.ConfigPath()); err != nil {
+			revert()
+			return fmt.Errorf("failed to save %s: %v", pkg.ConfigPath(), err)
+		}
+
+		logger.Info.Printf("Setting changed by %s: %s = %q (was %q)", user.Name, s.label, s.get(), previous)
+		message := fmt.Sprintf("[green]Saved %s = %s[-]", s.label, s.get())
+		if s.restart {
+			message = fmt.Sprintf("[yellow]Saved %s - restart the app for it to take effect[-]", s.label)
+		}
+		populateTable()
+		flashStatus(app, statusText, message, func() string { return fmt.Sprintf("Saved to %s", pkg.ConfigPath()) })
+		return nil
+	}
+
+	// Ask for a new value in a small form over the list
+	editSetting := func(s setting) {
+		backToList := func() {
+			app.SetRoot(horizontal, true)
+			app.SetFocus(table)
+		}
+
+		editForm := tview.NewForm()
+		// Values such as "^(B|BH|TP)-" or "lp -d lab" need '-', '*' and '?' typed as-is
+		addTextField(editForm, s.label, s.get(), 30)
+		editForm.AddButton("Save", func() {
+			value, _ := formText(editForm, s.label)
+			if err := applySetting(s, value); err != nil {
+				editForm.SetTitle(fmt.Sprintf(" %s ", err))
+				return
+			}
+			backToList()
+		})
+		editForm.AddButton("Cancel", backToList)
+		editForm.SetCancelFunc(backToList)
+
+		editForm.SetBorder(true).
+			SetTitle(fmt.Sprintf(" Edit %s ", s.label)).
+			SetTitleAlign(tview.AlignCenter).
+			SetBorderColor(tcell.ColorYellow).
+			SetBackgroundColor(tcell.ColorBlack)
+
+		editForm.SetFieldBackgroundColor(tcell.ColorBlack).
+			SetFieldTextColor(tcell.ColorWhite).
+			SetButtonBackgroundColor(tcell.ColorWhite).
+			SetButtonTextColor(tcell.ColorBlack).
+			SetLabelColor(tcell.ColorWhite).
+			SetBackgroundColor(tcell.ColorBlack)
+
+		// Center the form
+		modal := tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(editForm, 9, 0, true).
+				AddItem(nil, 0, 1, false), 70, 0, true).
+			AddItem(nil, 0, 1, false)
+		app.SetRoot(modal, true)
+		app.SetFocus(editForm)
+	}
+
+	table.SetSelectedFunc(func(row, column int) {
+		if row == 0 || row > len(settings) {
+			return
+		}
+		s := settings[row-1]
+		if s.isBool {
+			if err := applySetting(s, ""); err != nil {
+				ShowError(app, err, horizontal, table)
+			}
+			return
+		}
+		editSetting(s)
+	})
+
+	// Instructions text
+	instructions := tview.NewTextView().
+		SetText("Up/Down: Navigate  |  Enter: Change  |  +: Back to LMS\n" +
+			"Key remaps, ovens and the sheet layouts are edited in config.json").
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorWhite)
+
+	// Container
+	container := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(statusText, 1, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(instructions, 2, 0, false)
+
+	container.SetBorder(true).
+		SetTitle(userTitle("Settings", user)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.ColorWhite)
+
+	// Center it
+	vertical := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(container, 0, 4, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(vertical, 0, 3, true).
+		AddItem(nil, 0, 1, false)
+
+	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '+' {
+			onBack()
+			return nil
+		}
+		return event
+	})
+
+	return horizontal, table
+}
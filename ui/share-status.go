@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/logger"
+	"lms-tui/pkg"
+)
+
+// shareCheckInterval is how often the background check looks at the share
+const shareCheckInterval = 5 * time.Second
+
+var (
+	shareStatus     *pkg.ShareStatus // nil until the first check finishes
+	shareReplayNote string           // What happened to writes held while the share was down
+	shareStatusView *tview.TextView
+)
+
+// StartShareStatus starts checking the share in the background and redraws the status line
+// whenever it drops or comes back. When it comes back, writes held while it was down are saved.
+// Call the returned func on exit to stop checking.
+func StartShareStatus(app *tview.Application) (stop func()) {
+	return pkg.WatchShare(shareCheckInterval, func(status pkg.ShareStatus) {
+		// Writers are only used from the UI goroutine, so replay there too
+		app.QueueUpdateDraw(func() {
+			shareStatus = &status
+			shareReplayNote = ""
+			if !status.Reachable {
+				return
+			}
+			saved, err := pkg.ReplayAllPendingWrites()
+			switch {
+			case err != nil:
+				logger.Error.Printf("Failed to save held writes after the share came back: %v", err)
+				shareReplayNote = fmt.Sprintf(" — held writes still not saved: %v", err)
+			case saved > 0:
+				logger.Info.Printf("Saved %d held writes after the share came back", saved)
+				shareReplayNote = fmt.Sprintf(" — saved %d held writes", saved)
+			}
+		})
+	})
+}
+
+// DrawShareStatus draws the share status on the bottom line of the screen, green while
+// ProjectRoot is reachable and writable, red with a warning to stop entering data when it isn't.
+// It is called from the application's after-draw function alongside the help overlay.
+func DrawShareStatus(screen tcell.Screen) {
+	if shareStatus == nil {
+		return
+	}
+
+	if shareStatusView == nil {
+		shareStatusView = tview.NewTextView().SetTextAlign(tview.AlignCenter)
+	}
+	if shareStatus.Reachable {
+		shareStatusView.SetText(fmt.Sprintf("● Share OK: %s%s", shareStatus.Detail, shareReplayNote)).
+			SetTextColor(tcell.ColorGreen).
+			SetBackgroundColor(tcell.ColorBlack)
+	} else {
+		shareStatusView.SetText(fmt.Sprintf("● SHARE UNREACHABLE since %s — stop entering data until it recovers (%s)",
+			shareStatus.CheckedAt.In(pkg.Location()).Format("3:04:05 PM"), shareStatus.Detail)).
+			SetTextColor(tcell.ColorWhite).
+			SetBackgroundColor(tcell.ColorRed)
+	}
+
+	screenWidth, screenHeight := screen.Size()
+	shareStatusView.SetRect(0, screenHeight-1, screenWidth, 1)
+	shareStatusView.Draw(screen)
+}
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// statusFlashDuration is how long a flashed status message stays up
+const statusFlashDuration = 3 * time.Second
+
+// flashStatus shows message in view briefly, then puts back whatever restore returns.
+// restore is called when the message expires so it reflects the screen's state at that time.
+func flashStatus(app *tview.Application, view *tview.TextView, message string, restore func() string) {
+	view.SetText(message)
+	time.AfterFunc(statusFlashDuration, func() {
+		app.QueueUpdateDraw(func() {
+			// Leave it alone if something else has replaced the message since
+			if view.GetText(false) == message {
+				view.SetText(restore())
+			}
+		})
+	})
+}
@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"lms-tui/pkg"
+)
+
+// AddTrainingBanner adds a one-line warning to the top of flex when dry run
+// (training mode) is on, so nobody mistakes practice entries for real ones.
+// Call it before adding the screen's other items.
+func AddTrainingBanner(flex *tview.Flex) {
+	if !pkg.Config.DryRun {
+		return
+	}
+	banner := tview.NewTextView().
+		SetText("TRAINING MODE — not writing to Excel").
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(tcell.ColorBlack).
+		SetBackgroundColor(tcell.ColorYellow)
+	flex.AddItem(banner, 1, 0, false)
+}
@@ -0,0 +1,17 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"lms-tui/pkg"
+)
+
+// userTitle returns a border title naming who is logged in, e.g. " LMS — jsmith ", so anyone
+// at a shared machine (or a manager looking over a shoulder) can see whose data is going in
+func userTitle(title string, user *pkg.User) string {
+	if user == nil {
+		return fmt.Sprintf(" %s ", title)
+	}
+	return fmt.Sprintf(" %s — %s ", title, tview.Escape(user.ID))
+}
@@ -1,6 +1,10 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"lms-tui/logger"
@@ -8,12 +12,21 @@ import (
 	"lms-tui/pkg"
 )
 
-func NewViewJobScreen(app *tview.Application, onBack func()) (tview.Primitive, *tview.Table) {
+func NewViewJobScreen(app *tview.Application, user *pkg.User, onBack func()) (tview.Primitive, *tview.Table) {
+	SetScreenShortcuts("View Jobs", []Shortcut{
+		{"Up/Down", "Navigate"},
+		{"Enter", "View job samples"},
+		{"0-9", "Type a job number to jump to it"},
+		{"r", "Refresh the job list"},
+		{"h", "Put the job on hold / release it (lab managers)"},
+		{"o", "Reopen the job for samples added to its Lab file (lab managers)"},
+		{"+", "Back to LMS"},
+	})
 
 	// Dynamically discover jobs from projects folder
-	jobs, err := pkg.DiscoverJobs()
-	if err != nil {
-		logger.Error.Printf("Failed to discover jobs: %v", err)
+	jobs, discoverErr := pkg.DiscoverJobs()
+	if discoverErr != nil {
+		logger.Error.Printf("Failed to discover jobs: %v", discoverErr)
 		jobs = []models.Job{}
 	}
 
@@ -23,7 +36,7 @@ func NewViewJobScreen(app *tview.Application, onBack func()) (tview.Primitive, *
 		SetFixed(1, 0) // Fix header row so it doesn't scroll
 
 	// Set headers with better styling
-	headers := []string{"Project #", "Project Name", "Engineer", "Assigned", "Due Date"}
+	headers := []string{"Project #", "Project Name", "Engineer", "Assigned", "Due Date", "Status"}
 	for col, header := range headers {
 		cell := tview.NewTableCell(header).
 			SetTextColor(tcell.ColorWhite).
@@ -34,38 +47,81 @@ func NewViewJobScreen(app *tview.Application, onBack func()) (tview.Primitive, *
 	}
 
 	// Populate table with job data
-	for row, job := range jobs {
-		// Project Number
-		table.SetCell(row+1, 0, tview.NewTableCell(job.ProjectNumber).
-			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite))
+	populateTable := func() {
+		// Clear everything below the header
+		for row := table.GetRowCount() - 1; row > 0; row-- {
+			table.RemoveRow(row)
+		}
 
-		// Project Name
-		table.SetCell(row+1, 1, tview.NewTableCell(job.ProjectName).
-			SetTextColor(tcell.ColorWhite).
-			SetExpansion(2)) // Give more space to project name
+		labels := jobVersionLabels(jobs, jobs)
+		for row, job := range jobs {
+			// On-hold jobs are greyed out (discovery already sorts them to the bottom)
+			textColor := tcell.ColorWhite
+			if job.OnHold {
+				textColor = tcell.ColorGray
+			}
 
-		// Engineer Initials
-		table.SetCell(row+1, 2, tview.NewTableCell(job.EngineerInitials).
-			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite))
+			// Project Number
+			table.SetCell(row+1, 0, tview.NewTableCell(labels[row]).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(textColor))
 
-		// Date Assigned
-		table.SetCell(row+1, 3, tview.NewTableCell(job.FormatDateAssigned()).
-			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite))
+			// Project Name
+			table.SetCell(row+1, 1, tview.NewTableCell(job.ProjectName).
+				SetTextColor(textColor).
+				SetExpansion(2)) // Give more space to project name
 
-		// Due Date
-		table.SetCell(row+1, 4, tview.NewTableCell(job.FormatDueDate()).
-			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite))
+			// Engineer Initials
+			table.SetCell(row+1, 2, tview.NewTableCell(job.EngineerInitials).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(textColor))
+
+			// Date Assigned
+			table.SetCell(row+1, 3, tview.NewTableCell(job.FormatDateAssigned()).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(textColor))
+
+			// Due Date
+			table.SetCell(row+1, 4, tview.NewTableCell(job.FormatDueDate()).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(textColor))
+
+			// Completed or on-hold badge
+			status := ""
+			statusColor := tcell.ColorGreen
+			if job.OnHold {
+				status = "On Hold"
+				if job.HoldReason != "" {
+					status += ": " + job.HoldReason
+				}
+				statusColor = tcell.ColorGray
+			} else if progress, err := pkg.LoadProgressData(job.ProjectNumber); err == nil && progress.Completed {
+				status = "✓ Completed"
+			}
+			// Two folders claim this job number, so make it clear which one is being shown
+			if warning := job.DuplicateWarning(); warning != "" {
+				status = warning
+				statusColor = tcell.ColorRed
+			}
+			table.SetCell(row+1, 5, tview.NewTableCell(status).
+				SetAlign(tview.AlignCenter).
+				SetTextColor(statusColor))
+		}
+
+		if len(jobs) == 0 {
+			message, color := noJobsMessage(discoverErr, "No jobs found")
+			table.SetCell(1, 0, tview.NewTableCell(message).
+				SetTextColor(color).
+				SetSelectable(false))
+		}
 	}
+	populateTable()
 
 	// Handle job selection function
 	selectJob := func() {
 		row, _ := table.GetSelection()
 		// Skip header row
-		if row == 0 {
+		if row == 0 || row > len(jobs) {
 			return
 		}
 		// Get the selected job
@@ -73,9 +129,9 @@ func NewViewJobScreen(app *tview.Application, onBack func()) (tview.Primitive, *
 		logger.Info.Printf("Job selected: %s - %s", selectedJob.ProjectNumber, selectedJob.ProjectName)
 
 		// Navigate to job detail screen
-		detailScreen := NewJobDetailScreen(app, selectedJob, func() {
+		detailScreen := NewJobDetailScreen(app, user, selectedJob, func() {
 			// Go back to view jobs screen
-			viewJobScreen, viewJobTable := NewViewJobScreen(app, onBack)
+			viewJobScreen, viewJobTable := NewViewJobScreen(app, user, onBack)
 			app.SetRoot(viewJobScreen, true)
 			app.SetFocus(viewJobTable)
 		})
@@ -96,7 +152,7 @@ func NewViewJobScreen(app *tview.Application, onBack func()) (tview.Primitive, *
 
 	// Instructions text
 	instructions := tview.NewTextView().
-		SetText("Up/Down: Navigate  |  +: Back to Home  |  Enter: Select").
+		SetText("Up/Down: Navigate  |  +: Back to Home  |  Enter: Select  |  r: Refresh  |  h: Hold/Release  |  o: Reopen").
 		SetTextAlign(tview.AlignCenter).
 		SetTextColor(tcell.ColorWhite).
 		SetDynamicColors(true)
@@ -109,7 +165,7 @@ func NewViewJobScreen(app *tview.Application, onBack func()) (tview.Primitive, *
 		AddItem(instructions, 1, 0, false)
 
 	container.SetBorder(true).
-		SetTitle(" Job Management System ").
+		SetTitle(userTitle("Job Management System", user)).
 		SetTitleAlign(tview.AlignCenter).
 		SetBorderColor(tcell.ColorWhite)
 
@@ -125,14 +181,200 @@ func NewViewJobScreen(app *tview.Application, onBack func()) (tview.Primitive, *
 		AddItem(vertical, 0, 3, true). // Takes 3/5 of horizontal space
 		AddItem(nil, 0, 1, false)
 
+	// Re-read the projects folder so newly dropped jobs and hold changes show up
+	refreshJobs := func() error {
+		refreshed, err := pkg.DiscoverJobs()
+		if err != nil && !errors.Is(err, pkg.ErrProjectsDirMissing) {
+			return fmt.Errorf("failed to discover jobs: %v", err)
+		}
+		jobs, discoverErr = refreshed, err
+		populateTable()
+		logger.Info.Printf("Refreshed View Jobs list: %d jobs", len(jobs))
+		return nil
+	}
+
+	// Put a job on hold with a reason, or release it. The hold covers every Lab file
+	// version in the job folder.
+	showHoldModal := func(job models.Job) {
+		backToList := func() {
+			app.SetRoot(horizontal, true)
+			app.SetFocus(table)
+		}
+		setHold := func(onHold bool, reason string) {
+			if err := pkg.SetJobHold(job.FolderName, onHold, reason); err != nil {
+				ShowError(app, fmt.Errorf("failed to update hold for job %s: %v", job.BaseJobNumber, err), horizontal, table)
+				return
+			}
+			backToList()
+			if err := refreshJobs(); err != nil {
+				ShowError(app, err, horizontal, table)
+				return
+			}
+			message := fmt.Sprintf("Job %s released from hold", job.BaseJobNumber)
+			if onHold {
+				message = fmt.Sprintf("Job %s put on hold", job.BaseJobNumber)
+			}
+			flashStatus(app, titleText, message, func() string { return "View Jobs" })
+		}
+
+		if job.OnHold {
+			Confirm(app, fmt.Sprintf("Job %s is on hold.\n\nReason: %s\n\nRelease the hold?",
+				job.BaseJobNumber, job.HoldReason), []string{"Release", "Cancel"}, func(choice int) {
+				if choice == 0 {
+					setHold(false, "")
+				} else {
+					backToList()
+				}
+			})
+			return
+		}
+
+		holdForm := tview.NewForm()
+		addTextField(holdForm, "Reason", "", 40)
+		holdForm.AddButton("Put On Hold", func() {
+			reason, _ := formText(holdForm, "Reason")
+			if reason == "" {
+				holdForm.SetTitle(" A reason is required (e.g. awaiting client info) ")
+				return
+			}
+			setHold(true, reason)
+		})
+		holdForm.AddButton("Cancel", backToList)
+		holdForm.SetCancelFunc(backToList)
+
+		holdForm.SetBorder(true).
+			SetTitle(fmt.Sprintf(" Put Job %s On Hold ", job.BaseJobNumber)).
+			SetTitleAlign(tview.AlignCenter).
+			SetBorderColor(tcell.ColorYellow).
+			SetBackgroundColor(tcell.ColorBlack)
+
+		holdForm.SetFieldBackgroundColor(tcell.ColorBlack).
+			SetFieldTextColor(tcell.ColorWhite).
+			SetButtonBackgroundColor(tcell.ColorWhite).
+			SetButtonTextColor(tcell.ColorBlack).
+			SetLabelColor(tcell.ColorWhite).
+			SetBackgroundColor(tcell.ColorBlack)
+
+		// Center the form
+		modal := tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(holdForm, 9, 0, true).
+				AddItem(nil, 0, 1, false), 60, 0, true).
+			AddItem(nil, 0, 1, false)
+		app.SetRoot(modal, true)
+		app.SetFocus(holdForm)
+	}
+
+	// Reopen a job whose Lab file gained samples after it was finished, so only the
+	// added samples are pulled
+	showReopenModal := func(job models.Job) {
+		backToList := func() {
+			app.SetRoot(horizontal, true)
+			app.SetFocus(table)
+		}
+		reopen := func() {
+			// Hold the pull session so the working copy isn't rebuilt under an open pull
+			if err := pkg.ClaimPullSession(job.ProjectNumber); err != nil {
+				ShowError(app, err, horizontal, table)
+				return
+			}
+			result, err := pkg.ReopenJobForNewSamples(job.ProjectNumber, job.LabFilePath)
+			pkg.ReleasePullSession(job.ProjectNumber)
+			if err != nil {
+				ShowError(app, fmt.Errorf("failed to reopen job %s: %v", job.ProjectNumber, err), horizontal, table)
+				return
+			}
+			logger.Info.Printf("Job %s reopened for new samples by %s", job.ProjectNumber, user.Name)
+
+			var summary strings.Builder
+			if len(result.NewSamples) == 0 {
+				summary.WriteString("No new samples were found on the Main Form.\n")
+			} else {
+				summary.WriteString(fmt.Sprintf("%d new sample(s) to pull:\n", len(result.NewSamples)))
+				for i, sample := range result.NewSamples {
+					if i == 10 {
+						summary.WriteString(fmt.Sprintf("...and %d more\n", len(result.NewSamples)-10))
+						break
+					}
+					summary.WriteString(fmt.Sprintf("%s  %s\n", sample.BoringNumber, sample.Depth))
+				}
+			}
+			if result.NewColumns > 0 {
+				summary.WriteString(fmt.Sprintf("\nThe working Lab file was rebuilt with %d new Moisture column(s).\n", result.NewColumns))
+			}
+			dismiss := func() {
+				backToList()
+				if err := refreshJobs(); err != nil {
+					ShowError(app, err, horizontal, table)
+				}
+			}
+			app.SetRoot(newAlertModal(fmt.Sprintf("Job %s reopened\n\n%s\nPull Job now visits only the new samples.", job.ProjectNumber, summary.String()), dismiss), true)
+		}
+
+		Confirm(app, fmt.Sprintf("Reopen job %s for added samples?\n\n"+
+			"The Lab file is read again and only samples not yet recorded will be pulled.", job.ProjectNumber), []string{"Reopen", "Cancel"}, func(choice int) {
+			if choice == 0 {
+				reopen()
+			} else {
+				backToList()
+			}
+		})
+	}
+
+	jumpToJob := newJobJump(app, table, titleText, func() []string { return projectNumbers(jobs) },
+		func() string { return "View Jobs" })
+
 	// Input capture for navigation
 	horizontal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if jumpToJob(event) {
+			return nil
+		}
 		if event.Rune() == '+' {
 			onBack()
 			return nil
 		}
+		if event.Rune() == 'r' {
+			if err := refreshJobs(); err != nil {
+				ShowError(app, err, horizontal, table)
+				return nil
+			}
+			table.Select(1, 0)
+			flashStatus(app, titleText, fmt.Sprintf("Refreshed — %d jobs", len(jobs)), func() string { return "View Jobs" })
+			return nil
+		}
+		if event.Rune() == 'h' {
+			row, _ := table.GetSelection()
+			if row == 0 || row > len(jobs) {
+				return nil
+			}
+			if !user.IsManager() {
+				flashStatus(app, titleText, "Only lab managers can put jobs on hold", func() string { return "View Jobs" })
+				return nil
+			}
+			showHoldModal(jobs[row-1])
+			return nil
+		}
+		if event.Rune() == 'o' {
+			row, _ := table.GetSelection()
+			if row == 0 || row > len(jobs) {
+				return nil
+			}
+			if !user.IsManager() {
+				flashStatus(app, titleText, "Only lab managers can reopen jobs", func() string { return "View Jobs" })
+				return nil
+			}
+			showReopenModal(jobs[row-1])
+			return nil
+		}
 		return event
 	})
 
+	// A missing projects folder is explained in the list itself
+	if discoverErr != nil && !errors.Is(discoverErr, pkg.ErrProjectsDirMissing) {
+		QueueError(app, fmt.Errorf("failed to discover jobs: %v", discoverErr), horizontal, table)
+	}
+
 	return horizontal, table
 }
\ No newline at end of file